@@ -0,0 +1,208 @@
+/*
+Package data - Handles functions related to data source access e.g. cache, databases
+*/
+package data
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/thegeniusgroup/isgdatalib"
+)
+
+// alertRuleColumns : columns of isg_geniusodds_alert_rules in the order of scanGeniusOddsAlertRules
+const alertRuleColumns = "rule_id, user_id, rule_type, sport_id, league_id, match_id, IFNULL(market,''), team_id, IFNULL(provider_id,''), threshold, " +
+	" IFNULL(direction,''), notifier, IFNULL(target,''), cooldown_mins, status "
+
+// GetGeniusOddsAlertRules : active alert rules of every user, used by the alert evaluator
+func GetGeniusOddsAlertRules() ([]isg.GeniusOddsAlertRule, error) {
+
+	rows, err := UserDb.Query("SELECT "+alertRuleColumns+" FROM isg_geniusodds_alert_rules WHERE status = ? ORDER BY rule_id ", 1)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanGeniusOddsAlertRules(rows)
+}
+
+// GetGeniusOddsAlertRulesByUser : active alert rules of a user
+func GetGeniusOddsAlertRulesByUser(userID string) ([]isg.GeniusOddsAlertRule, error) {
+
+	rows, err := UserDb.Query("SELECT "+alertRuleColumns+" FROM isg_geniusodds_alert_rules WHERE user_id = ? AND status = ? ORDER BY rule_id ", userID, 1)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanGeniusOddsAlertRules(rows)
+}
+
+// scanGeniusOddsAlertRules :
+func scanGeniusOddsAlertRules(rows *sql.Rows) ([]isg.GeniusOddsAlertRule, error) {
+
+	var rules []isg.GeniusOddsAlertRule
+	for rows.Next() {
+		var rule isg.GeniusOddsAlertRule
+		err := rows.Scan(
+			&rule.RuleID,
+			&rule.UserID,
+			&rule.RuleType,
+			&rule.SportID,
+			&rule.LeagueID,
+			&rule.MatchID,
+			&rule.Market,
+			&rule.TeamID,
+			&rule.ProviderID,
+			&rule.Threshold,
+			&rule.Direction,
+			&rule.Notifier,
+			&rule.Target,
+			&rule.Cooldown,
+			&rule.Status,
+		)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+
+	return rules, nil
+}
+
+// InsertGeniusOddsAlertRule : stores a new alert rule and returns its id
+func InsertGeniusOddsAlertRule(rule isg.GeniusOddsAlertRule) (int64, error) {
+
+	result, err := UserDb.Exec("INSERT INTO isg_geniusodds_alert_rules SET user_id = ?, rule_type = ?, sport_id = ?, league_id = ?, match_id = ?, market = ?, "+
+		" team_id = ?, provider_id = ?, threshold = ?, direction = ?, notifier = ?, target = ?, cooldown_mins = ?, status = ?, dateadded = ? ",
+		rule.UserID, rule.RuleType, rule.SportID, rule.LeagueID, rule.MatchID, rule.Market, rule.TeamID, rule.ProviderID, rule.Threshold, rule.Direction,
+		rule.Notifier, rule.Target, rule.Cooldown, rule.Status, time.Now().In(AEST).Format("2006-01-02 15:04:05"))
+	if err != nil {
+		return 0, err
+	}
+
+	return result.LastInsertId()
+}
+
+// DeleteGeniusOddsAlertRule : switches off a rule of the user, returns false when the rule is not found
+func DeleteGeniusOddsAlertRule(userID string, ruleID int64) (bool, error) {
+
+	result, err := UserDb.Exec("UPDATE isg_geniusodds_alert_rules SET status = ? WHERE rule_id = ? AND user_id = ? AND status = ? ", 0, ruleID, userID, 1)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+// GetGeniusOddsAlertsSent : last sent time of each rule / selection key since the given time, seeds the alert cooldowns
+func GetGeniusOddsAlertsSent(since time.Time) (map[string]time.Time, error) {
+
+	sent := map[string]time.Time{}
+
+	rows, err := UserDb.Query("SELECT dedup_key, sent_at FROM isg_geniusodds_alert_sent WHERE sent_at >= ? ", since.In(AEST).Format("2006-01-02 15:04:05"))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var key, sentAt string
+		err = rows.Scan(&key, &sentAt)
+		if err != nil {
+			return nil, err
+		}
+		sentTime, err := time.ParseInLocation("2006-01-02 15:04:05", sentAt, AEST)
+		if err != nil {
+			continue
+		}
+		sent[key] = sentTime
+	}
+
+	return sent, nil
+}
+
+// InsertGeniusOddsAlertSent : records a sent alert for the cooldown (unique key on dedup_key)
+func InsertGeniusOddsAlertSent(objAlert isg.GeniusOddsAlert, dedupKey string, sentAt time.Time) error {
+
+	sentAtStr := sentAt.In(AEST).Format("2006-01-02 15:04:05")
+	_, err := UserDb.Exec("INSERT INTO isg_geniusodds_alert_sent SET dedup_key = ?, rule_id = ?, user_id = ?, match_id = ?, message = ?, sent_at = ? "+
+		" ON DUPLICATE KEY UPDATE message = ?, sent_at = ? ",
+		dedupKey, objAlert.RuleID, objAlert.UserID, objAlert.MatchID, objAlert.Message, sentAtStr, objAlert.Message, sentAtStr)
+	return err
+}
+
+// InsertGeniusOddsAlertDeadLetter : records an alert given up on after its failed attempts, with the last error
+func InsertGeniusOddsAlertDeadLetter(objAlert isg.GeniusOddsAlert, rule isg.GeniusOddsAlertRule, dedupKey string, attempts int, lastError string, deadAt time.Time) error {
+
+	if len(lastError) > 500 {
+		lastError = lastError[:500]
+	}
+	_, err := UserDb.Exec("INSERT INTO isg_geniusodds_alert_dead SET dedup_key = ?, rule_id = ?, user_id = ?, match_id = ?, notifier = ?, target = ?, "+
+		" message = ?, last_update = ?, attempts = ?, last_error = ?, dead_at = ? ",
+		dedupKey, objAlert.RuleID, objAlert.UserID, objAlert.MatchID, rule.Notifier, rule.Target, objAlert.Message, objAlert.LastUpdate,
+		attempts, lastError, deadAt.In(AEST).Format("2006-01-02 15:04:05"))
+	return err
+}
+
+// GetGeniusOddsFlucEvents : fluc rows added after from up to to, with the previous and opening price of the same provider selection
+func GetGeniusOddsFlucEvents(from, to time.Time) ([]isg.GeniusOddsFlucEvent, error) {
+
+	var flucs []isg.GeniusOddsFlucEvent
+
+	seriesStr := " FROM isg_geniusodds_marketodds_flucs series WHERE series.match_id = oddsfluc.match_id AND series.market_id = oddsfluc.market_id " +
+		" AND series.team_id = oddsfluc.team_id AND series.provider_id = oddsfluc.provider_id AND IFNULL(series.player_id, 0) = 0 "
+	prevStr := " AND series.last_update < oddsfluc.last_update ORDER BY series.last_update DESC LIMIT 1) "
+	openStr := " ORDER BY series.last_update ASC LIMIT 1) "
+
+	sqlstr := "SELECT oddsfluc.match_id, marketodds.sport_id, marketodds.league_level_id, oddsfluc.market_id, market.isg_api_id, IFNULL(marketcategory.category_name,''), " +
+		" oddsfluc.team_id, oddsfluc.provider_id, IFNULL(provider.provider_name,''), oddsfluc.market_price, oddsfluc.market_val, " +
+		" (SELECT series.market_price " + seriesStr + prevStr + ", (SELECT series.market_val " + seriesStr + prevStr + ", " +
+		" (SELECT series.market_price " + seriesStr + openStr + ", (SELECT series.market_val " + seriesStr + openStr + ", oddsfluc.last_update " +
+		" FROM isg_geniusodds_marketodds_flucs oddsfluc " +
+		" INNER JOIN isg_geniusodds_marketodds marketodds ON marketodds.match_id = oddsfluc.match_id AND marketodds.market_id = oddsfluc.market_id " +
+		" AND marketodds.team_id = oddsfluc.team_id AND marketodds.provider_id = oddsfluc.provider_id AND IFNULL(marketodds.player_id, 0) = 0 " +
+		" INNER JOIN isg_market market ON market.market_id = oddsfluc.market_id " +
+		" LEFT JOIN isg_market_category marketcategory ON marketcategory.category_id = market.category_id " +
+		" LEFT JOIN isg_providers provider ON provider.provider_id = oddsfluc.provider_id " +
+		" WHERE oddsfluc.last_update > ? AND oddsfluc.last_update <= ? AND oddsfluc.provider_id != ? AND IFNULL(oddsfluc.player_id, 0) = 0 " +
+		" ORDER BY oddsfluc.last_update, oddsfluc.match_id, oddsfluc.market_id "
+
+	rows, err := SportsDb.Query(sqlstr, CounterDateTimeString(from), CounterDateTimeString(to), 4)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var fluc isg.GeniusOddsFlucEvent
+		err = rows.Scan(
+			&fluc.MatchID,
+			&fluc.SportID,
+			&fluc.LeagueID,
+			&fluc.MarketID,
+			&fluc.ISGapiID,
+			&fluc.CategoryName,
+			&fluc.TeamID,
+			&fluc.ProviderID,
+			&fluc.ProviderName,
+			&fluc.Price,
+			&fluc.Val,
+			&fluc.PrevPrice,
+			&fluc.PrevVal,
+			&fluc.OpenPrice,
+			&fluc.OpenVal,
+			&fluc.LastUpdate,
+		)
+		if err != nil {
+			return nil, err
+		}
+		flucs = append(flucs, fluc)
+	}
+
+	return flucs, nil
+}
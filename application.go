@@ -1,6 +1,7 @@
 package main
 
 import (
+	"api/sports"
 	"data"
 	"fmt"
 	"log"
@@ -8,6 +9,7 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	_ "github.com/go-sql-driver/mysql"
 	"github.com/julienschmidt/httprouter"
@@ -77,12 +79,108 @@ func main() {
 	// Preload Sports and Leagues for quick lookup
 	preloadCachedLookupData()
 
+	// Rebuild the team name resolvers when the teams change, every 10 minutes unless an interval is set
+	resolverInterval, err := strconv.Atoi(os.Getenv("ENTITY_RESOLVER_REFRESH_INTERVAL"))
+	if err != nil || resolverInterval <= 0 {
+		resolverInterval = 10
+	}
+	go sports.StartEntityResolverRefresh(time.Duration(resolverInterval) * time.Minute)
+
+	// Sports served by genius odds, sport api ids e.g. ar,rl
+	geniusOddsSports := os.Getenv("GENIUSODDS_SPORTS")
+	if geniusOddsSports != "" {
+		data.GeniusOddsSportIDs, err = data.ParseGeniusOddsSports(geniusOddsSports, data.SportObjects)
+		if err != nil {
+			log.Panic(err)
+		}
+	}
+
+	// Publish the genius odds json snapshots for the CDN
+	snapshotBucket := os.Getenv("GENIUSODDS_SNAPSHOT_BUCKET")
+	if snapshotBucket != "" {
+		snapshotInterval, err := strconv.Atoi(os.Getenv("GENIUSODDS_SNAPSHOT_INTERVAL"))
+		if err != nil || snapshotInterval <= 0 {
+			snapshotInterval = 5
+		}
+		go sports.StartGeniusOddsPublisher(snapshotBucket, time.Duration(snapshotInterval)*time.Minute)
+	}
+
+	// Archive the genius odds closing lines of started matches
+	closingInterval, err := strconv.Atoi(os.Getenv("GENIUSODDS_CLOSING_INTERVAL"))
+	if err == nil && closingInterval > 0 {
+		go sports.StartGeniusOddsClosingLineArchive(time.Duration(closingInterval) * time.Minute)
+	}
+
+	// Settle the finished genius odds matches for the provider leaderboard
+	settleInterval, err := strconv.Atoi(os.Getenv("GENIUSODDS_SETTLE_INTERVAL"))
+	if err == nil && settleInterval > 0 {
+		go sports.StartGeniusOddsSettlement(time.Duration(settleInterval) * time.Minute)
+	}
+
+	// Rebuild the genius odds model ratings from the results
+	modelInterval, err := strconv.Atoi(os.Getenv("GENIUSODDS_MODEL_INTERVAL"))
+	if err == nil && modelInterval > 0 {
+		go sports.StartGeniusOddsModel(time.Duration(modelInterval) * time.Minute)
+	}
+
+	// Sessions of the genius odds user and admin routes, signed with the portal's shared secret
+	sports.InitGeniusOddsAuth(os.Getenv("GENIUSODDS_AUTH_SECRET"), strings.Split(os.Getenv("GENIUSODDS_ADMIN_USERS"), ","))
+
+	// Alert webhooks may only post to these hosts, alert emails only go to these addresses and domains
+	sports.GeniusOddsWebhookHosts = strings.Split(os.Getenv("GENIUSODDS_ALERT_WEBHOOK_HOSTS"), ",")
+	sports.GeniusOddsEmailAllowlist = strings.Split(os.Getenv("GENIUSODDS_ALERT_EMAIL_ALLOWLIST"), ",")
+
+	// Check the genius odds alert rules against the new flucs, email goes to a local SMTP stand-in unless one is set
+	alertInterval, err := strconv.Atoi(os.Getenv("GENIUSODDS_ALERT_INTERVAL"))
+	if err == nil && alertInterval > 0 {
+		smtpAddr := os.Getenv("GENIUSODDS_ALERT_SMTP_ADDR")
+		if smtpAddr == "" {
+			smtpAddr = "localhost:1025"
+		}
+		smtpFrom := os.Getenv("GENIUSODDS_ALERT_SMTP_FROM")
+		if smtpFrom == "" {
+			smtpFrom = "alerts@localhost"
+		}
+		go sports.StartGeniusOddsAlerts(time.Duration(alertInterval)*time.Minute, sports.NewGeniusOddsNotifiers(smtpAddr, smtpFrom, sports.GeniusOddsWebhookHosts, sports.GeniusOddsEmailAllowlist))
+	}
+
+	// Validate the genius odds provider prices, flagged prices are left out of the best price ranking. Off unless an interval is set.
+	qualityInterval, err := strconv.Atoi(os.Getenv("GENIUSODDS_QUALITY_INTERVAL"))
+	if err == nil && qualityInterval > 0 {
+		qualityConfig := isg.DefaultGeniusOddsQualityConfig
+		staleMinutes, err := strconv.Atoi(os.Getenv("GENIUSODDS_STALE_MINUTES"))
+		if err == nil && staleMinutes > 0 {
+			qualityConfig.StaleAfter = time.Duration(staleMinutes) * time.Minute
+		}
+		outlierPct, err := strconv.ParseFloat(os.Getenv("GENIUSODDS_OUTLIER_PCT"), 64)
+		if err == nil && outlierPct > 0 {
+			qualityConfig.OutlierPct = outlierPct
+		}
+		go sports.StartGeniusOddsQuality(time.Duration(qualityInterval)*time.Minute, qualityConfig)
+	}
+
+	// Forecast the weather of the upcoming genius odds matches, open-meteo or file:<path> for a local forecasts file.
+	// An invalid setting leaves the job off.
+	weatherSetting := os.Getenv("GENIUSODDS_WEATHER_PROVIDER")
+	if weatherSetting != "" {
+		weatherProvider, err := sports.NewWeatherForecastProvider(weatherSetting)
+		if err != nil {
+			log.Println("genius odds weather job not started: " + err.Error())
+		} else {
+			weatherInterval, err := strconv.Atoi(os.Getenv("GENIUSODDS_WEATHER_INTERVAL"))
+			if err != nil || weatherInterval <= 0 {
+				weatherInterval = 180
+			}
+			go sports.StartGeniusOddsWeather(time.Duration(weatherInterval)*time.Minute, weatherProvider)
+		}
+	}
+
 	router := httprouter.New()
 	router.RedirectTrailingSlash = true
 	addRouteHandlers(router)
 	c := cors.New(cors.Options{
 		AllowedOrigins:   []string{"*"},
-		AllowedMethods:   []string{"GET", "POST", "OPTIONS", "Authorization"},
+		AllowedMethods:   []string{"GET", "POST", "DELETE", "OPTIONS", "Authorization"},
 		AllowedHeaders:   []string{"*"},
 		AllowCredentials: true,
 		//Debug:            true,
@@ -100,11 +198,12 @@ func preloadCachedLookupData() {
 	preloadFilters()
 	preloadProviders()
 	preloadCustomers()
+	preloadEntityResolvers()
 }
 
 // Preload Sports
 func preloadSports() {
-	rows, err := data.SportsDb.Query("SELECT sport_api_altname, sport_api_code, sport_name, sport_id, sport_season_tablename, sport_match_tablename, sport_player_tablename,sport_url, sport_logo FROM isg_sports ORDER BY sport_id")
+	rows, err := data.SportsDb.Query("SELECT sport_api_altname, sport_api_code, sport_name, sport_id, sport_season_tablename, sport_match_tablename, sport_player_tablename, IFNULL(sport_ladder_tablename,''), IFNULL(sport_tips_tablename,''), sport_url, sport_logo FROM isg_sports ORDER BY sport_id")
 	if err != nil {
 		log.Panic(err)
 	}
@@ -117,7 +216,7 @@ func preloadSports() {
 		var sport isg.Sport
 		var sportAltName string
 
-		err := rows.Scan(&sportAltName, &sport.SportID, &sport.SportName, &sport.SportInternalID, &sport.TableNameSeasons, &sport.TableNameMatches, &sport.TableNamePlayers, &sport.SportURL, &sport.SportLogo)
+		err := rows.Scan(&sportAltName, &sport.SportID, &sport.SportName, &sport.SportInternalID, &sport.TableNameSeasons, &sport.TableNameMatches, &sport.TableNamePlayers, &sport.TableNameLadder, &sport.TableNameTips, &sport.SportURL, &sport.SportLogo)
 		if err != nil {
 			log.Panic(err)
 		}
@@ -245,6 +344,16 @@ func preloadProviders() {
 
 }
 
+// Preload the team name resolvers of the /resolve/team route
+func preloadEntityResolvers() {
+	err := data.LoadEntityResolvers()
+	if err != nil {
+		fmt.Println(err.Error())
+		return
+	}
+	fmt.Println("Entity resolvers preloaded.")
+}
+
 // Preload customers
 func preloadCustomers() {
 	rows, err := data.SportsDb.Query("SELECT customer_id, api_scope_id, customer_uuid FROM isports_users.tblform_customers ")
@@ -0,0 +1,104 @@
+package sports
+
+import (
+	"data"
+	"fmt"
+	"net/http"
+	"strconv"
+	"util"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/thegeniusgroup/isgdatalib"
+)
+
+// Calendar : seasons of a league with the rounds / weeks of a season, their dates, match counts and the current round.
+// ?season= picks the season, the current season is listed without it. Rounds of the genius odds sports link to the
+// markets route.
+// GET  /calendar/{:sport}/{:league}?season=
+func Calendar(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+
+	sportname := util.CleanText(p.ByName("sport"), true, true)
+	leaguename := util.CleanText(p.ByName("league"), true, true)
+	season := util.CleanText(r.URL.Query().Get("season"), true, true)
+
+	objsport, err := data.GetSport(sportname)
+	if err != nil {
+		util.WebResponse(w, r, http.StatusNotFound, "sport not found")
+		return
+	}
+
+	objleague, err := data.GetLeagueID(objsport, leaguename)
+	if err != nil {
+		util.WebResponse(w, r, http.StatusNotFound, "league not found")
+		return
+	}
+
+	// change season table for cricket
+	if objsport.SportInternalID == 5 && objleague.LeagueInternalID == 2 {
+		objsport.TableNameSeasons = "isg_cricket_single_season"
+	}
+
+	// change season table for soccer worldcup
+	if objsport.SportInternalID == 4 && objleague.LeagueInternalID == 17 {
+		objsport.TableNameSeasons = "isg_soccer_worldcup_season"
+	}
+
+	var seasonID int
+	if season != "" {
+		seasonID, err = data.GetSeasonID(objsport, season)
+		if err != nil || seasonID == 0 {
+			util.WebResponse(w, r, http.StatusNotFound, "season not found")
+			return
+		}
+	} else {
+		seasonID, err = data.GetCurrentSeasonid(objsport, objleague.LeagueInternalID)
+		if err != nil {
+			util.WebResponse(w, r, http.StatusNotFound, "season not found")
+			return
+		}
+	}
+
+	var t isg.Calendar
+	for _, objSeason := range data.GetSportsSeasonList(objsport, "all") {
+		var objCalendarSeason isg.CalendarSeason
+		objCalendarSeason.SeasonID, _ = strconv.Atoi(objSeason.SeasonID)
+		objCalendarSeason.Season = objSeason.SeasonName
+		objCalendarSeason.SeasonURL = objSeason.SeasonURL
+		if objCalendarSeason.SeasonID == seasonID {
+			objCalendarSeason.Current = true
+			t.Season = objSeason.SeasonName
+		}
+		t.Seasons = append(t.Seasons, objCalendarSeason)
+	}
+	if t.Season == "" {
+		t.Season = season
+	}
+
+	t.Rounds, t.RoundType, err = data.GetCalendarRounds(objsport, objleague, seasonID)
+	if err != nil {
+		fmt.Println(err.Error())
+		util.WebResponse(w, r, http.StatusNotFound, "record not found")
+		return
+	}
+
+	t.PlayedMatches, _, err = data.GetMatchCount(objsport, objleague.LeagueInternalID, seasonID)
+	if err != nil {
+		fmt.Println(err.Error())
+	}
+
+	objRound, err := data.GetSportsCurrentRound(objsport, objleague, seasonID)
+	if err == nil {
+		isg.SetCalendarCurrentRound(&t, objRound.RoundWeekID)
+	}
+
+	// the markets route takes the season name of the genius odds sports
+	if data.IsGeniusOddsSport(objsport.SportInternalID) {
+		for i := range t.Rounds {
+			t.Rounds[i].MarketsURL = isg.CalendarMarketsURL(sportname, leaguename, t.Season, t.Rounds[i])
+		}
+	}
+
+	final := util.JSONMessageWrappedObj(http.StatusOK, t)
+	util.WebResponseJSONObjectNoCache(w, r, http.StatusOK, final)
+	return
+}
@@ -0,0 +1,108 @@
+package isg
+
+import (
+	"sort"
+	"strconv"
+)
+
+// CalendarSeason : a season of the calendar, Current is the season the rounds are listed for when none is asked for
+type CalendarSeason struct {
+	SeasonID  int    `json:"season_id"`
+	Season    string `json:"season"`
+	SeasonURL string `json:"season_url,omitempty"`
+	Current   bool   `json:"current,omitempty"`
+}
+
+// CalendarRound : a round / week / season type / match day of a season with its dates in AEST and the match counts.
+// MarketsURL is the genius odds markets route of the round, the team slugs complete it.
+type CalendarRound struct {
+	RoundID    int    `json:"round_id"`
+	Name       string `json:"round_name"`
+	ShortName  string `json:"short_round_name,omitempty"`
+	URL        string `json:"round_url,omitempty"`
+	StartDate  string `json:"start_date"`
+	EndDate    string `json:"end_date"`
+	Matches    int    `json:"matches"`
+	Played     int    `json:"played"`
+	Current    bool   `json:"current,omitempty"`
+	MarketsURL string `json:"markets_url,omitempty"`
+}
+
+// Calendar : seasons of a league with the rounds of the selected season, RoundType is round, week, seasontype or matchday
+type Calendar struct {
+	RoundType     string           `json:"round_type"`
+	Season        string           `json:"season"`
+	PlayedMatches int              `json:"played_matches"`
+	CurrentRound  *int             `json:"current_round,omitempty"`
+	Seasons       []CalendarSeason `json:"seasons"`
+	Rounds        []CalendarRound  `json:"rounds"`
+}
+
+// SetCalendarCurrentRound : flags the current round, false when it is not one of the rounds
+func SetCalendarCurrentRound(objCalendar *Calendar, roundID int) bool {
+	for i := range objCalendar.Rounds {
+		if objCalendar.Rounds[i].RoundID == roundID {
+			objCalendar.Rounds[i].Current = true
+			objCalendar.CurrentRound = &objCalendar.Rounds[i].RoundID
+			return true
+		}
+	}
+	return false
+}
+
+// CalendarMatch : a match of the season with its round, CounterDate is the AEST kick off date
+type CalendarMatch struct {
+	RoundID     int
+	Name        string
+	ShortName   string
+	URL         string
+	CounterDate string
+	Played      bool
+}
+
+// BuildCalendarRounds : rounds of the matches ordered by their first match day then round id, with their last match day
+// and the match counts. Matches without a kick off date are counted but do not move the dates.
+func BuildCalendarRounds(matches []CalendarMatch) []CalendarRound {
+
+	var rounds []CalendarRound
+	index := map[int]int{}
+	for _, match := range matches {
+		i, ok := index[match.RoundID]
+		if !ok {
+			i = len(rounds)
+			index[match.RoundID] = i
+			rounds = append(rounds, CalendarRound{RoundID: match.RoundID, Name: match.Name, ShortName: match.ShortName, URL: match.URL})
+		}
+		objRound := &rounds[i]
+		objRound.Matches++
+		if match.Played {
+			objRound.Played++
+		}
+		if match.CounterDate == "" {
+			continue
+		}
+		if objRound.StartDate == "" || match.CounterDate < objRound.StartDate {
+			objRound.StartDate = match.CounterDate
+		}
+		if match.CounterDate > objRound.EndDate {
+			objRound.EndDate = match.CounterDate
+		}
+	}
+
+	sort.SliceStable(rounds, func(i, j int) bool {
+		if rounds[i].StartDate != rounds[j].StartDate {
+			return rounds[i].StartDate < rounds[j].StartDate
+		}
+		return rounds[i].RoundID < rounds[j].RoundID
+	})
+	return rounds
+}
+
+// CalendarMarketsURL : genius odds markets route of a round e.g. /geniusodds/markets/afl/afl/2024/round-5
+func CalendarMarketsURL(sport, league, season string, objRound CalendarRound) string {
+	round := objRound.URL
+	if round == "" {
+		round = strconv.Itoa(objRound.RoundID)
+	}
+	return "/geniusodds/markets/" + sport + "/" + league + "/" + season + "/" + round
+}
@@ -0,0 +1,55 @@
+package isg
+
+import "testing"
+
+func TestBuildCalendarRounds(t *testing.T) {
+
+	// round 0 is played before round 1 starts, round 2 has a match without a date yet
+	rounds := BuildCalendarRounds([]CalendarMatch{
+		{RoundID: 1, Name: "Round 1", URL: "round-1", CounterDate: "2024-03-14", Played: true},
+		{RoundID: 0, Name: "Opening Round", URL: "opening-round", CounterDate: "2024-03-07", Played: true},
+		{RoundID: 1, Name: "Round 1", URL: "round-1", CounterDate: "2024-03-17"},
+		{RoundID: 0, Name: "Opening Round", URL: "opening-round", CounterDate: "2024-03-10", Played: true},
+		{RoundID: 2, Name: "Round 2", URL: "round-2", CounterDate: "2024-03-21"},
+		{RoundID: 2, Name: "Round 2", URL: "round-2"},
+	})
+
+	want := []CalendarRound{
+		{RoundID: 0, Name: "Opening Round", URL: "opening-round", StartDate: "2024-03-07", EndDate: "2024-03-10", Matches: 2, Played: 2},
+		{RoundID: 1, Name: "Round 1", URL: "round-1", StartDate: "2024-03-14", EndDate: "2024-03-17", Matches: 2, Played: 1},
+		{RoundID: 2, Name: "Round 2", URL: "round-2", StartDate: "2024-03-21", EndDate: "2024-03-21", Matches: 2, Played: 0},
+	}
+	if len(rounds) != len(want) {
+		t.Fatalf("rounds = %+v, want %+v", rounds, want)
+	}
+	for i := range want {
+		if rounds[i] != want[i] {
+			t.Errorf("round %d = %+v, want %+v", i, rounds[i], want[i])
+		}
+	}
+
+	if rounds := BuildCalendarRounds(nil); len(rounds) != 0 {
+		t.Errorf("rounds of no matches = %+v", rounds)
+	}
+}
+
+func TestSetCalendarCurrentRound(t *testing.T) {
+
+	objCalendar := Calendar{Rounds: []CalendarRound{{RoundID: 1}, {RoundID: 2}}}
+	if !SetCalendarCurrentRound(&objCalendar, 2) || objCalendar.CurrentRound == nil || *objCalendar.CurrentRound != 2 || !objCalendar.Rounds[1].Current {
+		t.Errorf("calendar = %+v, want round 2 current", objCalendar)
+	}
+	if SetCalendarCurrentRound(&objCalendar, 3) {
+		t.Error("round 3 set as current")
+	}
+}
+
+func TestCalendarMarketsURL(t *testing.T) {
+
+	if got := CalendarMarketsURL("afl", "afl", "2024", CalendarRound{RoundID: 5, URL: "round-5"}); got != "/geniusodds/markets/afl/afl/2024/round-5" {
+		t.Errorf("url = %s", got)
+	}
+	if got := CalendarMarketsURL("nrl", "nrl", "2024", CalendarRound{RoundID: 5}); got != "/geniusodds/markets/nrl/nrl/2024/5" {
+		t.Errorf("url without a round url = %s", got)
+	}
+}
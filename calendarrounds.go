@@ -0,0 +1,60 @@
+/*
+Package data - Handles functions related to data source access e.g. cache, databases
+*/
+package data
+
+import (
+	"strings"
+
+	"github.com/thegeniusgroup/isgdatalib"
+)
+
+// GetCalendarRounds : rounds / weeks of a league season in date order with their first and last match day, match count and
+// completed matches, built from the matches of the season by isg.BuildCalendarRounds. Returns the round type of the sport.
+// The season total of the played matches is GetMatchCount.
+func GetCalendarRounds(objsport isg.Sport, objleague isg.League, seasonID int) ([]isg.CalendarRound, string, error) {
+
+	var records []isg.CalendarMatch
+
+	// tennis rounds belong to the tournaments and are not listed
+	objRound := GetRoundWeekTable(objsport, objleague)
+	if objRound.Column == "" || objsport.SportID == "te" {
+		return nil, "round", nil
+	}
+
+	matchtable := objsport.TableNameMatches
+	if objsport.SportID == "bb" && objleague.LeagueInternalID == 2 {
+		matchtable = strings.Replace(objsport.TableNameMatches, "_daily_", "_round_", -1)
+	}
+
+	sqlstr := "SELECT matches." + objRound.Column + ", IFNULL(rounds." + objRound.Name + ",''), IFNULL(rounds." + objRound.Short + ",''), IFNULL(rounds." + objRound.URL + ",''), " +
+		" IFNULL(matches.counter_date,''), IFNULL(matches.status = ?,0) " +
+		" FROM " + matchtable + " AS matches " +
+		" LEFT JOIN " + objRound.Table + " AS rounds ON rounds." + objRound.Column + " = matches." + objRound.Column + " AND rounds.season_id = matches.season_id " +
+		" WHERE matches.league_id = ? AND matches.season_id = ? AND matches." + objRound.Column + " IS NOT NULL " +
+		" ORDER BY matches.counter_date, matches." + objRound.Column
+
+	rows, err := SportsDb.Query(sqlstr, "N", objleague.LeagueInternalID, seasonID)
+	if err != nil {
+		return nil, objRound.Type, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var record isg.CalendarMatch
+		err = rows.Scan(
+			&record.RoundID,
+			&record.Name,
+			&record.ShortName,
+			&record.URL,
+			&record.CounterDate,
+			&record.Played,
+		)
+		if err != nil {
+			return nil, objRound.Type, err
+		}
+		records = append(records, record)
+	}
+
+	return isg.BuildCalendarRounds(records), objRound.Type, nil
+}
@@ -0,0 +1,142 @@
+/*
+Package data - Handles functions related to data source access e.g. cache, databases
+*/
+package data
+
+import (
+	"time"
+
+	"github.com/thegeniusgroup/isgdatalib"
+)
+
+// ArchiveGeniusOddsClosingLines : copies each provider's final pre-start price per market into isg_geniusodds_marketodds_closing
+// for the matches started within the lookback window. The open price is the first fluc row and the close price is the last
+// fluc row before the start, so the job can be re-run safely (unique key on match_id, market_id, team_id, provider_id).
+// Selections without a fluc before the start (counter date / time, AEST) are skipped, their live price may be in play.
+func ArchiveGeniusOddsClosingLines(objSport isg.Sport, leagueID int, lookback time.Duration) (int64, error) {
+
+	var sqlstr string
+	sportID := objSport.SportInternalID
+
+	currentDateTime := time.Now().UTC()
+	searchStr := "concat(matches.counter_date, ' ', matches.counter_time) BETWEEN '" + CounterDateTimeString(currentDateTime.Add(-lookback)) + "' AND " +
+		" '" + CounterDateTimeString(currentDateTime) + "' AND "
+
+	flucStr := " FROM isg_geniusodds_marketodds_flucs oddsfluc WHERE oddsfluc.match_id = marketodds.match_id AND oddsfluc.market_id = marketodds.market_id " +
+		" AND oddsfluc.team_id = marketodds.team_id AND oddsfluc.provider_id = marketodds.provider_id "
+	startStr := " AND oddsfluc.last_update <= concat(matches.counter_date, ' ', matches.counter_time) "
+
+	_, _, leagueColumn := geniusOddsMatchColumns(sportID)
+
+	switch {
+
+	case IsGeniusOddsSport(sportID):
+
+		sqlstr = "INSERT INTO isg_geniusodds_marketodds_closing (match_id, sport_id, league_level_id, season_id, provider_id, market_id, team_id, category_name, " +
+			" open_price, open_val, close_price, close_val, match_start, dateadded) " +
+			" SELECT matches.match_id, marketodds.sport_id, marketodds.league_level_id, matches.season_id, marketodds.provider_id, marketodds.market_id, marketodds.team_id, " +
+			" IFNULL(marketcategory.category_name,''), " +
+			" (SELECT oddsfluc.market_price " + flucStr + startStr + " ORDER BY oddsfluc.last_update ASC LIMIT 1), " +
+			" (SELECT oddsfluc.market_val " + flucStr + startStr + " ORDER BY oddsfluc.last_update ASC LIMIT 1), " +
+			" (SELECT oddsfluc.market_price " + flucStr + startStr + " ORDER BY oddsfluc.last_update DESC LIMIT 1), " +
+			" (SELECT oddsfluc.market_val " + flucStr + startStr + " ORDER BY oddsfluc.last_update DESC LIMIT 1), " +
+			" concat(matches.counter_date, ' ', matches.counter_time), ? " +
+			" FROM " + objSport.TableNameMatches + " AS matches " +
+			" INNER JOIN isg_geniusodds_marketodds marketodds ON marketodds.match_id = matches.match_id AND marketodds.sport_id = ? AND marketodds.league_level_id = ? " +
+			" AND marketodds.provider_id != ? " +
+			" INNER JOIN isg_market market ON market.market_id = marketodds.market_id " +
+			" LEFT JOIN isg_market_category marketcategory ON marketcategory.category_id = market.category_id " +
+			" WHERE " + searchStr + " " + leagueColumn + " = ? AND marketodds.`status`= ? AND marketodds.market_price IS NOT NULL " +
+			" AND EXISTS (SELECT 1 " + flucStr + startStr + " AND oddsfluc.market_price IS NOT NULL) " +
+			" ON DUPLICATE KEY UPDATE open_price = VALUES(open_price), open_val = VALUES(open_val), close_price = VALUES(close_price), " +
+			" close_val = VALUES(close_val), match_start = VALUES(match_start), dateadded = VALUES(dateadded) "
+	default:
+		return 0, nil
+	}
+
+	res, err := SportsDb.Exec(sqlstr, CounterDateTimeString(currentDateTime), sportID, leagueID, 4, leagueID, 1)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+// GetGeniusOddsClosingLineValue : open against close price per provider and market category, aggregated by provider, sport, league and season.
+// leagueID, seasonID and providerID are optional filters, 0 / "" means all.
+func GetGeniusOddsClosingLineValue(objSport isg.Sport, leagueID, seasonID int, providerID string) ([]isg.GeniusOddsClosingLineValue, error) {
+
+	var clvList []isg.GeniusOddsClosingLineValue
+
+	searchStr, args := geniusOddsReportFilter("closing", objSport.SportInternalID, leagueID, seasonID, providerID)
+
+	sqlstr := "SELECT closing.provider_id, IFNULL(provider.provider_name,''), IFNULL(provider.provider_icon,''), IFNULL(provider.genius_odds_sequence, 0), " +
+		" closing.league_level_id, closing.season_id, IFNULL(season.season,''), closing.category_name, COUNT(1), " +
+		" ROUND(AVG(closing.open_price), 2), ROUND(AVG(closing.close_price), 2), " +
+		" ROUND(AVG((closing.open_price / closing.close_price - 1) * 100), 2), " +
+		" SUM(IF(closing.close_price < closing.open_price, 1, 0)), " +
+		" ROUND(AVG(ABS(closing.close_val - closing.open_val)), 2) " +
+		" FROM isg_geniusodds_marketodds_closing AS closing " +
+		" LEFT JOIN isg_providers provider ON provider.provider_id = closing.provider_id " +
+		" LEFT JOIN " + objSport.TableNameSeasons + " AS season ON season.season_id = closing.season_id " +
+		" WHERE " + searchStr + " AND closing.open_price > 0 AND closing.close_price > 0 " +
+		" GROUP BY closing.provider_id, closing.sport_id, closing.league_level_id, closing.season_id, closing.category_name " +
+		" ORDER BY closing.league_level_id, closing.season_id DESC, closing.category_name, provider.genius_odds_sequence "
+
+	rows, err := SportsDb.Query(sqlstr, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var clv isg.GeniusOddsClosingLineValue
+		err = rows.Scan(
+			&clv.ProviderID,
+			&clv.Name,
+			&clv.Icon,
+			&clv.ProviderSequence,
+			&clv.LeagueID,
+			&clv.SeasonID,
+			&clv.Season,
+			&clv.CategoryName,
+			&clv.MarketCount,
+			&clv.AvgOpenOdds,
+			&clv.AvgCloseOdds,
+			&clv.CLVPer,
+			&clv.ShortenedCount,
+			&clv.AvgLineMove,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		clv.SportID = objSport.SportInternalID
+		clvList = append(clvList, clv)
+	}
+
+	return clvList, nil
+}
+
+// geniusOddsReportFilter : where clause and arguments of the closing line and settlement reports of a sport.
+// leagueID, seasonID and providerID are optional, 0 / "" means all.
+func geniusOddsReportFilter(table string, sportID, leagueID, seasonID int, providerID string) (string, []interface{}) {
+
+	searchStr := table + ".sport_id = ?"
+	args := []interface{}{sportID}
+
+	if leagueID != 0 {
+		searchStr += " AND " + table + ".league_level_id = ?"
+		args = append(args, leagueID)
+	}
+	if seasonID != 0 {
+		searchStr += " AND " + table + ".season_id = ?"
+		args = append(args, seasonID)
+	}
+	if providerID != "" {
+		searchStr += " AND " + table + ".provider_id = ?"
+		args = append(args, providerID)
+	}
+
+	return searchStr, args
+}
@@ -0,0 +1,18 @@
+package data
+
+import "testing"
+
+func TestGeniusOddsReportFilter(t *testing.T) {
+
+	searchStr, args := geniusOddsReportFilter("closing", 1, 0, 0, "")
+	if searchStr != "closing.sport_id = ?" || len(args) != 1 || args[0] != 1 {
+		t.Errorf("sport only filter = %q %v", searchStr, args)
+	}
+
+	// the provider id is bound, not pasted into the query
+	searchStr, args = geniusOddsReportFilter("closing", 7, 2, 30, "4 OR 1=1")
+	want := "closing.sport_id = ? AND closing.league_level_id = ? AND closing.season_id = ? AND closing.provider_id = ?"
+	if searchStr != want || len(args) != 4 || args[1] != 2 || args[2] != 30 || args[3] != "4 OR 1=1" {
+		t.Errorf("full filter = %q %v, want %q", searchStr, args, want)
+	}
+}
@@ -289,12 +289,13 @@ func GetAllTeams(sport isg.Sport, league isg.League, seasons string, inclusions
 
 	default:
 		if rankSQL != "" {
-			teamSQL = "SELECT DISTINCT team.isg_api_id, team.team_id, team.team_name, team.short_teamname, team.pinnaclesports, team.unibet, IFNULL(team.abbreviation,''),team.sort_val,team.url,team.icon,IFNULL(rank.ranking,'9999') " +
+			teamSQL = "SELECT DISTINCT team.isg_api_id, team.team_id, team.team_name, team.short_teamname, team.pinnaclesports, team.unibet, IFNULL(team.abbreviation,''),team.sort_val,team.url,team.icon, " +
+				" IFNULL(team.conference,''), IFNULL(team.division,''), IFNULL(rank.ranking,'9999') " +
 				" FROM isg_team team " + rankSQL +
 				" WHERE team.sport_id = " + strconv.Itoa(sport.SportInternalID) +
 				" AND ( team.team_id IN ( " + homeSQL + ") OR team.team_id IN ( " + awaySQL + ") ) ORDER BY rank.ranking ASC "
 		} else {
-			teamSQL = "SELECT DISTINCT isg_api_id, team_id,team_name, short_teamname, pinnaclesports, unibet, abbreviation,sort_val,url,icon" +
+			teamSQL = "SELECT DISTINCT isg_api_id, team_id,team_name, short_teamname, pinnaclesports, unibet, abbreviation,sort_val,url,icon, IFNULL(conference,''), IFNULL(division,'')" +
 				" FROM isg_team team" +
 				" WHERE team.sport_id = " + strconv.Itoa(sport.SportInternalID) +
 				" AND team_id IN ( " + homeSQL + ") ORDER BY team_name "
@@ -321,7 +322,7 @@ func GetAllTeams(sport isg.Sport, league isg.League, seasons string, inclusions
 			}
 		default:
 			if rankSQL != "" {
-				err := rows.Scan(&team.TeamID, &team.TeamInternalID, &team.TeamName, &team.TeamShortName, &team.TeamAltName1, &team.TeamAltName2, &team.Abbreviation, &team.SortVal, &team.TeamURL, &team.TeamFlag, &team.Ranking)
+				err := rows.Scan(&team.TeamID, &team.TeamInternalID, &team.TeamName, &team.TeamShortName, &team.TeamAltName1, &team.TeamAltName2, &team.Abbreviation, &team.SortVal, &team.TeamURL, &team.TeamFlag, &team.Conference, &team.Division, &team.Ranking)
 
 				if err != nil {
 					fmt.Println(err)
@@ -330,7 +331,7 @@ func GetAllTeams(sport isg.Sport, league isg.League, seasons string, inclusions
 					return nil, err
 				}
 			} else {
-				err := rows.Scan(&team.TeamID, &team.TeamInternalID, &team.TeamName, &team.TeamShortName, &team.TeamAltName1, &team.TeamAltName2, &team.Abbreviation, &team.SortVal, &team.TeamURL, &team.TeamFlag)
+				err := rows.Scan(&team.TeamID, &team.TeamInternalID, &team.TeamName, &team.TeamShortName, &team.TeamAltName1, &team.TeamAltName2, &team.Abbreviation, &team.SortVal, &team.TeamURL, &team.TeamFlag, &team.Conference, &team.Division)
 				if err != nil {
 					fmt.Println(err)
 					fmt.Println(teamSQL)
@@ -354,7 +355,7 @@ func GetAllTeams(sport isg.Sport, league isg.League, seasons string, inclusions
 // GetSport returns an object containing essential info of a sport.
 // The sport input can be a name or an Id.
 func GetSport(sport string) (isg.Sport, error) {
-	var tblmatches, tblplayers, tblseasons sql.NullString
+	var tblmatches, tblplayers, tblseasons, tblladder, tbltips sql.NullString
 	var result isg.Sport
 	var query string
 
@@ -378,7 +379,7 @@ func GetSport(sport string) (isg.Sport, error) {
 
 		// sport value is numeric, most likely an ID
 		query = "SELECT sport_id as id, sport_name as name, sport_api_code as apicode, sport_match_tablename as matchtable, " +
-			" sport_player_tablename as playertable, sport_season_tablename as seasontable, sport_url FROM isg_sports WHERE sport_id = ?"
+			" sport_player_tablename as playertable, sport_season_tablename as seasontable, sport_ladder_tablename as laddertable, sport_tips_tablename as tipstable, sport_url FROM isg_sports WHERE sport_id = ?"
 		err = SportsDb.QueryRow(query, sport).Scan(
 			&result.SportInternalID,
 			&result.SportName,
@@ -386,6 +387,8 @@ func GetSport(sport string) (isg.Sport, error) {
 			&tblmatches,
 			&tblplayers,
 			&tblseasons,
+			&tblladder,
+			&tbltips,
 			&result.SportURL,
 		)
 		if err != nil {
@@ -417,7 +420,7 @@ func GetSport(sport string) (isg.Sport, error) {
 		}
 
 		query = "SELECT sport_id as id, sport_name as name, sport_api_code as apicode, sport_match_tablename as matchtable, " +
-			" sport_player_tablename as playertable, sport_season_tablename as seasontable, sport_url FROM isg_sports WHERE sport_name = ?  or sport_api_code = ? or sport_api_altname = ? or sport_url = ? "
+			" sport_player_tablename as playertable, sport_season_tablename as seasontable, sport_ladder_tablename as laddertable, sport_tips_tablename as tipstable, sport_url FROM isg_sports WHERE sport_name = ?  or sport_api_code = ? or sport_api_altname = ? or sport_url = ? "
 		err = SportsDb.QueryRow(query, sport, sport, sport, sport).Scan(
 			&result.SportInternalID,
 			&result.SportName,
@@ -425,6 +428,8 @@ func GetSport(sport string) (isg.Sport, error) {
 			&tblmatches,
 			&tblplayers,
 			&tblseasons,
+			&tblladder,
+			&tbltips,
 			&result.SportURL,
 		)
 		if err != nil {
@@ -445,6 +450,12 @@ func GetSport(sport string) (isg.Sport, error) {
 	if tblseasons.Valid {
 		result.TableNameSeasons = tblseasons.String
 	}
+	if tblladder.Valid {
+		result.TableNameLadder = tblladder.String
+	}
+	if tbltips.Valid {
+		result.TableNameTips = tbltips.String
+	}
 
 	// Cache it for future use
 	resultBytes, err := json.MarshalIndent(result, "", "    ")
@@ -521,6 +532,16 @@ func GetTennisPlayer(levelid int, playerName string) (int, error) {
 	return playerid, nil
 }
 
+// GetTennisTournament : tournament of the level by url, filter name or api id
+func GetTennisTournament(levelid int, tournament string) (int, error) {
+	var tournamentid int
+	err := SportsDb.QueryRow("select tournament_id from isg_tennis_tournament where level_id = ? AND ((tournament_url = ?) OR (filter_name = ?) OR (isg_api_id = ?))", levelid, tournament, tournament, tournament).Scan(&tournamentid)
+	if err != nil {
+		return 0, err
+	}
+	return tournamentid, nil
+}
+
 // GetAFLRoundTeamScore :
 func GetAFLRoundTeamScore(seasons []int, typefunction string, curseasonid, curround int) []isg.AllMatchDetail {
 	var matches []isg.AllMatchDetail
@@ -1263,69 +1284,19 @@ func GetSportsSeasonList(objsport isg.Sport, seasons string) []isg.Season {
 func GetRoundWeekDetails(objsport isg.Sport, objleague isg.League, roundstr string) ([]isg.SportRound, string, error) {
 
 	var objSprotRounds []isg.SportRound
-	var sqlstr, sportweekround string
-	//var weekround int
-	switch objsport.SportID {
-
-	case "ar":
-		sportweekround = "round"
-		sqlstr = "SELECT round_id, round_name, short_round_name, round_url FROM isg_aussie_rules_round WHERE (short_round_name IN ('" + roundstr + "') OR round_url IN ('" + roundstr + "'))"
-
-	case "af":
-		sportweekround = "week"
-		sqlstr = "SELECT week_id, week_name, short_week_name, week_url FROM isg_nfl_week WHERE (short_week_name IN ('" + roundstr + "') OR week_url IN ('" + roundstr + "'))"
-
-	case "bb":
-		if objleague.LeagueInternalID == 1 {
-			sportweekround = "seasontype"
-			sqlstr = "SELECT season_type_id, season_type_name, short_type_name, type_url FROM isg_basketball_season_type WHERE (short_type_name IN ('" + roundstr + "') OR type_url IN ('" + roundstr + "'))"
-		} else {
-			sportweekround = "round"
-			sqlstr = "SELECT round_id, round_name, short_round_name, round_url FROM isg_basketball_round WHERE (short_round_name IN ('" + roundstr + "') OR round_url IN ('" + roundstr + "'))"
-		}
-
-	case "sc":
-
-		if objleague.LeagueInternalID == 10 || objleague.LeagueInternalID == 11 {
-			sportweekround = "matchday"
-			sqlstr = "SELECT match_day_id, match_day, short_match_day_name, match_day_url FROM isg_soccer_match_days WHERE (short_match_day_name IN ('" + roundstr + "') OR match_day_url IN ('" + roundstr + "') OR match_day_group_url IN ('" + roundstr + "'))"
-		} else if objleague.LeagueInternalID == 17 {
-			sportweekround = "week"
-			sqlstr = "SELECT week_id, week, short_week_name, week_url FROM isg_soccer_worldcup_week WHERE (short_week_name IN ('" + roundstr + "')  OR week_url IN ('" + roundstr + "'))"
-		} else {
-			sportweekround = "week"
-			sqlstr = "SELECT week_id, week, short_week_name, week_url FROM isg_soccer_week WHERE (short_week_name IN ('" + roundstr + "') OR week_url IN ('" + roundstr + "'))"
-		}
-
-	case "cr":
-		if objleague.LeagueInternalID == 1 {
-			sportweekround = "round"
-			sqlstr = "SELECT round_id, round_name, short_round_name, round_url FROM isg_cricket_round WHERE (short_round_name IN ('" + roundstr + "') OR round_url IN ('" + roundstr + "'))"
-		} else {
-			sportweekround = "seasontype"
-			sqlstr = "SELECT season_type_id, season_type_name, short_type_name, type_url FROM isg_cricket_season_type WHERE (short_type_name IN ('" + roundstr + "') OR type_url IN ('" + roundstr + "'))"
-		}
-
-	case "te":
-		sportweekround = "round"
-		sqlstr = "SELECT round_id, round_name, short_round_name, round_url FROM isg_tennis_round WHERE (short_round_name IN ('" + roundstr + "') OR round_url IN ('" + roundstr + "'))"
-
-	case "rl":
-		sportweekround = "round"
-		sqlstr = "SELECT round_id, round_name, short_round_name, round_url FROM isg_rugby_league_round WHERE (short_round_name IN ('" + roundstr + "') OR round_url IN ('" + roundstr + "'))"
 
-	case "ih":
-		sportweekround = "week"
-		sqlstr = "SELECT week_id, week_name, short_week_name, week_url FROM isg_hockey_week WHERE (short_week_name IN ('" + roundstr + "') OR week_url IN ('" + roundstr + "'))"
-
-	case "bl":
-		sportweekround = "round"
-		sqlstr = "SELECT round_id, round_name, round_short_name, round_url FROM isg_baseball_round WHERE (round_short_name IN ('" + roundstr + "') OR round_url IN ('" + roundstr + "'))"
+	objRound := GetRoundWeekTable(objsport, objleague)
+	sportweekround := objRound.Type
+	if objRound.Column == "" {
+		return objSprotRounds, sportweekround, nil
+	}
 
-	case "ru":
-		sportweekround = "round"
-		sqlstr = "SELECT round_id, round_name, short_round_name, round_url FROM isg_rugby_union_round WHERE (short_round_name IN ('" + roundstr + "') OR round_url IN ('" + roundstr + "'))"
+	sqlstr := "SELECT " + objRound.Column + ", " + objRound.Name + ", " + objRound.Short + ", " + objRound.URL + " FROM " + objRound.Table +
+		" WHERE (" + objRound.Short + " IN ('" + roundstr + "') OR " + objRound.URL + " IN ('" + roundstr + "')"
+	if objRound.GroupURL != "" {
+		sqlstr += " OR " + objRound.GroupURL + " IN ('" + roundstr + "')"
 	}
+	sqlstr += ")"
 
 	rows, err := SportsDb.Query(sqlstr)
 	if err != nil {
@@ -1349,6 +1320,57 @@ func GetRoundWeekDetails(objsport isg.Sport, objleague isg.League, roundstr stri
 	return objSprotRounds, sportweekround, nil
 }
 
+// RoundWeekTable : round / week / season type / match day of the matches of a sport and the table naming it. Column is
+// the id in both tables, GroupURL the extra url column of the soccer match days.
+type RoundWeekTable struct {
+	Type     string
+	Column   string
+	Table    string
+	Name     string
+	Short    string
+	URL      string
+	GroupURL string
+}
+
+// GetRoundWeekTable : round table of the sport and league read by GetRoundWeekDetails, the calendar and the ladder
+func GetRoundWeekTable(objsport isg.Sport, objleague isg.League) RoundWeekTable {
+
+	switch objsport.SportID {
+	case "ar":
+		return RoundWeekTable{"round", "round_id", "isg_aussie_rules_round", "round_name", "short_round_name", "round_url", ""}
+	case "af":
+		return RoundWeekTable{"week", "week_id", "isg_nfl_week", "week_name", "short_week_name", "week_url", ""}
+	case "bb":
+		if objleague.LeagueInternalID == 1 {
+			return RoundWeekTable{"seasontype", "season_type_id", "isg_basketball_season_type", "season_type_name", "short_type_name", "type_url", ""}
+		}
+		return RoundWeekTable{"round", "round_id", "isg_basketball_round", "round_name", "short_round_name", "round_url", ""}
+	case "sc":
+		if objleague.LeagueInternalID == 10 || objleague.LeagueInternalID == 11 {
+			return RoundWeekTable{"matchday", "match_day_id", "isg_soccer_match_days", "match_day", "short_match_day_name", "match_day_url", "match_day_group_url"}
+		} else if objleague.LeagueInternalID == 17 {
+			return RoundWeekTable{"week", "week_id", "isg_soccer_worldcup_week", "week", "short_week_name", "week_url", ""}
+		}
+		return RoundWeekTable{"week", "week_id", "isg_soccer_week", "week", "short_week_name", "week_url", ""}
+	case "cr":
+		if objleague.LeagueInternalID == 1 {
+			return RoundWeekTable{"round", "round_id", "isg_cricket_round", "round_name", "short_round_name", "round_url", ""}
+		}
+		return RoundWeekTable{"seasontype", "season_type_id", "isg_cricket_season_type", "season_type_name", "short_type_name", "type_url", ""}
+	case "te":
+		return RoundWeekTable{"round", "round_id", "isg_tennis_round", "round_name", "short_round_name", "round_url", ""}
+	case "rl":
+		return RoundWeekTable{"round", "round_id", "isg_rugby_league_round", "round_name", "short_round_name", "round_url", ""}
+	case "ih":
+		return RoundWeekTable{"week", "week_id", "isg_hockey_week", "week_name", "short_week_name", "week_url", ""}
+	case "bl":
+		return RoundWeekTable{"round", "round_id", "isg_baseball_round", "round_name", "round_short_name", "round_url", ""}
+	case "ru":
+		return RoundWeekTable{"round", "round_id", "isg_rugby_union_round", "round_name", "short_round_name", "round_url", ""}
+	}
+	return RoundWeekTable{}
+}
+
 // GetSportsRoundWeek :
 func GetSportsRoundWeek(objsport isg.Sport, objleague isg.League, roundfilter string) ([]isg.SportRound, error) {
 
@@ -2423,18 +2445,101 @@ func MakingFixtureLiveOddsSort(liveOdds []isg.FixtureOdds, sportID int) []isg.Ge
 	return fixtureLiveOdds
 }
 
-// GenerateSQLQueryForGeniusOdds :
-func GenerateSQLQueryForGeniusOdds(objSport isg.Sport, objLeague isg.League, matchID int) string {
+// geniusOddsWindowSQL : kick off window and page condition shared by the genius odds fixtures, odds and flucs queries
+func geniusOddsWindowSQL(objWindow isg.GeniusOddsWindow) string {
+
+	from := objWindow.From
+	if from.IsZero() {
+		from = time.Now().UTC()
+	}
+	to := objWindow.To
+	if to.IsZero() {
+		to = from.Add(isg.GeniusOddsWindowDays * 24 * time.Hour)
+	}
+
+	counterDateTime := "concat(matches.counter_date, ' ', matches.counter_time)"
+	searchStr := counterDateTime + " BETWEEN '" + CounterDateTimeString(from) + "' AND '" + CounterDateTimeString(to) + "' AND "
+
+	if len(objWindow.MatchIDs) > 0 {
+		searchStr += " matches.match_id IN (" + matchIDList(objWindow.MatchIDs) + ") AND "
+	}
+
+	return searchStr
+}
+
+// geniusOddsCursorSQL : matches of the sport after the cursor in the upcoming order, kick off, sport sequence then match id.
+// The cursor is validated by isg.DecodeGeniusOddsCursor.
+func geniusOddsCursorSQL(cursor *isg.GeniusOddsCursor, sportID int) string {
+
+	if cursor == nil {
+		return ""
+	}
+
+	counterDateTime := "concat(matches.counter_date, ' ', matches.counter_time)"
+	sequence := isg.SportsSequence[sportID]
+
+	if sequence > cursor.Sequence {
+		return counterDateTime + " >= '" + cursor.CounterDateTime + "' AND "
+	} else if sequence < cursor.Sequence {
+		return counterDateTime + " > '" + cursor.CounterDateTime + "' AND "
+	}
+
+	return " (" + counterDateTime + " > '" + cursor.CounterDateTime + "' OR (" + counterDateTime + " = '" + cursor.CounterDateTime + "' " +
+		" AND matches.match_id > " + strconv.FormatInt(cursor.MatchID, 10) + ")) AND "
+}
+
+// GetGeniusOddsPageMatchIDs : ids of the upcoming matches of a league with a live win price, from the cursor in kick off order.
+// One more than the page limit is loaded so the caller knows there is a next page.
+func GetGeniusOddsPageMatchIDs(objSport isg.Sport, objLeague isg.League, objWindow isg.GeniusOddsWindow) ([]int64, error) {
+
+	var matchIDs []int64
+
+	limit := objWindow.Limit
+	if limit <= 0 {
+		limit = isg.GeniusOddsPageLimit
+	}
+
+	sportID := objSport.SportInternalID
+	_, _, leagueColumn := geniusOddsMatchColumns(sportID)
+
+	sqlstr := "SELECT matches.match_id FROM " + objSport.TableNameMatches + " AS matches " +
+		" WHERE " + geniusOddsWindowSQL(isg.GeniusOddsWindow{From: objWindow.From, To: objWindow.To}) + geniusOddsCursorSQL(objWindow.Cursor, sportID) +
+		" matches.status = ? AND " + leagueColumn + " = ? " +
+		" AND EXISTS (SELECT 1 FROM isg_geniusodds_marketodds marketodds " +
+		" INNER JOIN isg_market market ON market.market_id = marketodds.market_id AND market.isg_api_id = 'win' " +
+		" WHERE marketodds.match_id = matches.match_id AND marketodds.sport_id = ? AND marketodds.provider_id != ? AND marketodds.`status` = ?) " +
+		" ORDER BY matches.counter_date, matches.counter_time, matches.match_id " +
+		" LIMIT " + strconv.Itoa(limit+1)
+
+	rows, err := SportsDb.Query(sqlstr, "Y", objLeague.LeagueInternalID, sportID, 4, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+	for rows.Next() {
+		var matchID int64
+		err = rows.Scan(&matchID)
+		if err != nil {
+			return nil, err
+		}
+		matchIDs = append(matchIDs, matchID)
+	}
+
+	return matchIDs, rows.Err()
+}
+
+// GenerateSQLQueryForGeniusOdds : matchID is optional, without it the matches of the kick off window are listed
+func GenerateSQLQueryForGeniusOdds(objSport isg.Sport, objLeague isg.League, matchID int, objWindow isg.GeniusOddsWindow) string {
 
-	var _sqlstr, _searchStr, limitstr string
+	var _sqlstr, _searchStr string
 
 	if matchID != 0 {
 		_searchStr = " matches.match_id = " + strconv.Itoa(matchID) + " AND "
 	}
 
 	if matchID == 0 {
-		_searchStr = "concat(matches.counter_date, ' ', matches.counter_time) >= '" + time.Now().In(AEST).Format("2006-01-02 15:04:05") + "' AND "
-		limitstr = " LIMIT 0, 10 "
+		_searchStr = geniusOddsWindowSQL(objWindow)
 	}
 	switch objSport.SportInternalID {
 
@@ -2454,7 +2559,29 @@ func GenerateSQLQueryForGeniusOdds(objSport isg.Sport, objLeague isg.League, mat
 			" LEFT JOIN isg_aussie_rules_round round_name ON matches.round_id = round_name.round_id " +
 			" WHERE " + _searchStr + "" +
 			"  matches.status = ? AND matches.league_id = ? " +
-			" ORDER BY matches.season_id ASC, matches.round_id ASC, match_date ASC, match_time ASC " + limitstr
+			" ORDER BY matches.season_id ASC, matches.round_id ASC, match_date ASC, match_time ASC "
+
+	case 6:
+
+		_sqlstr = "SELECT matches.match_id, matches.match_date, matches.match_time, counter_date, counter_time, round_name.short_round_name, round_name.round_name, round_name.round_url, " +
+			" home.isg_api_id, matches.player1_id, home.filter_name, home.full_name, home.short_name, country1.flag, home.player_url_name, home.short_name, NULL, " +
+			" away.isg_api_id, matches.player2_id, away.filter_name, away.full_name, away.short_name, country2.flag, away.player_url_name, away.short_name, NULL, " +
+			" isg_venue.isg_api_id, isg_venue.filtername, season_venue.venue_id, isg_venue.friendlyname, isg_venue.city, country.country, matches.status, " +
+			" NULL, NULL, isg_venue.timezone, 0 " +
+			" FROM isg_tennis_matches AS matches " +
+			" LEFT JOIN isg_tennis_players AS home ON home.player_id = matches.player1_id " +
+			" LEFT JOIN isg_tennis_players AS away ON away.player_id = matches.player2_id " +
+			" LEFT JOIN isg_country country1 ON country1.country_id = home.country_id " +
+			" LEFT JOIN isg_country country2 ON country2.country_id = away.country_id " +
+			" LEFT JOIN isg_tennis_tournament_season_venue season_venue ON season_venue.tournament_id = matches.tournament_id AND season_venue.level_id = matches.level_id " +
+			" AND season_venue.season_id = matches.season_id " +
+			" LEFT JOIN isg_venue ON isg_venue.venue_id = season_venue.venue_id " +
+			" LEFT JOIN isg_country country ON country.country_id = isg_venue.country " +
+			" LEFT JOIN isg_tennis_tournament_season_round AS seasonround ON seasonround.match_id = matches.match_id " +
+			" LEFT JOIN isg_tennis_round round_name ON round_name.round_id = seasonround.round_id " +
+			" WHERE " + _searchStr + "" +
+			"  matches.status = ? AND matches.level_id = ? " +
+			" ORDER BY matches.season_id ASC, matches.tournament_id ASC, seasonround.round_id ASC, match_date ASC, match_time ASC "
 
 	case 7:
 
@@ -2472,7 +2599,7 @@ func GenerateSQLQueryForGeniusOdds(objSport isg.Sport, objLeague isg.League, mat
 			" LEFT JOIN isg_rugby_league_round round_name ON matches.round_id = round_name.round_id " +
 			" WHERE " + _searchStr + "" +
 			"  matches.status = ? AND matches.league_id = ? " +
-			" ORDER BY  matches.season_id ASC, matches.round_id ASC, match_date ASC, match_time ASC " + limitstr
+			" ORDER BY  matches.season_id ASC, matches.round_id ASC, match_date ASC, match_time ASC "
 
 	case 10:
 
@@ -2490,7 +2617,7 @@ func GenerateSQLQueryForGeniusOdds(objSport isg.Sport, objLeague isg.League, mat
 			" LEFT JOIN isg_rugby_union_round round_name ON matches.round_id = round_name.round_id " +
 			" WHERE " + _searchStr + "" +
 			"  matches.status = ? AND matches.league_id = ? " +
-			" ORDER BY  matches.season_id ASC, matches.round_id ASC, match_date ASC, match_time ASC " + limitstr
+			" ORDER BY  matches.season_id ASC, matches.round_id ASC, match_date ASC, match_time ASC "
 
 	}
 
@@ -2504,7 +2631,7 @@ func GetMatchesForGeniusOdds(_sqlstr string, objMatchesRecord []isg.GeniusSports
 	if err != nil {
 		return nil, err
 	}
-	currentDateTime, _ := time.Parse("2006-01-02 15:04:05", time.Now().In(AEST).Format("2006-01-02 15:04:05"))
+	currentDateTime := time.Now().UTC()
 	defer rows.Close()
 	for rows.Next() {
 
@@ -2554,10 +2681,11 @@ func GetMatchesForGeniusOdds(_sqlstr string, objMatchesRecord []isg.GeniusSports
 		}
 
 		// exclude started match from best matches
-		matchDateTime, _ := time.Parse("2006-01-02 15:04:05", objmatch.CounterDate.String+" "+objmatch.CounterTime.String)
-		if (typeVal == "best" || typeVal == "upcoming") && matchDateTime.Unix() < currentDateTime.Unix() {
+		matchDateTime, _ := GetCounterDateTime(objmatch.CounterDate.String, objmatch.CounterTime.String)
+		if (typeVal == "best" || typeVal == "upcoming") && matchDateTime.Before(currentDateTime) {
 			continue
 		}
+		objmatch.StartTime = matchDateTime
 
 		objmatch.SportInfo = objSport
 		objmatch.LeagueInfo = objLeague
@@ -2672,14 +2800,14 @@ func GetMatchesGeniusOddsPlunges(plungeOddsFluc []isg.GeniusOddsMarket, objSport
 	return liveOdds, nil
 }
 
-// GetPlungeMatchesForGeniusOdds :
-func GetPlungeMatchesForGeniusOdds(objMatchesRecord []isg.GeniusSportsMatch, livePlungeOdds map[int64][]isg.GeniusOddsPlunge, objSport isg.Sport, objLeague isg.League, typeVal string, matchIDs []string) ([]isg.GeniusSportsMatch, error) {
+// GetPlungeMatchesForGeniusOdds : objWindow keeps the plunge matches to the kick off window and page cursor of the listing
+func GetPlungeMatchesForGeniusOdds(objMatchesRecord []isg.GeniusSportsMatch, livePlungeOdds map[int64][]isg.GeniusOddsPlunge, objSport isg.Sport, objLeague isg.League, typeVal string, matchIDs []string, objWindow isg.GeniusOddsWindow) ([]isg.GeniusSportsMatch, error) {
 
 	var sqlstr, searchStr string
 	teamMap := map[int64]int64{}
 
 	matchID := strings.Join(matchIDs, ",")
-	searchStr = " AND matches.match_id IN (" + matchID + ") "
+	searchStr = " AND " + geniusOddsWindowSQL(objWindow) + " matches.match_id IN (" + matchID + ") "
 
 	switch objSport.SportInternalID {
 
@@ -2790,6 +2918,7 @@ func GetPlungeMatchesForGeniusOdds(objMatchesRecord []isg.GeniusSportsMatch, liv
 
 		objmatch.SportInfo = objSport
 		objmatch.LeagueInfo = objLeague
+		objmatch.StartTime, _ = GetCounterDateTime(objmatch.CounterDate.String, objmatch.CounterTime.String)
 
 		_, homeok := teamMap[objmatch.HomeTeamInternalID.Int64]
 		_, awayok := teamMap[objmatch.AwayTeamInternalID.Int64]
@@ -2918,14 +3047,14 @@ func GetSprotsMarketOdds(matchodd isg.Odds) []isg.MarketOdds {
 }
 
 // GetMatchesProviderMarketOdds :
-func GetMatchesProviderMarketOdds(marketFlucs []isg.GeniusOddsMarket, objSport isg.Sport, leagueID, matchID int, typeVal string) ([]isg.GeniusOddsMarket, error) {
+func GetMatchesProviderMarketOdds(marketFlucs []isg.GeniusOddsMarket, objSport isg.Sport, leagueID, matchID int, typeVal string, objWindow isg.GeniusOddsWindow) ([]isg.GeniusOddsMarket, error) {
 	var liveOdds []isg.GeniusOddsMarket
 	var sqlstr, searchStr, search string
 
 	flucMap := map[string]string{}
 	sportID := objSport.SportInternalID
-	searchStr = "concat(matches.counter_date, ' ', matches.counter_time) BETWEEN '" + time.Now().In(AEST).Format("2006-01-02 15:04:05") + "' AND " +
-		" '" + time.Now().In(AEST).AddDate(0, 0, 8).Format("2006-01-02 15:04:05") + "' AND "
+	searchStr = geniusOddsWindowSQL(objWindow)
+	homeColumn, awayColumn, leagueColumn := geniusOddsMatchColumns(sportID)
 
 	if matchID != 0 {
 		searchStr = "matches.match_id = " + strconv.Itoa(matchID) + " AND "
@@ -2933,13 +3062,13 @@ func GetMatchesProviderMarketOdds(marketFlucs []isg.GeniusOddsMarket, objSport i
 
 	switch sportID {
 
-	case 1, 7, 10: // AFL
+	case 1, 6, 7, 10: // AFL
 		if typeVal == "best" {
-			search = " market.isg_api_id IN ('win', 'loss', 'draw', 'over', 'under','cover') "
+			search = " " + isg.GeniusOddsBestMarketSQL(sportID) + " "
 		} else if typeVal == "upcoming" {
 			search = " market.isg_api_id IN ('win') "
 		}
-		sqlstr = "SELECT matches.match_id, matches.home_team_id, matches.away_team_id, market.market_id, market.market_name, IFNULL(marketcategory.category_id,0), IFNULL(marketcategory.category_name,''), " +
+		sqlstr = "SELECT matches.match_id, " + homeColumn + ", " + awayColumn + ", market.market_id, market.market_name, IFNULL(marketcategory.category_id,0), IFNULL(marketcategory.category_name,''), " +
 			" marketodds.team_id, marketodds.market_price, marketodds.market_val, marketodds.provider_market_id, IFNULL(provider.provider_name,''), IFNULL(provider.provider_icon,''), marketodds.provider_id, " +
 			" IFNULL(provider.genius_odds_sequence, 0), market.isg_api_id " +
 			" FROM " + objSport.TableNameMatches + " AS matches " +
@@ -2949,7 +3078,7 @@ func GetMatchesProviderMarketOdds(marketFlucs []isg.GeniusOddsMarket, objSport i
 			" LEFT JOIN isg_market_category marketcategory ON marketcategory.category_id = market.category_id " +
 			" LEFT JOIN isg_providers provider ON marketodds.provider_id= provider.provider_id " +
 			" WHERE " + searchStr + "" + search +
-			" AND matches.status = ? AND matches.league_id = ?  AND marketodds.`status`= ? " +
+			" AND matches.status = ? AND " + leagueColumn + " = ?  AND marketodds.`status`= ? " +
 			" ORDER BY matches.match_id,  market.category_id, market.market_id"
 	}
 
@@ -3024,25 +3153,53 @@ func GetMatchesProviderMarketOdds(marketFlucs []isg.GeniusOddsMarket, objSport i
 	return liveOdds, nil
 }
 
-// GetMarketMatchesProviderOdds :
-func GetMarketMatchesProviderOdds(marketFlucs []isg.GeniusOddsMarket, objSport isg.Sport, leagueID, matchID int) ([]isg.GeniusOddsMarket, error) {
+// geniusOddsFilterSQL : conditions and arguments of the markets page filter, empty lists are not filtered
+func geniusOddsFilterSQL(objFilter isg.GeniusOddsFilter) (string, []interface{}) {
+
+	var filterStr string
+	var args []interface{}
+
+	inStr := func(column string, values []string) {
+		if len(values) == 0 {
+			return
+		}
+		filterStr += " AND " + column + " IN (?" + strings.Repeat(", ?", len(values)-1) + ") "
+		for _, value := range values {
+			args = append(args, value)
+		}
+	}
+
+	inStr("marketodds.provider_id", objFilter.Providers)
+	inStr("LOWER(map.market_name)", objFilter.Categories)
+	inStr("LOWER(isg_market_category_group.group_name)", objFilter.Groups)
+	inStr("market.isg_api_id", objFilter.Markets)
+
+	return filterStr, args
+}
+
+// GetMarketMatchesProviderOdds : objFilter narrows the providers, categories, groups and markets
+func GetMarketMatchesProviderOdds(marketFlucs []isg.GeniusOddsMarket, objSport isg.Sport, leagueID, matchID int, objFilter isg.GeniusOddsFilter) ([]isg.GeniusOddsMarket, error) {
 	var liveOdds []isg.GeniusOddsMarket
 	var sqlstr, searchStr, search string
 
+	filterStr, filterArgs := geniusOddsFilterSQL(objFilter)
+
 	flucMap := map[string]string{}
 
-	searchStr = "concat(matches.counter_date, ' ', matches.counter_time) <= '" + time.Now().In(AEST).AddDate(0, 0, 8).Format("2006-01-02 15:04:05") + "'"
+	searchStr = geniusOddsWindowSQL(isg.GeniusOddsWindow{})
 	if matchID != 0 {
-		searchStr = "matches.match_id = " + strconv.Itoa(matchID)
+		searchStr = "matches.match_id = " + strconv.Itoa(matchID) + " AND "
 	}
 	sportID := objSport.SportInternalID
+	homeColumn, awayColumn, leagueColumn := geniusOddsMatchColumns(sportID)
 	switch sportID {
 
-	case 1, 7, 10: // AFL
-		sqlstr = " SELECT matches.match_id, matches.home_team_id, matches.away_team_id, market.market_id, marketodds.team_id, marketodds.market_price, marketodds.market_val, " +
+	case 1, 6, 7, 10: // AFL
+		sqlstr = " SELECT matches.match_id, " + homeColumn + ", " + awayColumn + ", market.market_id, marketodds.team_id, marketodds.market_price, marketodds.market_val, " +
 			" marketodds.provider_market_id, IFNULL(provider.provider_name,'') AS provider_name, IFNULL(provider.provider_icon,'') AS provider_icon, provider.provider_id, " +
 			" IFNULL(provider.genius_odds_sequence, 0), marketmap.parent_id, " +
-			" marketmap.market_name, map.market_name AS category_name, isg_market_category_group.group_name, IFNULL(marketodds.market_display_name,'') AS display_name, map.sequence " +
+			" marketmap.market_name, map.market_name AS category_name, isg_market_category_group.group_name, IFNULL(marketodds.market_display_name,'') AS display_name, map.sequence, " +
+			" IFNULL(market.isg_api_id,'') " +
 			" FROM " + objSport.TableNameMatches + " AS matches " +
 			" INNER JOIN isg_geniusodds_marketodds marketodds ON marketodds.match_id = matches.match_id AND marketodds.sport_id = ? AND marketodds.league_level_id = ? " +
 			" AND marketodds.provider_id != ? " +
@@ -3052,12 +3209,13 @@ func GetMarketMatchesProviderOdds(marketFlucs []isg.GeniusOddsMarket, objSport i
 			" LEFT JOIN isg_market_category_group ON isg_market_category_group.group_id = map.group_id " +
 			" LEFT JOIN isg_providers provider ON marketodds.provider_id= provider.provider_id " +
 			" WHERE " + searchStr + "" + search +
-			" AND matches.status = ? AND matches.league_id = ? AND marketodds.`status`= ? " +
+			" matches.status = ? AND " + leagueColumn + " = ? AND marketodds.`status`= ? AND IFNULL(marketodds.player_id, 0) = 0 " + filterStr +
 			" ORDER BY matches.match_id, isg_market_category_group.group_id, marketmap.sequence, market.market_id, marketodds.provider_id, " +
-			" IF(matches.home_team_id < matches.away_team_id, team_id, 0) ASC, team_id DESC "
+			" IF(" + homeColumn + " < " + awayColumn + ", team_id, 0) ASC, team_id DESC "
 	}
 	//fmt.Println(sqlstr)
-	rows, err := SportsDb.Query(sqlstr, sportID, leagueID, 4, "Y", leagueID, 1)
+	args := append([]interface{}{sportID, leagueID, 4, "Y", leagueID, 1}, filterArgs...)
+	rows, err := SportsDb.Query(sqlstr, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -3085,6 +3243,7 @@ func GetMarketMatchesProviderOdds(marketFlucs []isg.GeniusOddsMarket, objSport i
 				&odds.GroupName,
 				&odds.DisplayName,
 				&odds.Sequence,
+				&odds.ISGapiID,
 			)
 		}
 
@@ -3135,24 +3294,24 @@ func GetMarketMatchesProviderOdds(marketFlucs []isg.GeniusOddsMarket, objSport i
 }
 
 // GetMatchesProviderMarketFlucs :
-func GetMatchesProviderMarketFlucs(objSport isg.Sport, leagueID, matchID int, typeVal string) ([]isg.GeniusOddsMarket, error) {
+func GetMatchesProviderMarketFlucs(objSport isg.Sport, leagueID, matchID int, typeVal string, objWindow isg.GeniusOddsWindow) ([]isg.GeniusOddsMarket, error) {
 	var liveFlucOdds []isg.GeniusOddsMarket
 	var sqlstr, searchStr, plungeStr string
-	searchStr = "concat(matches.counter_date, ' ', matches.counter_time) BETWEEN '" + time.Now().In(AEST).Format("2006-01-02 15:04:05") + "' AND " +
-		" '" + time.Now().In(AEST).AddDate(0, 0, 8).Format("2006-01-02 15:04:05") + "' AND "
+	searchStr = geniusOddsWindowSQL(objWindow)
 	sportID := objSport.SportInternalID
+	_, _, leagueColumn := geniusOddsMatchColumns(sportID)
 	if matchID != 0 {
 		searchStr = "matches.match_id = " + strconv.Itoa(matchID) + " AND "
 	}
 
 	switch sportID {
 
-	case 1, 7, 10: // AFL
+	case 1, 6, 7, 10: // AFL
 
 		if typeVal == "plunge" || typeVal == "upcoming" {
 			plungeStr = " AND  market.isg_api_id IN ('win') "
 		} else if typeVal == "best" {
-			plungeStr = " AND market.isg_api_id IN ('win', 'loss', 'draw', 'over', 'under', 'cover') "
+			plungeStr = " AND " + isg.GeniusOddsBestMarketSQL(sportID) + " "
 		}
 
 		sqlstr = "SELECT oddsfluc.match_id, oddsfluc.market_id, oddsfluc.provider_id, oddsfluc.team_id, oddsfluc.market_price, oddsfluc.market_val, marketcategory.category_name " +
@@ -3164,7 +3323,7 @@ func GetMatchesProviderMarketFlucs(objSport isg.Sport, leagueID, matchID int, ty
 			" LEFT JOIN isg_geniusodds_marketodds_flucs oddsfluc ON oddsfluc.match_id = marketodds.match_id AND oddsfluc.market_id = marketodds.market_id " +
 			" AND oddsfluc.team_id = marketodds.team_id AND oddsfluc.provider_id = marketodds.provider_id " +
 			" WHERE " + searchStr + "" +
-			"  matches.status = ? AND matches.league_id = ? AND marketodds.`status`= ? " + plungeStr +
+			"  matches.status = ? AND " + leagueColumn + " = ? AND marketodds.`status`= ? " + plungeStr +
 			" ORDER BY matches.match_id, market.market_id, market.category_id, oddsfluc.last_update "
 	}
 
@@ -3203,16 +3362,17 @@ func GetMarketMatchesProviderFlucs(objSport isg.Sport, leagueID, matchID int, ty
 	var liveFlucOdds []isg.GeniusOddsMarket
 	var sqlstr, searchStr string
 
-	searchStr = "concat(matches.counter_date, ' ', matches.counter_time) <= '" + time.Now().In(AEST).AddDate(0, 0, 4).Format("2006-01-02 15:04:05") + "'"
+	searchStr = geniusOddsWindowSQL(isg.GeniusOddsWindow{})
 	if matchID != 0 {
-		searchStr = "matches.match_id = " + strconv.Itoa(matchID)
+		searchStr = "matches.match_id = " + strconv.Itoa(matchID) + " AND "
 	}
 	sportID := objSport.SportInternalID
+	_, _, leagueColumn := geniusOddsMatchColumns(sportID)
 	switch sportID {
 
-	case 1, 7, 10: // AFL
+	case 1, 6, 7, 10: // AFL
 
-		sqlstr = " SELECT oddsfluc.match_id, oddsfluc.market_id, oddsfluc.provider_id, oddsfluc.team_id, oddsfluc.market_price, oddsfluc.market_val " +
+		sqlstr = " SELECT oddsfluc.match_id, oddsfluc.market_id, oddsfluc.provider_id, oddsfluc.team_id, oddsfluc.market_price, oddsfluc.market_val, IFNULL(oddsfluc.player_id, 0) " +
 			" FROM " + objSport.TableNameMatches + " AS matches " +
 			" INNER JOIN isg_geniusodds_marketodds marketodds ON marketodds.match_id = matches.match_id AND marketodds.sport_id = ? AND marketodds.league_level_id = ? " +
 			" AND marketodds.provider_id != ? " +
@@ -3220,8 +3380,9 @@ func GetMarketMatchesProviderFlucs(objSport isg.Sport, leagueID, matchID int, ty
 			" INNER JOIN isg_geniusodds_markets_mapping AS marketmap  ON marketmap.market_id = market.market_id " +
 			" LEFT JOIN isg_geniusodds_marketodds_flucs oddsfluc ON oddsfluc.match_id = marketodds.match_id AND oddsfluc.market_id = marketodds.market_id " +
 			" AND oddsfluc.team_id = marketodds.team_id AND oddsfluc.provider_id = marketodds.provider_id " +
+			" AND IFNULL(oddsfluc.player_id, 0) = IFNULL(marketodds.player_id, 0) " +
 			" WHERE " + searchStr + "" +
-			" AND matches.status = ? AND matches.league_id = ? AND marketodds.`status`= ? " +
+			" matches.status = ? AND " + leagueColumn + " = ? AND marketodds.`status`= ? " +
 			" ORDER BY matches.match_id, marketmap.sequence, market.market_id, oddsfluc.last_update  "
 	}
 	rows, err := SportsDb.Query(sqlstr, sportID, leagueID, 4, "Y", leagueID, 1)
@@ -3240,6 +3401,7 @@ func GetMarketMatchesProviderFlucs(objSport isg.Sport, leagueID, matchID int, ty
 				&flucOdds.MarketTeamID,
 				&flucOdds.MarketPrice,
 				&flucOdds.MarketVal,
+				&flucOdds.PlayerID,
 			)
 		}
 
@@ -3326,53 +3488,47 @@ func GetGeniusOddPlungeMatch(sportID, leagueID int, typeVal, matchID string) ([]
 	return geniusMatches, nil
 }
 
-// GetMatchPreviewContent :
-func GetMatchPreviewContent(objSport isg.Sport, objLeague isg.League, matchID int) (string, error) {
-
-	var _sqlstr string
-	var preview string
-	switch objSport.SportInternalID {
+// matchPreviewTable : written content table of the sport, basketball and rugby union previews are kept per league
+func matchPreviewTable(sportID int) (string, bool) {
 
+	switch sportID {
 	case 1:
-
-		_sqlstr = "SELECT description FROM isg_aussie_rules_matches_written_content WHERE match_id = ? AND content_id = ? "
-
+		return "isg_aussie_rules_matches_written_content", false
 	case 2:
-
-		_sqlstr = "SELECT description FROM isg_nflmatches_written_content WHERE match_id = ? AND content_id = ? "
-
+		return "isg_nflmatches_written_content", false
 	case 3:
-
-		_sqlstr = "SELECT description FROM isg_basketball_matches_written_content WHERE match_id = ? AND content_id = ? AND league_id = " + strconv.Itoa(objLeague.LeagueInternalID)
-
+		return "isg_basketball_matches_written_content", true
 	case 4:
-
-		_sqlstr = "SELECT description FROM isg_soccermatches_written_content WHERE match_id = ? AND content_id = ? "
-
+		return "isg_soccermatches_written_content", false
 	case 5:
-
-		_sqlstr = "SELECT description FROM isg_cricket_written_content WHERE match_id = ? AND content_id = ? "
-
+		return "isg_cricket_written_content", false
 	case 6:
-
-		_sqlstr = "SELECT description FROM isg_tennis_matches_written_content WHERE match_id = ? AND content_id = ? "
-
+		return "isg_tennis_matches_written_content", false
 	case 7:
-
-		_sqlstr = "SELECT description FROM isg_rugby_league_matches_written_content WHERE match_id = ? AND content_id = ? "
-
+		return "isg_rugby_league_matches_written_content", false
 	case 8:
-
-		_sqlstr = "SELECT description FROM isg_hockeymatches_written_content WHERE match_id = ? AND content_id = ? "
-
+		return "isg_hockeymatches_written_content", false
 	case 9:
+		return "isg_baseball_matches_written_content", false
+	case 10:
+		return "isg_rugby_union_written_content", true
+	}
+	return "", false
+}
 
-		_sqlstr = "SELECT description FROM isg_baseball_matches_written_content WHERE match_id = ? AND content_id = ? "
+// GetMatchPreviewContent :
+func GetMatchPreviewContent(objSport isg.Sport, objLeague isg.League, matchID int) (string, error) {
 
-	case 10:
+	var preview string
 
-		_sqlstr = "SELECT description FROM isg_rugby_union_written_content WHERE match_id = ? AND content_id = ? AND league_id = " + strconv.Itoa(objLeague.LeagueInternalID)
+	tableName, perLeague := matchPreviewTable(objSport.SportInternalID)
+	if tableName == "" {
+		return "", nil
+	}
 
+	_sqlstr := "SELECT description FROM " + tableName + " WHERE match_id = ? AND content_id = ? "
+	if perLeague {
+		_sqlstr += " AND league_id = " + strconv.Itoa(objLeague.LeagueInternalID)
 	}
 
 	err := SportsDb.QueryRow(_sqlstr, matchID, 1).Scan(&preview)
@@ -3442,7 +3598,22 @@ func UpdateMatchOdds(objsport isg.Sport, homeodds, awayodds, drawodds string, ma
 	return nil
 }
 
-// UpdateCorrectScoreOdds : Update Soccer Match Correct Odds as per sport / matchid
+// correctScoreOddsTable : correct score odds table of a sport
+func correctScoreOddsTable(sportID int) string {
+	switch sportID {
+	case 1: // AFL
+		return "isg_aussie_rules_correct_score_odds"
+	case 4: // Soccer
+		return "isg_soccer_correct_score_odds"
+	case 7: // NRL
+		return "isg_rugby_league_correct_score_odds"
+	case 8: // Hockey
+		return "isg_hockey_correct_score_odds"
+	}
+	return ""
+}
+
+// UpdateCorrectScoreOdds : Update Match Correct Odds as per sport / matchid, AFL and NRL scores are stored as the start of their score band
 func UpdateCorrectScoreOdds(correctscoredetails []isg.CorrectScoreDetails, objsport isg.Sport, homeTeamID, awayTeamID string, matchid, providerID int) error {
 	var sqlstr string
 	var sqlvalues string
@@ -3451,46 +3622,64 @@ func UpdateCorrectScoreOdds(correctscoredetails []isg.CorrectScoreDetails, objsp
 	updatedcurrentDateTime = currentDateTime.Format("2006-01-02 15:04:05")
 	fmt.Println(updatedcurrentDateTime)
 	switch objsport.SportInternalID {
-	case 4: // Soccer
-		sqlstr = "INSERT INTO isg_soccer_correct_score_odds (match_id, team_id, provider_id, correct_score, correct_score_odds, correct_score_market, status, date_added) VALUES "
+	case 1, 4, 7, 8: // AFL, Soccer, NRL, Hockey
+		sqlstr = "INSERT INTO " + correctScoreOddsTable(objsport.SportInternalID) + " (match_id, team_id, provider_id, correct_score, correct_score_odds, correct_score_market, status, date_added) VALUES "
+
+		// the winner comes from the exact scores, the band is only applied to the stored score.
+		// Selections that share a team and banded score keep the best price.
+		var keys [][2]string
+		selections := map[[2]string]isg.CorrectScoreDetails{}
+		addSelection := func(teamid, correctscore string, corectScoreDetail isg.CorrectScoreDetails) {
+			key := [2]string{teamid, correctscore}
+			prev, ok := selections[key]
+			if !ok {
+				keys = append(keys, key)
+			} else {
+				prevOdds, _ := strconv.ParseFloat(prev.CorrectScoreOdds, 64)
+				odds, _ := strconv.ParseFloat(corectScoreDetail.CorrectScoreOdds, 64)
+				if odds <= prevOdds {
+					return
+				}
+			}
+			selections[key] = corectScoreDetail
+		}
 
 		for _, corectScoreDetail := range correctscoredetails {
 
-			correctscores := strings.Split(corectScoreDetail.CorrectScore, " - ")
-			hometeamscore, _ := strconv.Atoi(correctscores[0])
-			awayteamscore, _ := strconv.Atoi(correctscores[1])
+			hometeamscore, awayteamscore, err := isg.ParseCorrectScore(corectScoreDetail.CorrectScore)
+			if err != nil {
+				fmt.Println(err.Error())
+				continue
+			}
+			homeband := strconv.Itoa(isg.GetCorrectScoreBand(objsport.SportInternalID, hometeamscore))
+			awayband := strconv.Itoa(isg.GetCorrectScoreBand(objsport.SportInternalID, awayteamscore))
 
-			teamid := ""
-			correctscore := ""
 			if hometeamscore > awayteamscore {
-				teamid = homeTeamID
-				correctscore = strconv.Itoa(hometeamscore) + " - " + strconv.Itoa(awayteamscore)
+				addSelection(homeTeamID, homeband+" - "+awayband, corectScoreDetail)
 			} else if awayteamscore > hometeamscore {
-				teamid = awayTeamID
-				correctscore = strconv.Itoa(awayteamscore) + " - " + strconv.Itoa(hometeamscore)
+				addSelection(awayTeamID, awayband+" - "+homeband, corectScoreDetail)
 			} else {
-				correctscore = corectScoreDetail.CorrectScore
+				addSelection(homeTeamID, homeband+" - "+awayband, corectScoreDetail)
+				addSelection(awayTeamID, awayband+" - "+homeband, corectScoreDetail)
 			}
+		}
 
-			correctscoremarket := corectScoreDetail.CorrectScoreMarket
-			correctscoreodds := corectScoreDetail.CorrectScoreOdds
-
-			if teamid != "" {
-				sqlvalues = sqlvalues + "('" + strconv.Itoa(matchid) + "','" + teamid + "','" + strconv.Itoa(providerID) + "','" + correctscore + "'," +
-					correctscoreodds + ",'" + correctscoremarket + "','1','" + updatedcurrentDateTime + "'),"
-			} else if teamid == "" {
-				sqlvalues = sqlvalues + "('" + strconv.Itoa(matchid) + "','" + homeTeamID + "','" + strconv.Itoa(providerID) + "','" + correctscore + "'," +
-					correctscoreodds + ",'" + correctscoremarket + "','1','" + updatedcurrentDateTime + "'),"
-				sqlvalues = sqlvalues + "('" + strconv.Itoa(matchid) + "','" + awayTeamID + "','" + strconv.Itoa(providerID) + "','" + correctscore + "'," +
-					correctscoreodds + ",'" + correctscoremarket + "','1','" + updatedcurrentDateTime + "'),"
-			}
+		for _, key := range keys {
+			corectScoreDetail := selections[key]
+			sqlvalues = sqlvalues + "('" + strconv.Itoa(matchid) + "','" + key[0] + "','" + strconv.Itoa(providerID) + "','" + key[1] + "'," +
+				corectScoreDetail.CorrectScoreOdds + ",'" + corectScoreDetail.CorrectScoreMarket + "','1','" + updatedcurrentDateTime + "'),"
+		}
 
+		if sqlvalues == "" {
+			return nil
 		}
 
 		//trim the last
 		sqlstr = sqlstr + sqlvalues[0:len(sqlvalues)-1] + " ON DUPLICATE KEY UPDATE correct_score_market = values(correct_score_market)," +
 			" correct_score_odds = values(correct_score_odds), status = 1, date_added = '" + updatedcurrentDateTime + "'"
 
+	default:
+		return nil
 	}
 
 	stmt, err := SportsDb.Prepare(sqlstr)
@@ -3504,37 +3693,113 @@ func UpdateCorrectScoreOdds(correctscoredetails []isg.CorrectScoreDetails, objsp
 	return nil
 }
 
-// GetGeniusMarketMatchID :
-func GetGeniusMarketMatchID(objSport isg.Sport, leagueID int, seasonID int, roundWeekDate string, homeTeamID int, awayTeamID int, sortOder string) (int, error) {
+// GetCorrectScoreOdds : live correct score prices of a match per provider with the home / away teams to orient the scores
+func GetCorrectScoreOdds(objsport isg.Sport, matchID int) ([]isg.CorrectScoreOdds, error) {
+
+	var records []isg.CorrectScoreOdds
+
+	tablename := correctScoreOddsTable(objsport.SportInternalID)
+	if tablename == "" {
+		return records, nil
+	}
+
+	sqlstr := "SELECT cs.match_id, cs.provider_id, IFNULL(provider.provider_name,''), IFNULL(provider.provider_icon,''), IFNULL(provider.genius_odds_sequence, 0), " +
+		" cs.team_id, matches.home_team_id, matches.away_team_id, cs.correct_score, cs.correct_score_odds, IFNULL(cs.correct_score_market,'') " +
+		" FROM " + tablename + " AS cs " +
+		" INNER JOIN " + objsport.TableNameMatches + " AS matches ON matches.match_id = cs.match_id " +
+		" LEFT JOIN isg_providers provider ON provider.provider_id = cs.provider_id " +
+		" WHERE cs.match_id = ? AND cs.status = ? AND cs.provider_id != ? " +
+		" ORDER BY IFNULL(provider.genius_odds_sequence, 0), cs.provider_id, cs.correct_score "
+
+	rows, err := SportsDb.Query(sqlstr, matchID, 1, 4)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var record isg.CorrectScoreOdds
+		err = rows.Scan(
+			&record.MatchID,
+			&record.ProviderInfo.ProviderId,
+			&record.ProviderInfo.Name,
+			&record.ProviderInfo.Icon,
+			&record.ProviderInfo.GeniusOddsSequence,
+			&record.TeamID,
+			&record.HomeTeamID,
+			&record.AwayTeamID,
+			&record.Score,
+			&record.Odds,
+			&record.MarketID,
+		)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+// GetGeniusMarketMatchIDs : upcoming matches of the two teams in the round / week / date, in kick off order so the
+// games of a doubleheader are returned first to last
+func GetGeniusMarketMatchIDs(objSport isg.Sport, leagueID int, seasonID int, roundWeekDate string, homeTeamID int, awayTeamID int) ([]int, error) {
 	var sqlstr string
-	var matchID int
+	var matchIDs []int
 
 	switch objSport.SportInternalID {
 	case 1, 7, 10:
 		sqlstr = "SELECT match_id FROM " + objSport.TableNameMatches + " WHERE league_id = ? AND season_id = ? AND round_id = " + roundWeekDate +
-			" AND home_team_id = ? AND away_team_id = ? AND status = ?  limit 1"
+			" AND home_team_id = ? AND away_team_id = ? AND status = ? ORDER BY match_date, match_time, match_id"
 
 	case 2, 4:
 		sqlstr = "SELECT match_id FROM " + objSport.TableNameMatches + " WHERE league_id = ? AND season_id = ? AND week_id = " + roundWeekDate +
-			" AND home_team_id = ? AND away_team_id = ? AND status = ? limit 1"
+			" AND home_team_id = ? AND away_team_id = ? AND status = ? ORDER BY match_date, match_time, match_id"
 
 	case 3, 5, 8, 9:
 		if (objSport.SportInternalID == 3 && leagueID == 2) || (objSport.SportInternalID == 5 && leagueID == 1) {
 			sqlstr = "SELECT match_id FROM " + objSport.TableNameMatches + " WHERE league_id = ? AND season_id = ? AND round_id = " + roundWeekDate +
-				" AND home_team_id = ? AND away_team_id = ? AND status = ?  limit 1"
+				" AND home_team_id = ? AND away_team_id = ? AND status = ? ORDER BY match_date, match_time, match_id"
 		} else {
-			substr := "ORDER BY match_time 	" + sortOder
 			sqlstr = "SELECT match_id FROM " + objSport.TableNameMatches + " WHERE league_id = ? AND season_id = ? AND match_date LIKE '%" + roundWeekDate + "' " +
-				" AND home_team_id = ? AND away_team_id = ? AND status = ?  " + substr + " limit 1"
+				" AND home_team_id = ? AND away_team_id = ? AND status = ? ORDER BY match_date, match_time, match_id"
 		}
+	default:
+		return matchIDs, nil
 	}
 
-	err := SportsDb.QueryRow(sqlstr, leagueID, seasonID, homeTeamID, awayTeamID, "Y").Scan(&matchID)
+	rows, err := SportsDb.Query(sqlstr, leagueID, seasonID, homeTeamID, awayTeamID, "Y")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var matchID int
+		err = rows.Scan(&matchID)
+		if err != nil {
+			return nil, err
+		}
+		matchIDs = append(matchIDs, matchID)
+	}
+
+	return matchIDs, nil
+}
+
+// GetGeniusOddsMatchLeague : league of a genius odds match, 0 when the match is not in the sport's matches table
+func GetGeniusOddsMatchLeague(objSport isg.Sport, matchID int) (int, error) {
+	var leagueID int
+
+	if !IsGeniusOddsSport(objSport.SportInternalID) {
+		return 0, nil
+	}
 
+	_, _, leagueColumn := geniusOddsMatchColumns(objSport.SportInternalID)
+	err := SportsDb.QueryRow("SELECT "+leagueColumn+" FROM "+objSport.TableNameMatches+" AS matches WHERE match_id = ?", matchID).Scan(&leagueID)
 	if err == sql.ErrNoRows {
 		return 0, nil
 	} else if err != nil {
 		return 0, err
 	}
-	return matchID, nil
+	return leagueID, nil
 }
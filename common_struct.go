@@ -90,6 +90,8 @@ type Sport struct {
 	TableNameSeasons string `json:"-"`
 	TableNameMatches string `json:"-"`
 	TableNamePlayers string `json:"-"`
+	TableNameLadder  string `json:"-"` // season ladder table with the form and streak of each team, empty when the sport has none
+	TableNameTips    string `json:"-"` // provider tips table of the matches, empty when the sport has none
 	SportAPICode     string `json:"-"`
 	SportURL         string `json:"sport_url,omitempty"`
 	SportLogo        string `json:"sport_logo"`
@@ -119,32 +121,35 @@ type Season struct {
 
 //Team :
 type Team struct {
-	TeamID                 string `json:"id"` // Official API ID e.g. kduw36b4
-	TeamInternalID         string `json:"-"`  // Internal DB ID e.g. 1428
-	TeamName               string `json:"name,omitempty"`
-	FullName               string `json:"full_name,omitempty"`
-	TeamShortName          string `json:"short_teamname,omitempty"`
-	TeamAltName1           string `json:"-"`
-	TeamAltName2           string `json:"-"`
-	Abbreviation           string `json:"abbr,omitempty"`
-	Conference             string `json:"conference,omitempty"`
-	Division               string `json:"division,omitempty"`
-	Ranking                string `json:"rank,omitempty"`
-	DivisionRanking        string `json:"division_rank,omitempty"`
-	DivisionDisplayRanking string `json:"division_display_rank,omitempty"`
-	TeamURL                string `json:"team_url,omitempty"`
-	TeamSBURL              string `json:"sb_team_url,omitempty"`
-	TeamFlag               string `json:"icon,omitempty"`
-	SortVal                int    `json:"-"`
-	TeamColor              string `json:"color,omitempty"`
-	TeamGroup              string `json:"group,omitempty"`
-	Pitcher                string `json:"pitcher,omitempty"`
-	Points                 int    `json:"-"`
-	TeamReverseName        string `json:"-"`
-	Country                string `json:"country,omitempty"`
-	CountryID              int    `json:"-"`
-	TeamNickName           string `json:"nick_name,omitempty"`
-	GroupRanking           string `json:"group_rank,omitempty"`
+	TeamID                 string      `json:"id"` // Official API ID e.g. kduw36b4
+	TeamInternalID         string      `json:"-"`  // Internal DB ID e.g. 1428
+	TeamName               string      `json:"name,omitempty"`
+	FullName               string      `json:"full_name,omitempty"`
+	TeamShortName          string      `json:"short_teamname,omitempty"`
+	TeamAltName1           string      `json:"-"`
+	TeamAltName2           string      `json:"-"`
+	Abbreviation           string      `json:"abbr,omitempty"`
+	Conference             string      `json:"conference,omitempty"`
+	Division               string      `json:"division,omitempty"`
+	Ranking                string      `json:"rank,omitempty"`
+	DivisionRanking        string      `json:"division_rank,omitempty"`
+	DivisionDisplayRanking string      `json:"division_display_rank,omitempty"`
+	TeamURL                string      `json:"team_url,omitempty"`
+	TeamSBURL              string      `json:"sb_team_url,omitempty"`
+	TeamFlag               string      `json:"icon,omitempty"`
+	SortVal                int         `json:"-"`
+	TeamColor              string      `json:"color,omitempty"`
+	TeamGroup              string      `json:"group,omitempty"`
+	Pitcher                string      `json:"pitcher,omitempty"`
+	Points                 int         `json:"-"`
+	TeamReverseName        string      `json:"-"`
+	Country                string      `json:"country,omitempty"`
+	CountryID              int         `json:"-"`
+	TeamNickName           string      `json:"nick_name,omitempty"`
+	GroupRanking           string      `json:"group_rank,omitempty"`
+	Form                   *TeamForm   `json:"form,omitempty"`
+	Travel                 *TeamTravel `json:"travel,omitempty"`
+	Hand                   string      `json:"hand,omitempty"`
 }
 
 type SportRound struct {
@@ -333,6 +338,8 @@ type MatchInfo struct {
 	AwayPastH2HResult      sql.NullString
 	HomeTeamNickName       sql.NullString
 	AwayTeamNickName       sql.NullString
+	HomeVenueInternalID    sql.NullInt64 // home ground of the home team, NULL when it has none
+	AwayVenueInternalID    sql.NullInt64 // home ground of the away team, NULL when it has none
 }
 
 // RoundWeek : holds all information for a round or week
@@ -354,15 +361,18 @@ type Venue struct {
 	VenueState      string `json:"state,omitempty"`
 	Latitude        string `json:"latitude,omitempty"`
 	Longitude       string `json:"longitude,omitempty"`
+	Continent       string `json:"continent,omitempty"`
+	Timezone        string `json:"timezone,omitempty"`
 }
 
 // Tips : holds all information for a tips by macth
 type Tips struct {
-	TipsTitle string `json:"title,omitempty"`
-	Option1   string `json:"option1_title,omitempty"`
-	Option2   string `json:"option2_title,omitempty"`
-	Tips      string `json:"tip,omitempty"`
-	Roughie   string `json:"roughie,omitempty"`
+	TipsTitle    string `json:"title,omitempty"`
+	Option1      string `json:"option1_title,omitempty"`
+	Option2      string `json:"option2_title,omitempty"`
+	Tips         string `json:"tip,omitempty"`
+	Roughie      string `json:"roughie,omitempty"`
+	ProviderName string `json:"provider,omitempty"`
 }
 
 // Odds :
@@ -1019,9 +1029,21 @@ type GeniusSportsMatch struct {
 	MatchReschedule    int
 	MatchOdds          FixtureOdds
 	IntMatchOdds       []IntMarketInfo
+	ExoticMatchOdds    []GeniusOddsMarket
+	ModelInfo          *GeniusOddsModelMatch
+	ModelMatchOdds     []IntMarketInfo
+	HomeFormInfo       *TeamForm
+	AwayFormInfo       *TeamForm
+	PreviewInfo        string
+	TipsInfo           []Tips
+	WeatherInfo        *WeatherInfo
+	HomeTravelInfo     *TeamTravel
+	AwayTravelInfo     *TeamTravel
+	TennisInfo         *MatchInfo
 	PlungeOddsList     []GeniusOddsPlunge
 	MatchTeamRank      sql.NullInt64
 	TypeVal            string
+	StartTime          time.Time
 }
 
 // for Listing on Sports Sequence for team rank is same
@@ -1113,33 +1135,39 @@ type MarketOptions struct {
 
 // OddsInfo :
 type OddsInfo struct {
-	ProviderInfo       *ExtProvider `json:"provider,omitempty"`
-	Name               string       `json:"name,omitempty"`
-	Icon               string       `json:"icon,omitempty"`
-	OpenOdds           *float64     `json:"open_price,omitempty"`
-	NewOdds            *float64     `json:"price,omitempty"`
-	OpenLine           *float64     `json:"open_line,omitempty"`
-	NewLine            *float64     `json:"current_line,omitempty"`
-	OpenTotal          *float64     `json:"open_closing_total,omitempty"`
-	NewTotal           *float64     `json:"current_closing_total,omitempty"`
-	FlucPer            *float64     `json:"fluc_per,omitempty"`
-	UpDownArrow        string       `json:"up_down_arrow,omitempty"`
-	ProviderOrder      int          `json:"order,omitempty"`
-	MarketID           string       `json:"market,omitempty"`
-	Flucs              []*float64   `json:"fluc,omitempty"`
-	MarketInternalID   int          `json:"-"`
-	MarketName         string       `json:"market_name,omitempty"`
-	DisplayName        string       `json:"-"`
-	CategoryInternalID int          `json:"-"`
-	CategoryName       string       `json:"-"`
-	OppoTeamName       string       `json:"team_name,omitempty"`
-	PlayerName         string       `json:"player_name,omitempty"`
-	MatchID            int          `json:"-"`
-	SportID            int          `json:"-"`
-	LeagueID           int          `json:"-"`
-	TeamID             int          `json:"-"`
-	ProviderSequence   int          `json:"-"`
-	TypeVal            string       `json:"-"`
+	ProviderInfo       *ExtProvider      `json:"provider,omitempty"`
+	Name               string            `json:"name,omitempty"`
+	Icon               string            `json:"icon,omitempty"`
+	OpenOdds           *float64          `json:"open_price,omitempty"`
+	NewOdds            *float64          `json:"price,omitempty"`
+	OpenLine           *float64          `json:"open_line,omitempty"`
+	NewLine            *float64          `json:"current_line,omitempty"`
+	OpenTotal          *float64          `json:"open_closing_total,omitempty"`
+	NewTotal           *float64          `json:"current_closing_total,omitempty"`
+	FlucPer            *float64          `json:"fluc_per,omitempty"`
+	UpDownArrow        string            `json:"up_down_arrow,omitempty"`
+	ProviderOrder      int               `json:"order,omitempty"`
+	MarketID           string            `json:"market,omitempty"`
+	Flucs              []*float64        `json:"fluc,omitempty"`
+	MarketInternalID   int               `json:"-"`
+	MarketName         string            `json:"market_name,omitempty"`
+	ISGapiID           string            `json:"-"`
+	DisplayName        string            `json:"-"`
+	CategoryInternalID int               `json:"-"`
+	CategoryName       string            `json:"-"`
+	OppoTeamName       string            `json:"team_name,omitempty"`
+	PlayerName         string            `json:"player_name,omitempty"`
+	PlayerInfo         *GeniusOddsPlayer `json:"-"`
+	MatchID            int               `json:"-"`
+	SportID            int               `json:"-"`
+	LeagueID           int               `json:"-"`
+	TeamID             int               `json:"-"`
+	ProviderSequence   int               `json:"-"`
+	TypeVal            string            `json:"-"`
+	OddsFormat         string            `json:"odds_format,omitempty"`
+	OpenOddsFmt        string            `json:"open_price_fmt,omitempty"`
+	NewOddsFmt         string            `json:"price_fmt,omitempty"`
+	FlucsFmt           []string          `json:"fluc_fmt,omitempty"`
 }
 
 // GeniusOddsMarket :
@@ -1165,6 +1193,9 @@ type GeniusOddsMarket struct {
 	DisplayName      string
 	Sequence         int
 	ISGapiID         string
+	PlayerID         int64
+	PlayerInfo       *GeniusOddsPlayer
+	LastUpdate       string
 }
 
 //CheckValueInArray : check val in existing array
@@ -1195,10 +1226,12 @@ type CorrectScoreDetails struct {
 
 //WeatherInfo :
 type WeatherInfo struct {
-	Weather     string `json:"weather,omitempty"`
-	Temperature string `json:"temperature,omitempty"`
-	WindSpeed   string `json:"wind_speed,omitempty"`
-	Precip      string `json:"precip,omitempty"`
+	Weather      string `json:"weather,omitempty"`
+	Temperature  string `json:"temperature,omitempty"`
+	WindSpeed    string `json:"wind_speed,omitempty"`
+	Precip       string `json:"precip,omitempty"`
+	PrecipChance string `json:"precip_chance,omitempty"`
+	Updated      string `json:"updated,omitempty"`
 }
 
 // GetDisplayRank :
@@ -0,0 +1,56 @@
+package data
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/thegeniusgroup/isgdatalib"
+)
+
+func TestGeniusOddsCursorSQL(t *testing.T) {
+
+	if searchStr := geniusOddsCursorSQL(nil, 1); searchStr != "" {
+		t.Errorf("no cursor = %q", searchStr)
+	}
+
+	// the cursor is on an NRL match, sequence 2
+	cursor := &isg.GeniusOddsCursor{Type: "upcoming", CounterDateTime: "2024-05-01 19:40:00", Sequence: 2, MatchID: 7}
+
+	cases := map[int]string{
+		1:  "concat(matches.counter_date, ' ', matches.counter_time) > '2024-05-01 19:40:00' AND ",
+		10: "concat(matches.counter_date, ' ', matches.counter_time) >= '2024-05-01 19:40:00' AND ",
+		7:  "= '2024-05-01 19:40:00'  AND matches.match_id > 7)) AND ",
+	}
+	for sportID, want := range cases {
+		if searchStr := geniusOddsCursorSQL(cursor, sportID); !strings.HasSuffix(searchStr, want) {
+			t.Errorf("sport %d = %q", sportID, searchStr)
+		}
+	}
+}
+
+func TestGeniusOddsWindowSQL(t *testing.T) {
+
+	AEST = time.FixedZone("AEST", 10*60*60)
+	from := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	objWindow := isg.GeniusOddsWindow{From: from, MatchIDs: []int64{3, 4}}
+
+	searchStr := geniusOddsWindowSQL(objWindow)
+	if !strings.Contains(searchStr, "BETWEEN '"+CounterDateTimeString(from)+"' AND '"+CounterDateTimeString(from.Add(isg.GeniusOddsWindowDays*24*time.Hour))+"'") ||
+		!strings.HasSuffix(searchStr, " matches.match_id IN (3,4) AND ") {
+		t.Errorf("window = %q", searchStr)
+	}
+}
+
+func TestGeniusOddsFilterSQL(t *testing.T) {
+
+	if filterStr, args := geniusOddsFilterSQL(isg.GeniusOddsFilter{}); filterStr != "" || len(args) != 0 {
+		t.Errorf("no filter = %q %v", filterStr, args)
+	}
+
+	filterStr, args := geniusOddsFilterSQL(isg.GeniusOddsFilter{Providers: []string{"3"}, Categories: []string{"line", "total"}, Markets: []string{"win"}})
+	want := " AND marketodds.provider_id IN (?)  AND LOWER(map.market_name) IN (?, ?)  AND market.isg_api_id IN (?) "
+	if filterStr != want || len(args) != 4 || args[0] != "3" || args[1] != "line" || args[2] != "total" || args[3] != "win" {
+		t.Errorf("filter = %q %v", filterStr, args)
+	}
+}
@@ -0,0 +1,353 @@
+package isg
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// CorrectScoreBandWidth : points per score band, soccer and hockey are priced on exact scores
+var CorrectScoreBandWidth = map[int]int{
+	1: 10, // AFL
+	4: 1,  // Soccer
+	7: 6,  // NRL
+	8: 1,  // Hockey
+}
+
+// CorrectScoreOdds : a stored correct score price, Score is from the point of view of TeamID as written by UpdateCorrectScoreOdds
+type CorrectScoreOdds struct {
+	MatchID      int64
+	ProviderInfo Provider
+	TeamID       int64
+	HomeTeamID   int64
+	AwayTeamID   int64
+	Score        string
+	Odds         *float64
+	MarketID     string
+}
+
+// CorrectScoreCell : one home by away score of the grid
+type CorrectScoreCell struct {
+	HomeScore   int      `json:"home_score"`
+	AwayScore   int      `json:"away_score"`
+	Score       string   `json:"score"`
+	Result      string   `json:"result"`
+	Odds        *float64 `json:"price"`
+	OddsFormat  string   `json:"odds_format,omitempty"`
+	OddsFmt     string   `json:"price_fmt,omitempty"`
+	MarketID    string   `json:"market,omitempty"`
+	ImpliedProb float64  `json:"implied_prob"`
+}
+
+// CorrectScoreProb : implied probability of a derived selection with its fair price
+type CorrectScoreProb struct {
+	ImpliedProb  float64  `json:"implied_prob"`
+	FairPrice    *float64 `json:"fair_price,omitempty"`
+	FairPriceFmt string   `json:"fair_price_fmt,omitempty"`
+}
+
+// CorrectScoreTotal :
+type CorrectScoreTotal struct {
+	Line  float64          `json:"line"`
+	Over  CorrectScoreProb `json:"over"`
+	Under CorrectScoreProb `json:"under"`
+}
+
+// CorrectScoreMargin : positive margins are home wins, negative are away wins
+type CorrectScoreMargin struct {
+	Margin int              `json:"margin"`
+	Prob   CorrectScoreProb `json:"prob"`
+}
+
+// CorrectScoreDerived : markets derived from the grid
+type CorrectScoreDerived struct {
+	HomeWin CorrectScoreProb     `json:"home_win"`
+	Draw    CorrectScoreProb     `json:"draw"`
+	AwayWin CorrectScoreProb     `json:"away_win"`
+	BTTSYes *CorrectScoreProb    `json:"btts_yes,omitempty"`
+	BTTSNo  *CorrectScoreProb    `json:"btts_no,omitempty"`
+	Totals  []CorrectScoreTotal  `json:"totals,omitempty"`
+	Margins []CorrectScoreMargin `json:"margins,omitempty"`
+}
+
+// CorrectScoreGrid : a provider's correct score prices as a home by away grid, rows follow HomeScores and columns AwayScores
+type CorrectScoreGrid struct {
+	Name             string                `json:"name"`
+	Icon             string                `json:"icon,omitempty"`
+	ProviderSequence int                   `json:"-"`
+	HomeScores       []int                 `json:"home_scores"`
+	AwayScores       []int                 `json:"away_scores"`
+	Grid             [][]*CorrectScoreCell `json:"grid"`
+	Overround        float64               `json:"overround"`
+	Derived          CorrectScoreDerived   `json:"derived"`
+}
+
+// CorrectScoreMatch : correct score grids of a match per provider
+type CorrectScoreMatch struct {
+	SportID   string             `json:"sport_id,omitempty"`
+	SportName string             `json:"sport_name,omitempty"`
+	SportURL  string             `json:"sport_url,omitempty"`
+	MatchID   int64              `json:"match_id"`
+	BandWidth int                `json:"band_width"`
+	Providers []CorrectScoreGrid `json:"providers,omitempty"`
+}
+
+// GetCorrectScoreBand : start of the score band of a score e.g. 87 is 80 with the 10 point AFL bands
+func GetCorrectScoreBand(sportID, score int) int {
+	width := CorrectScoreBandWidth[sportID]
+	if width <= 1 {
+		return score
+	}
+	return score / width * width
+}
+
+// ParseCorrectScore : splits a "2 - 1" score
+func ParseCorrectScore(score string) (int, int, error) {
+
+	scores := strings.Split(score, "-")
+	if len(scores) != 2 {
+		return 0, 0, errors.New(ISGErrBadInputPrefix + "invalid correct score " + score)
+	}
+
+	score1, err := strconv.Atoi(strings.TrimSpace(scores[0]))
+	if err != nil {
+		return 0, 0, errors.New(ISGErrBadInputPrefix + "invalid correct score " + score)
+	}
+	score2, err := strconv.Atoi(strings.TrimSpace(scores[1]))
+	if err != nil {
+		return 0, 0, errors.New(ISGErrBadInputPrefix + "invalid correct score " + score)
+	}
+
+	return score1, score2, nil
+}
+
+// BindingCorrectScoreGrids : one grid per provider, the records must be ordered by provider
+func BindingCorrectScoreGrids(records []CorrectScoreOdds, bandWidth int) []CorrectScoreGrid {
+
+	var grids []CorrectScoreGrid
+
+	for i := 0; i < len(records); {
+		j := i
+		for j < len(records) && records[j].ProviderInfo.ProviderId == records[i].ProviderInfo.ProviderId {
+			j++
+		}
+
+		grid := bindingCorrectScoreGrid(records[i:j], bandWidth)
+		if len(grid.Grid) > 0 {
+			grids = append(grids, grid)
+		}
+		i = j
+	}
+
+	return grids
+}
+
+// correctScoreSelection : a priced result of a provider, Result is 1 for a home win, -1 for an away win and 0 for a draw
+type correctScoreSelection struct {
+	HomeScore int
+	AwayScore int
+	Result    int
+	Odds      *float64
+	MarketID  string
+}
+
+// correctScoreResults : result names of the grid cells
+var correctScoreResults = map[int]string{1: "home", 0: "draw", -1: "away"}
+
+// bindingCorrectScoreGrid : turns the winner / loser scores of a provider into home / away cells with the overround removed.
+// Band sports can price a win and a draw on the same banded score, the cell shows the best of them and the derived
+// markets use every selection.
+func bindingCorrectScoreGrid(records []CorrectScoreOdds, bandWidth int) CorrectScoreGrid {
+
+	var grid CorrectScoreGrid
+	grid.Name = records[0].ProviderInfo.Name
+	grid.Icon = records[0].ProviderInfo.Icon
+	grid.ProviderSequence = records[0].ProviderInfo.GeniusOddsSequence
+
+	// draws are stored once for each team with the same market, a level banded score stored for one team is a win
+	levelTeams := map[string]map[int64]bool{}
+	for _, record := range records {
+		if score1, score2, err := ParseCorrectScore(record.Score); err == nil && score1 == score2 {
+			key := record.Score + "-" + record.MarketID
+			if levelTeams[key] == nil {
+				levelTeams[key] = map[int64]bool{}
+			}
+			levelTeams[key][record.TeamID] = true
+		}
+	}
+
+	var selections []correctScoreSelection
+	draws := map[string]bool{}
+	for _, record := range records {
+
+		if record.Odds == nil || *record.Odds <= 1 {
+			continue
+		}
+
+		score1, score2, err := ParseCorrectScore(record.Score)
+		if err != nil {
+			continue
+		}
+
+		homeScore, awayScore := score1, score2
+		if record.TeamID == record.AwayTeamID {
+			homeScore, awayScore = score2, score1
+		}
+
+		selection := correctScoreSelection{HomeScore: homeScore, AwayScore: awayScore, Odds: record.Odds, MarketID: record.MarketID}
+		if homeScore > awayScore {
+			selection.Result = 1
+		} else if homeScore < awayScore {
+			selection.Result = -1
+		} else if key := record.Score + "-" + record.MarketID; bandWidth <= 1 || len(levelTeams[key]) > 1 {
+			if draws[key] {
+				continue
+			}
+			draws[key] = true
+		} else if record.TeamID == record.HomeTeamID {
+			selection.Result = 1
+		} else {
+			selection.Result = -1
+		}
+		selections = append(selections, selection)
+	}
+
+	if len(selections) == 0 {
+		return grid
+	}
+
+	var booksum float64
+	for _, selection := range selections {
+		booksum += 1 / *selection.Odds
+	}
+	grid.Overround = Round(booksum*100, .5, 2)
+
+	cells := map[[2]int]*CorrectScoreCell{}
+	for _, selection := range selections {
+
+		key := [2]int{selection.HomeScore, selection.AwayScore}
+		if cell, ok := cells[key]; ok && *cell.Odds >= *selection.Odds {
+			continue
+		}
+
+		cells[key] = &CorrectScoreCell{
+			HomeScore:   selection.HomeScore,
+			AwayScore:   selection.AwayScore,
+			Score:       strconv.Itoa(selection.HomeScore) + " - " + strconv.Itoa(selection.AwayScore),
+			Result:      correctScoreResults[selection.Result],
+			Odds:        selection.Odds,
+			MarketID:    selection.MarketID,
+			ImpliedProb: Round(1 / *selection.Odds / booksum, .5, 4),
+		}
+	}
+
+	homeAxis := map[int]bool{}
+	awayAxis := map[int]bool{}
+	for key := range cells {
+		homeAxis[key[0]] = true
+		awayAxis[key[1]] = true
+	}
+	for score := range homeAxis {
+		grid.HomeScores = append(grid.HomeScores, score)
+	}
+	for score := range awayAxis {
+		grid.AwayScores = append(grid.AwayScores, score)
+	}
+	sort.Ints(grid.HomeScores)
+	sort.Ints(grid.AwayScores)
+
+	grid.Grid = make([][]*CorrectScoreCell, len(grid.HomeScores))
+	for h, homeScore := range grid.HomeScores {
+		grid.Grid[h] = make([]*CorrectScoreCell, len(grid.AwayScores))
+		for a, awayScore := range grid.AwayScores {
+			grid.Grid[h][a] = cells[[2]int{homeScore, awayScore}]
+		}
+	}
+
+	grid.Derived = deriveCorrectScoreMarkets(selections, booksum, bandWidth)
+	return grid
+}
+
+// deriveCorrectScoreMarkets : result, BTTS, totals and winning margin from the normalised selection probabilities.
+// Band sports work on the band starts, so their totals and margins are as coarse as the bands and BTTS is left out.
+func deriveCorrectScoreMarkets(selections []correctScoreSelection, booksum float64, bandWidth int) CorrectScoreDerived {
+
+	var derived CorrectScoreDerived
+	var homeWin, draw, awayWin, bttsYes float64
+
+	margins := map[int]float64{}
+	totals := map[int]float64{}
+	for _, selection := range selections {
+
+		prob := 1 / *selection.Odds / booksum
+		switch selection.Result {
+		case 1:
+			homeWin += prob
+		case 0:
+			draw += prob
+		default:
+			awayWin += prob
+		}
+		margins[selection.HomeScore-selection.AwayScore] += prob
+		totals[selection.HomeScore+selection.AwayScore] += prob
+
+		if selection.HomeScore > 0 && selection.AwayScore > 0 {
+			bttsYes += prob
+		}
+	}
+
+	derived.HomeWin = getCorrectScoreProb(homeWin)
+	derived.Draw = getCorrectScoreProb(draw)
+	derived.AwayWin = getCorrectScoreProb(awayWin)
+
+	if bandWidth <= 1 {
+		objYes := getCorrectScoreProb(bttsYes)
+		objNo := getCorrectScoreProb(1 - bttsYes)
+		derived.BTTSYes = &objYes
+		derived.BTTSNo = &objNo
+	}
+
+	var totalKeys []int
+	for total := range totals {
+		totalKeys = append(totalKeys, total)
+	}
+	sort.Ints(totalKeys)
+
+	var under float64
+	for k, total := range totalKeys {
+		under += totals[total]
+		if k == len(totalKeys)-1 {
+			break
+		}
+		var objTotal CorrectScoreTotal
+		objTotal.Line = float64(total) + 0.5
+		objTotal.Under = getCorrectScoreProb(under)
+		objTotal.Over = getCorrectScoreProb(1 - under)
+		derived.Totals = append(derived.Totals, objTotal)
+	}
+
+	var marginKeys []int
+	for margin := range margins {
+		marginKeys = append(marginKeys, margin)
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(marginKeys)))
+
+	for _, margin := range marginKeys {
+		derived.Margins = append(derived.Margins, CorrectScoreMargin{Margin: margin, Prob: getCorrectScoreProb(margins[margin])})
+	}
+
+	return derived
+}
+
+// getCorrectScoreProb :
+func getCorrectScoreProb(prob float64) CorrectScoreProb {
+
+	var objProb CorrectScoreProb
+	objProb.ImpliedProb = Round(prob, .5, 4)
+	if prob > 0 {
+		fairPrice := Round(1/prob, .5, 2)
+		objProb.FairPrice = &fairPrice
+	}
+	return objProb
+}
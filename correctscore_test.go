@@ -0,0 +1,157 @@
+package isg
+
+import (
+	"math"
+	"testing"
+)
+
+func TestGetCorrectScoreBand(t *testing.T) {
+
+	tests := []struct {
+		sportID int
+		score   int
+		want    int
+	}{
+		{1, 87, 80},
+		{1, 80, 80},
+		{1, 9, 0},
+		{7, 17, 12},
+		{7, 18, 18},
+		{4, 3, 3},
+		{8, 5, 5},
+		{2, 27, 27},
+	}
+
+	for _, tt := range tests {
+		if got := GetCorrectScoreBand(tt.sportID, tt.score); got != tt.want {
+			t.Errorf("GetCorrectScoreBand(%d, %d) = %d, want %d", tt.sportID, tt.score, got, tt.want)
+		}
+	}
+}
+
+func TestParseCorrectScore(t *testing.T) {
+
+	score1, score2, err := ParseCorrectScore("2 - 1")
+	if err != nil || score1 != 2 || score2 != 1 {
+		t.Errorf("ParseCorrectScore(2 - 1) = %d, %d, %v", score1, score2, err)
+	}
+
+	for _, score := range []string{"", "2", "2 - x", "1 - 2 - 3"} {
+		if _, _, err := ParseCorrectScore(score); err == nil {
+			t.Errorf("ParseCorrectScore(%q) expected an error", score)
+		}
+	}
+}
+
+// correctScoreRecord : a stored price of provider 1 for home team 10 v away team 20
+func correctScoreRecord(teamID int64, score string, odds float64, marketID string) CorrectScoreOdds {
+	return CorrectScoreOdds{MatchID: 1, ProviderInfo: Provider{ProviderId: "1", Name: "A"}, TeamID: teamID, HomeTeamID: 10, AwayTeamID: 20,
+		Score: score, Odds: &odds, MarketID: marketID}
+}
+
+func TestBindingCorrectScoreGridSoccer(t *testing.T) {
+
+	records := []CorrectScoreOdds{
+		correctScoreRecord(10, "1 - 0", 4, "m1"),
+		correctScoreRecord(20, "1 - 0", 5, "m2"),
+		correctScoreRecord(10, "2 - 1", 8, "m3"),
+		// draws are stored for both teams
+		correctScoreRecord(10, "1 - 1", 4, "m4"),
+		correctScoreRecord(20, "1 - 1", 4, "m4"),
+		correctScoreRecord(10, "0 - 0", 5, "m5"),
+		correctScoreRecord(20, "0 - 0", 5, "m5"),
+	}
+
+	grids := BindingCorrectScoreGrids(records, CorrectScoreBandWidth[4])
+	if len(grids) != 1 {
+		t.Fatalf("got %d grids, want 1", len(grids))
+	}
+	grid := grids[0]
+
+	booksum := 1.0/4 + 1.0/5 + 1.0/8 + 1.0/4 + 1.0/5
+	if want := Round(booksum*100, .5, 2); grid.Overround != want {
+		t.Errorf("overround = %v, want %v", grid.Overround, want)
+	}
+
+	// the away win is oriented as 0 - 1
+	if cell := grid.Grid[0][1]; cell == nil || cell.Score != "0 - 1" || cell.Result != "away" {
+		t.Errorf("grid[0][1] = %+v, want the 0 - 1 away win", cell)
+	}
+
+	derived := grid.Derived
+	checkCorrectScoreProb(t, "home win", derived.HomeWin, (1.0/4+1.0/8)/booksum)
+	checkCorrectScoreProb(t, "draw", derived.Draw, (1.0/4+1.0/5)/booksum)
+	checkCorrectScoreProb(t, "away win", derived.AwayWin, (1.0/5)/booksum)
+
+	// both teams score in 2 - 1 and 1 - 1 only
+	if derived.BTTSYes == nil || derived.BTTSNo == nil {
+		t.Fatal("BTTS expected for soccer")
+	}
+	checkCorrectScoreProb(t, "btts yes", *derived.BTTSYes, (1.0/8+1.0/4)/booksum)
+}
+
+func TestBindingCorrectScoreGridBands(t *testing.T) {
+
+	records := []CorrectScoreOdds{
+		// 87 - 82 is a home win stored on the 80 - 80 band
+		correctScoreRecord(10, "80 - 80", 10, "m1"),
+		// 85 - 85 is a draw stored for both teams
+		correctScoreRecord(10, "80 - 80", 50, "m2"),
+		correctScoreRecord(20, "80 - 80", 50, "m2"),
+		correctScoreRecord(20, "90 - 70", 12, "m3"),
+	}
+
+	grids := BindingCorrectScoreGrids(records, CorrectScoreBandWidth[1])
+	if len(grids) != 1 {
+		t.Fatalf("got %d grids, want 1", len(grids))
+	}
+	grid := grids[0]
+
+	booksum := 1.0/10 + 1.0/50 + 1.0/12
+	derived := grid.Derived
+	checkCorrectScoreProb(t, "home win", derived.HomeWin, (1.0/10)/booksum)
+	checkCorrectScoreProb(t, "draw", derived.Draw, (1.0/50)/booksum)
+	checkCorrectScoreProb(t, "away win", derived.AwayWin, (1.0/12)/booksum)
+
+	if derived.BTTSYes != nil {
+		t.Error("BTTS is left out of band sports")
+	}
+
+	// the shared 80 - 80 cell keeps the best price
+	for h, homeScore := range grid.HomeScores {
+		for a, awayScore := range grid.AwayScores {
+			cell := grid.Grid[h][a]
+			if homeScore == 80 && awayScore == 80 && (cell == nil || *cell.Odds != 50 || cell.Result != "draw") {
+				t.Errorf("80 - 80 cell = %+v, want the draw at 50", cell)
+			}
+		}
+	}
+}
+
+func TestFormatCorrectScoreGrids(t *testing.T) {
+
+	records := []CorrectScoreOdds{
+		correctScoreRecord(10, "1 - 0", 2.5, "m1"),
+		correctScoreRecord(20, "1 - 0", 2.5, "m2"),
+	}
+	grids := BindingCorrectScoreGrids(records, 1)
+	FormatCorrectScoreGrids(grids, OddsFormatFractional)
+
+	for _, row := range grids[0].Grid {
+		for _, cell := range row {
+			if cell != nil && cell.OddsFmt != "6/4" {
+				t.Errorf("cell %s price_fmt = %q, want 6/4", cell.Score, cell.OddsFmt)
+			}
+		}
+	}
+	if grids[0].Derived.HomeWin.FairPriceFmt != "1/1" {
+		t.Errorf("home win fair_price_fmt = %q, want 1/1", grids[0].Derived.HomeWin.FairPriceFmt)
+	}
+}
+
+func checkCorrectScoreProb(t *testing.T, name string, prob CorrectScoreProb, want float64) {
+	t.Helper()
+	if math.Abs(prob.ImpliedProb-want) > 0.0001 {
+		t.Errorf("%s implied prob = %v, want %.4f", name, prob.ImpliedProb, want)
+	}
+}
@@ -26,6 +26,16 @@ var GeniusStatsDb *sql.DB
 // AEST : set dafault time zone
 var AEST *time.Location
 
+// GetCounterDateTime : kick off instant of a match, counter_date / counter_time are stored in AEST
+func GetCounterDateTime(counterDate, counterTime string) (time.Time, error) {
+	return time.ParseInLocation("2006-01-02 15:04:05", counterDate+" "+counterTime, AEST)
+}
+
+// CounterDateTimeString : formats an instant in the AEST counter_date / counter_time format used by the match queries
+func CounterDateTimeString(t time.Time) string {
+	return t.In(AEST).Format("2006-01-02 15:04:05")
+}
+
 // InitDB initialises the database pools with
 func InitDB(host, port, user, password, hostAU, passAU string) (sportsDb, sportsDbAU, userDb *sql.DB, logDb *sql.DB, geniusStatsDb *sql.DB, err error) {
 	SportsDb, err = sql.Open("mysql", user+":"+password+"@tcp("+host+":"+port+")/isports")
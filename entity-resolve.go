@@ -0,0 +1,70 @@
+package sports
+
+import (
+	"data"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+	"util"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+// ResolveTeam : teams matching a feed name with the confidence of each match, best first. ?limit= defaults to 5.
+// GET  /resolve/team?sport=&q=&limit=
+func ResolveTeam(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+
+	sportname := util.CleanText(r.URL.Query().Get("sport"), true, true)
+	query := util.CleanText(r.URL.Query().Get("q"), false, true)
+
+	if sportname == "" {
+		util.WebResponse(w, r, http.StatusNotFound, "sport not found")
+		return
+	}
+
+	objsport, err := data.GetSport(sportname)
+	if err != nil {
+		util.WebResponse(w, r, http.StatusNotFound, "sport not found")
+		return
+	}
+
+	if query == "" {
+		util.WebResponse(w, r, http.StatusBadRequest, "q not found")
+		return
+	}
+
+	limit := 5
+	if val := r.URL.Query().Get("limit"); val != "" {
+		limit, err = strconv.Atoi(val)
+		if err != nil || limit <= 0 {
+			util.WebResponse(w, r, http.StatusBadRequest, "invalid limit value :"+val)
+			return
+		}
+	}
+
+	t := data.ResolveTeamCandidates(objsport.SportInternalID, query, limit)
+	if len(t) == 0 {
+		util.WebResponse(w, r, http.StatusNotFound, "record not found")
+		return
+	}
+
+	final := util.JSONMessageWrappedObj(http.StatusOK, t)
+	util.WebResponseJSONObjectNoCache(w, r, http.StatusOK, final)
+	return
+}
+
+// StartEntityResolverRefresh : rebuilds the team name resolvers of /resolve/team whenever the teams or their aliases change
+func StartEntityResolverRefresh(interval time.Duration) {
+	for {
+		time.Sleep(interval)
+		refreshed, err := data.RefreshEntityResolvers()
+		if err != nil {
+			fmt.Println(err.Error())
+			continue
+		}
+		if refreshed {
+			fmt.Println("Entity resolvers refreshed.")
+		}
+	}
+}
@@ -0,0 +1,301 @@
+package isg
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Entity match methods
+const (
+	EntityMatchExact    = "exact"
+	EntityMatchAlias    = "alias"
+	EntityMatchInitials = "initials"
+	EntityMatchFuzzy    = "fuzzy"
+)
+
+// EntityMinConfidence : fuzzy matches below it are not resolved, EntityAmbiguousConfidence : confidence of each entity
+// of a normalised name shared by several entities
+const (
+	EntityMinConfidence       = 0.75
+	EntityAmbiguousConfidence = 0.5
+)
+
+// entityStopWords : words left out of the normalised names e.g. "The Sydney FC" -> "sydney", so "Sydney FC" and
+// "Sydney Club" share a name and are both returned as candidates
+var entityStopWords = map[string]bool{"the": true, "fc": true, "club": true}
+
+// EntityMatch : a resolved team / venue with the name it matched on
+type EntityMatch struct {
+	ID         int     `json:"id"`
+	Name       string  `json:"name"`
+	Matched    string  `json:"matched"`
+	Method     string  `json:"method"`
+	Confidence float64 `json:"confidence"`
+}
+
+// entityName : one of the names of an entity, Method is the match method when the query equals it
+type entityName struct {
+	ID       int
+	Name     string
+	Norm     string
+	Method   string
+	Trigrams map[string]bool
+}
+
+// EntityResolver : in memory name lookup of one entity type of a sport, built at preload
+type EntityResolver struct {
+	display map[int]string
+	exact   map[string][]entityName
+	names   []entityName
+}
+
+// NewEntityResolver :
+func NewEntityResolver() *EntityResolver {
+	return &EntityResolver{display: map[int]string{}, exact: map[string][]entityName{}}
+}
+
+// Add : adds an entity with its display name and the other name columns, the initials of the longer names
+// (e.g. "GWS Giants" for "Greater Western Sydney Giants") are added as well
+func (objResolver *EntityResolver) Add(id int, name string, altNames ...string) {
+
+	if _, ok := objResolver.display[id]; !ok {
+		objResolver.display[id] = name
+	}
+
+	for _, val := range append([]string{name}, altNames...) {
+		norm := NormaliseEntityName(val)
+		if norm == "" {
+			continue
+		}
+		objResolver.add(entityName{ID: id, Name: val, Norm: norm, Method: EntityMatchExact})
+
+		words := strings.Fields(norm)
+		for k := 2; k <= len(words) && len(words) >= 2; k++ {
+			var initials string
+			for _, word := range words[:k] {
+				initials += string([]rune(word)[0])
+			}
+			variant := strings.TrimSpace(initials + " " + strings.Join(words[k:], " "))
+			objResolver.add(entityName{ID: id, Name: val, Norm: variant, Method: EntityMatchInitials})
+		}
+	}
+}
+
+// AddAlias : a known spelling of a feed e.g. "Brisbane Broncos" -> "Broncos"
+func (objResolver *EntityResolver) AddAlias(id int, alias string) {
+	norm := NormaliseEntityName(alias)
+	if norm == "" {
+		return
+	}
+	objResolver.add(entityName{ID: id, Name: alias, Norm: norm, Method: EntityMatchAlias})
+}
+
+// add : exact names win over initials, every entity with the same kind of name is kept when a normalised name is shared
+func (objResolver *EntityResolver) add(objName entityName) {
+	objName.Trigrams = entityTrigrams(objName.Norm)
+	objResolver.names = append(objResolver.names, objName)
+
+	current := objResolver.exact[objName.Norm]
+	if len(current) == 0 || (current[0].Method == EntityMatchInitials && objName.Method != EntityMatchInitials) {
+		objResolver.exact[objName.Norm] = []entityName{objName}
+		return
+	}
+	if (current[0].Method == EntityMatchInitials) != (objName.Method == EntityMatchInitials) {
+		return
+	}
+	for _, objCurrent := range current {
+		if objCurrent.ID == objName.ID {
+			return
+		}
+	}
+	objResolver.exact[objName.Norm] = append(current, objName)
+}
+
+// Resolve : best matches of the query, one per entity with the highest confidence first. A name shared by several
+// entities returns each of them with EntityAmbiguousConfidence.
+func (objResolver *EntityResolver) Resolve(query string, limit int) []EntityMatch {
+
+	var matches []EntityMatch
+
+	norm := NormaliseEntityName(query)
+	if norm == "" {
+		return matches
+	}
+
+	exact := map[int]bool{}
+	for _, objName := range objResolver.exact[norm] {
+		confidence := 1.0
+		if len(objResolver.exact[norm]) > 1 {
+			confidence = EntityAmbiguousConfidence
+		} else if objName.Method == EntityMatchInitials {
+			confidence = 0.95
+		}
+		matches = append(matches, objResolver.binding(objName, confidence))
+		exact[objName.ID] = true
+	}
+
+	trigrams := entityTrigrams(norm)
+	best := map[int]EntityMatch{}
+	for _, objName := range objResolver.names {
+		if exact[objName.ID] {
+			continue
+		}
+		// trigrams catch reordered words, the edit distance catches typos and a query of whole words of the name
+		// (e.g. "Geelong" for "Geelong Cats") scores on the share of the name it covers
+		confidence := 0.6*trigramSimilarity(trigrams, objName.Trigrams) + 0.4*levenshteinSimilarity(norm, objName.Norm)
+		if words := wordSimilarity(norm, objName.Norm); words > confidence {
+			confidence = words
+		}
+		confidence = Round(confidence, .5, 3)
+		if objMatch, ok := best[objName.ID]; !ok || confidence > objMatch.Confidence {
+			objMatch = objResolver.binding(objName, confidence)
+			objMatch.Method = EntityMatchFuzzy
+			best[objName.ID] = objMatch
+		}
+	}
+
+	for _, objMatch := range best {
+		matches = append(matches, objMatch)
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].Confidence != matches[j].Confidence {
+			return matches[i].Confidence > matches[j].Confidence
+		}
+		return matches[i].ID < matches[j].ID
+	})
+
+	if limit > 0 && len(matches) > limit {
+		matches = matches[:limit]
+	}
+	return matches
+}
+
+// Best : the best match of the query when its confidence is at least EntityMinConfidence and a second entity
+// is not as close to the query (e.g. "Sydney Olympc" for "Sydney Olympic" and "Sydney Olympians"). A name shared
+// by several entities has no best match.
+func (objResolver *EntityResolver) Best(query string) (EntityMatch, bool) {
+	if len(objResolver.exact[NormaliseEntityName(query)]) > 1 {
+		return EntityMatch{}, false
+	}
+	matches := objResolver.Resolve(query, 2)
+	if len(matches) == 0 || matches[0].Confidence < EntityMinConfidence {
+		return EntityMatch{}, false
+	}
+	if len(matches) > 1 && matches[0].Confidence < 1 && matches[0].Confidence-matches[1].Confidence < 0.05 {
+		return EntityMatch{}, false
+	}
+	return matches[0], true
+}
+
+// binding :
+func (objResolver *EntityResolver) binding(objName entityName, confidence float64) EntityMatch {
+	var objMatch EntityMatch
+	objMatch.ID = objName.ID
+	objMatch.Name = objResolver.display[objName.ID]
+	objMatch.Matched = objName.Name
+	objMatch.Method = objName.Method
+	objMatch.Confidence = confidence
+	return objMatch
+}
+
+// NormaliseEntityName : lower case letters and digits with single spaces, "&" is read as "and" and stop words are dropped
+func NormaliseEntityName(name string) string {
+
+	name = strings.ToLower(strings.Replace(name, "&", " and ", -1))
+	name = strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return r
+		}
+		return ' '
+	}, name)
+
+	var words []string
+	for _, word := range strings.Fields(name) {
+		if !entityStopWords[word] {
+			words = append(words, word)
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// entityTrigrams : trigrams of the name padded with spaces so short names still have some
+func entityTrigrams(norm string) map[string]bool {
+	trigrams := map[string]bool{}
+	runes := []rune("  " + norm + " ")
+	for i := 0; i+3 <= len(runes); i++ {
+		trigrams[string(runes[i:i+3])] = true
+	}
+	return trigrams
+}
+
+// trigramSimilarity : dice coefficient of the trigrams
+func trigramSimilarity(a, b map[string]bool) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	var shared int
+	for trigram := range a {
+		if b[trigram] {
+			shared++
+		}
+	}
+	return 2 * float64(shared) / float64(len(a)+len(b))
+}
+
+// wordSimilarity : 0.7 - 1 when every word of the query is a word of the name, 0 otherwise
+func wordSimilarity(query, name string) float64 {
+
+	nameWords := map[string]bool{}
+	for _, word := range strings.Fields(name) {
+		nameWords[word] = true
+	}
+
+	queryWords := strings.Fields(query)
+	for _, word := range queryWords {
+		if !nameWords[word] {
+			return 0
+		}
+	}
+	return 0.7 + 0.3*float64(len(queryWords))/float64(len(nameWords))
+}
+
+// levenshteinSimilarity : 1 - edit distance / length of the longer name
+func levenshteinSimilarity(a, b string) float64 {
+
+	ra, rb := []rune(a), []rune(b)
+	if len(ra) == 0 && len(rb) == 0 {
+		return 1
+	}
+
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = minInt(minInt(prev[j]+1, cur[j-1]+1), prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+
+	longest := len(ra)
+	if len(rb) > longest {
+		longest = len(rb)
+	}
+	return 1 - float64(prev[len(rb)])/float64(longest)
+}
+
+// minInt :
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
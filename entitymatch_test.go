@@ -0,0 +1,89 @@
+package isg
+
+import "testing"
+
+func TestNormaliseEntityName(t *testing.T) {
+
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"The Sydney FC", "sydney"},
+		{"Brighton & Hove Albion", "brighton and hove albion"},
+		{"  St.  Kilda ", "st kilda"},
+		{"north-melbourne", "north melbourne"},
+	}
+
+	for _, tt := range tests {
+		if got := NormaliseEntityName(tt.name); got != tt.want {
+			t.Errorf("NormaliseEntityName(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestEntityResolverBest(t *testing.T) {
+
+	objResolver := entityTestResolver()
+	tests := []struct {
+		query  string
+		id     int
+		method string
+	}{
+		{"Greater Western Sydney Giants", 1, EntityMatchExact},
+		{"GWS Giants", 1, EntityMatchInitials},
+		{"Kangaroos", 2, EntityMatchAlias},
+		{"Geelong Catz", 4, EntityMatchFuzzy},
+		{"Swans Sydney", 3, EntityMatchFuzzy},
+	}
+
+	for _, tt := range tests {
+		objMatch, ok := objResolver.Best(tt.query)
+		if !ok || objMatch.ID != tt.id || objMatch.Method != tt.method {
+			t.Errorf("Best(%q) = %+v, %v, want id %d by %s", tt.query, objMatch, ok, tt.id, tt.method)
+		}
+	}
+
+	if objMatch, ok := objResolver.Best("Collingwood"); ok {
+		t.Errorf("Best(Collingwood) = %+v, want no match", objMatch)
+	}
+}
+
+func TestEntityResolverAmbiguous(t *testing.T) {
+
+	objResolver := NewEntityResolver()
+	objResolver.Add(1, "Sydney FC")
+	objResolver.Add(2, "Sydney Olympic")
+	objResolver.Add(3, "The Sydney Club")
+
+	// "Sydney FC" and "The Sydney Club" both normalise to "sydney"
+	if objMatch, ok := objResolver.Best("Sydney"); ok {
+		t.Errorf("Best(Sydney) = %+v, want no match", objMatch)
+	}
+	candidates := map[int]float64{}
+	for _, objMatch := range objResolver.Resolve("Sydney FC", 0) {
+		candidates[objMatch.ID] = objMatch.Confidence
+	}
+	if candidates[1] != EntityAmbiguousConfidence || candidates[3] != EntityAmbiguousConfidence {
+		t.Errorf("Resolve(Sydney FC) = %v, want clubs 1 and 3 at %v", candidates, EntityAmbiguousConfidence)
+	}
+
+	if objMatch, ok := objResolver.Best("Sydney Olympc"); !ok || objMatch.ID != 2 {
+		t.Errorf("Best(Sydney Olympc) = %+v, %v, want Sydney Olympic", objMatch, ok)
+	}
+}
+
+func TestEntityResolverResolve(t *testing.T) {
+
+	matches := entityTestResolver().Resolve("Sydney", 3)
+	if len(matches) != 3 {
+		t.Fatalf("got %d matches, want 3", len(matches))
+	}
+	if matches[0].ID != 3 || matches[0].Confidence != 1 {
+		t.Errorf("first match %+v, want Sydney Swans exact", matches[0])
+	}
+	for i := 1; i < len(matches); i++ {
+		if matches[i].Confidence > matches[i-1].Confidence || matches[i].ID == matches[0].ID {
+			t.Errorf("match %d = %+v out of order or repeated", i, matches[i])
+		}
+	}
+}
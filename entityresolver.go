@@ -0,0 +1,117 @@
+/*
+Package data - Handles functions related to data source access e.g. cache, databases
+*/
+package data
+
+import (
+	"sync"
+
+	"github.com/thegeniusgroup/isgdatalib"
+)
+
+// entityTypeTeam : entity type of the team names in the alias table
+const entityTypeTeam = "team"
+
+// teamResolvers : team name resolvers of each sport id, teamResolversChecksum is the checksum of the team and alias rows
+// they were built from
+var teamResolvers = map[int]*isg.EntityResolver{}
+var teamResolversChecksum string
+var teamResolversLock sync.RWMutex
+
+// teamResolversChecksumSQL : changes whenever a team or team alias is added, removed or renamed
+const teamResolversChecksumSQL = "SELECT CONCAT(" +
+	" (SELECT CONCAT(COUNT(*), '-', IFNULL(SUM(CRC32(CONCAT_WS('|', sport_id, team_id, team_name, isg_api_name, isg_api_regionname, filtername, url))),0)) FROM isg_team), '/', " +
+	" (SELECT CONCAT(COUNT(*), '-', IFNULL(SUM(CRC32(CONCAT_WS('|', sport_id, entity_id, alias, status))),0)) FROM isg_entity_alias WHERE entity_type = ?))"
+
+// LoadEntityResolvers : builds the team name resolvers of the /resolve/team route from the name columns and the alias table
+func LoadEntityResolvers() error {
+
+	var checksum string
+	err := SportsDb.QueryRow(teamResolversChecksumSQL, entityTypeTeam).Scan(&checksum)
+	if err != nil {
+		return err
+	}
+
+	teams := map[int]*isg.EntityResolver{}
+
+	rows, err := SportsDb.Query("SELECT sport_id, team_id, IFNULL(team_name,''), IFNULL(isg_api_name,''), IFNULL(isg_api_regionname,''), " +
+		" IFNULL(filtername,''), IFNULL(url,'') FROM isg_team ORDER BY sport_id, team_id")
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var sportID, teamID int
+		var teamName, apiName, regionName, filterName, url string
+		err = rows.Scan(&sportID, &teamID, &teamName, &apiName, &regionName, &filterName, &url)
+		if err != nil {
+			return err
+		}
+		if teams[sportID] == nil {
+			teams[sportID] = isg.NewEntityResolver()
+		}
+		teams[sportID].Add(teamID, teamName, apiName, regionName, filterName, url)
+	}
+
+	// feed spellings which are not close to any name column e.g. "Kangaroos" for North Melbourne
+	rows2, err := SportsDb.Query("SELECT sport_id, entity_id, alias FROM isg_entity_alias WHERE entity_type = ? AND status = ?", entityTypeTeam, 1)
+	if err != nil {
+		return err
+	}
+	defer rows2.Close()
+
+	for rows2.Next() {
+		var alias string
+		var sportID, entityID int
+		err = rows2.Scan(&sportID, &entityID, &alias)
+		if err != nil {
+			return err
+		}
+		if teams[sportID] != nil {
+			teams[sportID].AddAlias(entityID, alias)
+		}
+	}
+
+	teamResolversLock.Lock()
+	teamResolvers = teams
+	teamResolversChecksum = checksum
+	teamResolversLock.Unlock()
+
+	return nil
+}
+
+// RefreshEntityResolvers : rebuilds the team name resolvers when the teams or their aliases changed since the last load,
+// true when they were rebuilt
+func RefreshEntityResolvers() (bool, error) {
+
+	var checksum string
+	err := SportsDb.QueryRow(teamResolversChecksumSQL, entityTypeTeam).Scan(&checksum)
+	if err != nil {
+		return false, err
+	}
+
+	teamResolversLock.RLock()
+	current := teamResolversChecksum
+	teamResolversLock.RUnlock()
+
+	if checksum == current {
+		return false, nil
+	}
+
+	return true, LoadEntityResolvers()
+}
+
+// ResolveTeamCandidates : best team matches of the name in the sport, an ambiguous name returns every team it names
+// with a low confidence
+func ResolveTeamCandidates(sportID int, name string, limit int) []isg.EntityMatch {
+
+	teamResolversLock.RLock()
+	objResolver := teamResolvers[sportID]
+	teamResolversLock.RUnlock()
+
+	if objResolver == nil {
+		return nil
+	}
+	return objResolver.Resolve(name, limit)
+}
@@ -0,0 +1,112 @@
+package isg
+
+import (
+	"database/sql"
+	"strconv"
+)
+
+// Shared fixtures of the isg tests, every test builds its records from these
+
+// testFloat : a price, line or total value
+func testFloat(val float64) *float64 {
+	return &val
+}
+
+// testNullString : a string column, NULL when empty
+func testNullString(val string) sql.NullString {
+	return sql.NullString{String: val, Valid: val != ""}
+}
+
+// testNullInt : a non NULL id column
+func testNullInt(val int64) sql.NullInt64 {
+	return sql.NullInt64{Int64: val, Valid: true}
+}
+
+// testResult : a played match of season 1
+func testResult(matchID, homeTeamID, awayTeamID int64, homeScore, awayScore int) GeniusOddsModelResult {
+	return GeniusOddsModelResult{MatchID: matchID, SeasonID: 1, HomeTeamID: homeTeamID, AwayTeamID: awayTeamID, HomeScore: homeScore, AwayScore: awayScore}
+}
+
+// testMarket : a live price of match 1, home team 10 v away team 20, last updated at 2024-05-01 12:00:00 counter time
+func testMarket(providerID string, marketID, teamID int64, category string, price float64, val *float64) GeniusOddsMarket {
+	return GeniusOddsMarket{MatchID: 1, HomeTeamID: 10, AwayTeamID: 20, ProviderInfo: Provider{ProviderId: providerID, Name: "P" + providerID},
+		MarketID: marketID, CategoryName: category, MarketTeamID: teamID, MarketPrice: &price, MarketVal: val, LastUpdate: "2024-05-01 12:00:00"}
+}
+
+// testSettlement : a provider price of played match 1, home team 10 v away team 20 with the final score
+func testSettlement(providerID string, isgAPIID string, teamID int64, price float64, val *float64, homeScore, awayScore int) GeniusOddsSettlement {
+	return GeniusOddsSettlement{MatchID: 1, MatchStatus: "N", ProviderID: providerID, MarketTeamID: teamID, HomeTeamID: 10, AwayTeamID: 20,
+		ISGapiID: isgAPIID, MarketPrice: &price, MarketVal: val, HomeScore: homeScore, AwayScore: awayScore}
+}
+
+// testMatch : a listed match of the sport in league 1
+func testMatch(matchID int64, sportID int) GeniusSportsMatch {
+	objmatch := GeniusSportsMatch{MatchID: testNullInt(matchID)}
+	objmatch.SportInfo.SportInternalID = sportID
+	objmatch.LeagueInfo.LeagueInternalID = 1
+	return objmatch
+}
+
+// testKickOffMatch : a listed match of the sport kicking off at the counter date and time
+func testKickOffMatch(matchID int64, sportID int, counterDate, counterTime string) GeniusSportsMatch {
+	objmatch := testMatch(matchID, sportID)
+	objmatch.CounterDate = testNullString(counterDate)
+	objmatch.CounterTime = testNullString(counterTime)
+	return objmatch
+}
+
+// testBestMatch : a listed match of the sport with its home and away H2H prices
+func testBestMatch(matchID int64, sportID int, home, away *float64) GeniusSportsMatch {
+	objmatch := testMatch(matchID, sportID)
+	objmatch.IntMatchOdds = []IntMarketInfo{{MatchID: matchID, HomeMarketOdds: []OddsInfo{{NewOdds: home}}, AwayMarketOdds: []OddsInfo{{NewOdds: away}}}}
+	return objmatch
+}
+
+// testPlayerMarket : a live player market price of match 1 in the Player group
+func testPlayerMarket(providerID string, category string, playerID int64, playerName string, price float64) GeniusOddsMarket {
+	objMarket := testMarket(providerID, 100, 0, category, price, nil)
+	objMarket.GroupName = "Player"
+	objMarket.MarketName = category
+	objMarket.DisplayName = playerName
+	objMarket.PlayerInfo = &GeniusOddsPlayer{PlayerID: strconv.FormatInt(playerID, 10), PlayerInternalID: playerID, Name: playerName}
+	return objMarket
+}
+
+// testTeams : teams with internal ids 1, 2, ... in the order of the names
+func testTeams(names ...string) []Team {
+	var teams []Team
+	for i, name := range names {
+		teams = append(teams, Team{TeamInternalID: strconv.Itoa(i + 1), TeamName: name})
+	}
+	return teams
+}
+
+// testModel : a model of teams 1 and 2 with the same scoring form, rated elo1 and elo2
+func testModel(sportID int, elo1, elo2, score float64) *GeniusOddsModel {
+
+	params := GeniusOddsModelSports[sportID]
+	return &GeniusOddsModel{
+		SportID:      sportID,
+		Params:       params,
+		AvgHomeScore: score,
+		AvgAwayScore: score,
+		MarginSD:     params.MarginSD,
+		TotalSD:      params.TotalSD,
+		Matches:      100,
+		Ratings: map[int64]*GeniusOddsTeamRating{
+			1: {Elo: elo1, Scored: score, Conceded: score},
+			2: {Elo: elo2, Scored: score, Conceded: score},
+		},
+	}
+}
+
+// entityTestResolver : AFL teams with the name columns of isg_team and an alias
+func entityTestResolver() *EntityResolver {
+	objResolver := NewEntityResolver()
+	objResolver.Add(1, "Greater Western Sydney Giants", "GWS", "greater-western-sydney")
+	objResolver.Add(2, "North Melbourne", "North Melbourne Kangaroos", "north-melbourne")
+	objResolver.Add(3, "Sydney Swans", "Sydney", "sydney-swans")
+	objResolver.Add(4, "Geelong Cats", "Geelong", "geelong-cats")
+	objResolver.AddAlias(2, "Kangaroos")
+	return objResolver
+}
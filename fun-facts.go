@@ -0,0 +1,111 @@
+package sports
+
+import (
+	"data"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"util"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/thegeniusgroup/isgdatalib"
+)
+
+// OnThisDay : historical facts of a calendar day grouped by year. :month is 1-12 or the month name, ?sport= limits the
+// facts to one sport.
+// GET  /onthisday/{:month}/{:day}?sport=
+func OnThisDay(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+
+	month, day, err := isg.ParseOnThisDayDate(util.CleanText(p.ByName("month"), true, true), util.CleanText(p.ByName("day"), true, true))
+	if err != nil {
+		util.WebResponse(w, r, http.StatusBadRequest, strings.TrimPrefix(err.Error(), isg.ISGErrBadInputPrefix))
+		return
+	}
+
+	var sport string
+	if sportname := util.CleanText(r.URL.Query().Get("sport"), true, true); sportname != "" {
+		objsport, err := data.GetSport(sportname)
+		if err != nil {
+			util.WebResponse(w, r, http.StatusNotFound, "sport not found")
+			return
+		}
+		sport = objsport.SportID
+	}
+
+	records, err := data.GetOnThisDayFacts(month, day, sport)
+	if err != nil {
+		fmt.Println(err.Error())
+		util.WebResponse(w, r, http.StatusNotFound, "unable to get the facts")
+		return
+	}
+
+	t := isg.BindingOnThisDay(records, month, day, data.SportObjects)
+	if len(t.Years) == 0 {
+		util.WebResponse(w, r, http.StatusNotFound, "record not found")
+		return
+	}
+
+	final := util.JSONMessageWrappedObj(http.StatusOK, t)
+	util.WebResponseJSONObjectNoCache(w, r, http.StatusOK, final)
+	return
+}
+
+// MatchFunFacts : fun facts of a match from the head to head history of its teams
+// GET  /funfacts/{:sport}/{:league}/{:matchid}
+func MatchFunFacts(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+
+	sportname := util.CleanText(p.ByName("sport"), true, true)
+	leaguename := util.CleanText(p.ByName("league"), true, true)
+	matchid := util.CleanText(p.ByName("matchid"), true, true)
+
+	objsport, err := data.GetSport(sportname)
+	if err != nil {
+		util.WebResponse(w, r, http.StatusNotFound, "sport not found")
+		return
+	}
+
+	if _, ok := isg.GeniusOddsModelSports[objsport.SportInternalID]; !ok {
+		util.WebResponse(w, r, http.StatusNotFound, "sport not supported")
+		return
+	}
+
+	objleague, err := data.GetLeagueID(objsport, leaguename)
+	if err != nil {
+		util.WebResponse(w, r, http.StatusNotFound, "league not found")
+		return
+	}
+
+	matchID, err := strconv.Atoi(matchid)
+	if err != nil {
+		util.WebResponse(w, r, http.StatusNotFound, "invalid id")
+		return
+	}
+
+	matchinfo, err := data.GetEventTeamID(objsport, objleague.LeagueInternalID, matchID)
+	if err != nil {
+		util.WebResponse(w, r, http.StatusNotFound, "match not found")
+		return
+	}
+
+	homeTeamID, awayTeamID := int64(matchinfo.HomeTeamInternalID), int64(matchinfo.AwayTeamInternalID)
+	results, err := data.GetHeadToHeadResults(objsport, objleague.LeagueInternalID, homeTeamID, awayTeamID)
+	if err != nil {
+		fmt.Println(err.Error())
+		util.WebResponse(w, r, http.StatusNotFound, "unable to get the head to head results")
+		return
+	}
+
+	var t isg.MatchFunFacts
+	t.MatchID = matchID
+	t.HomeTeam = isg.Team{TeamID: matchinfo.HomeTeamID, TeamName: matchinfo.HomeTeamName, Abbreviation: matchinfo.HomeTeamAbbr,
+		TeamURL: matchinfo.HomeTeamURL, TeamFlag: matchinfo.HomeTeamIcon}
+	t.AwayTeam = isg.Team{TeamID: matchinfo.AwayTeamID, TeamName: matchinfo.AwayTeamName, Abbreviation: matchinfo.AwayTeamAbbr,
+		TeamURL: matchinfo.AwayTeamURL, TeamFlag: matchinfo.AwayTeamIcon}
+	t.Meetings = len(results)
+	t.FunfactList = isg.BuildMatchFunFacts(results, homeTeamID, awayTeamID, matchinfo.HomeTeamName, matchinfo.AwayTeamName, objsport.SportInternalID)
+
+	final := util.JSONMessageWrappedObj(http.StatusOK, t)
+	util.WebResponseJSONObjectNoCache(w, r, http.StatusOK, final)
+	return
+}
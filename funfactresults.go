@@ -0,0 +1,84 @@
+/*
+Package data - Handles functions related to data source access e.g. cache, databases
+*/
+package data
+
+import (
+	"time"
+
+	"github.com/thegeniusgroup/isgdatalib"
+)
+
+// GetOnThisDayFacts : historical facts of a calendar day latest year first, sport is the sport code and optional.
+// isg_onthisday (schema.sql) is written by the content editors, the api only reads it.
+func GetOnThisDayFacts(month time.Month, day int, sport string) ([]isg.OnThisDayData, error) {
+
+	var records []isg.OnThisDayData
+
+	sqlstr := "SELECT `day`, `month`, `year`, `date`, sport, onthisday FROM isg_onthisday WHERE `month` = ? AND `day` = ? "
+	args := []interface{}{int(month), day}
+	if sport != "" {
+		sqlstr += " AND sport = ? "
+		args = append(args, sport)
+	}
+	sqlstr += " ORDER BY `year` DESC, `date` DESC "
+
+	rows, err := SportsDb.Query(sqlstr, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var record isg.OnThisDayData
+		err = rows.Scan(&record.Day, &record.Month, &record.Year, &record.Date, &record.Sport, &record.OnThisDay)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+// GetHeadToHeadResults : final scores of every played meeting of the two teams in a league in kick off order
+func GetHeadToHeadResults(objSport isg.Sport, leagueID int, team1ID, team2ID int64) ([]isg.GeniusOddsModelResult, error) {
+
+	var results []isg.GeniusOddsModelResult
+
+	if _, ok := isg.GeniusOddsModelSports[objSport.SportInternalID]; !ok {
+		return results, nil
+	}
+
+	sqlstr := "SELECT matches.match_id, matches.season_id, matches.home_team_id, matches.away_team_id, scores.home_score, scores.away_score, matches.match_date " +
+		" FROM " + objSport.TableNameMatches + " AS matches " +
+		" INNER JOIN " + objSport.TableNameMatches + "_scores AS scores ON scores.match_id = matches.match_id " +
+		" WHERE matches.league_id = ? AND matches.status = ? " +
+		" AND ((matches.home_team_id = ? AND matches.away_team_id = ?) OR (matches.home_team_id = ? AND matches.away_team_id = ?)) " +
+		" ORDER BY matches.counter_date, matches.counter_time, matches.match_id "
+
+	rows, err := SportsDb.Query(sqlstr, leagueID, "N", team1ID, team2ID, team2ID, team1ID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var result isg.GeniusOddsModelResult
+		err = rows.Scan(
+			&result.MatchID,
+			&result.SeasonID,
+			&result.HomeTeamID,
+			&result.AwayTeamID,
+			&result.HomeScore,
+			&result.AwayScore,
+			&result.MatchDate,
+		)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, result)
+	}
+
+	return results, nil
+}
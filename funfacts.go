@@ -0,0 +1,257 @@
+package isg
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Match fun fact types
+const (
+	FunFactMeetings    = "meetings"
+	FunFactStreak      = "streak"
+	FunFactBiggestWin  = "biggest_win"
+	FunFactHighScore   = "highest_score"
+	FunFactHomeRecord  = "home_record"
+	FunFactLastMeeting = "last_meeting"
+)
+
+// FunFact : a fact of the head to head history listed by LinkResourceFunfacts, Value is the number the fact is about
+type FunFact struct {
+	Type  string `json:"type"`
+	Fact  string `json:"fact"`
+	Value int    `json:"value,omitempty"`
+}
+
+// MatchFunFacts : fun facts of a match from the head to head results of its teams
+type MatchFunFacts struct {
+	MatchID  int  `json:"match_id"`
+	HomeTeam Team `json:"home"`
+	AwayTeam Team `json:"away"`
+	Meetings int  `json:"meetings"`
+	LinkResourceFunfacts
+}
+
+// funFactUnit : what a score is counted in
+func funFactUnit(sportID, num int) string {
+	unit := "point"
+	if sportID == 4 || sportID == 8 {
+		unit = "goal"
+	}
+	if num != 1 {
+		unit += "s"
+	}
+	return unit
+}
+
+// funFactTimes : once, twice, three times ...
+func funFactTimes(num int) string {
+	switch num {
+	case 1:
+		return "once"
+	case 2:
+		return "twice"
+	}
+	return ConvertNum2Words(num) + " times"
+}
+
+// funFactYear : year of a match date, the date itself when it can't be read
+func funFactYear(matchDate string) string {
+	if len(matchDate) >= 4 {
+		return matchDate[:4]
+	}
+	return matchDate
+}
+
+// BuildMatchFunFacts : facts from the head to head results of the two teams in kick off order, counts are written in words
+func BuildMatchFunFacts(results []GeniusOddsModelResult, homeTeamID, awayTeamID int64, homeName, awayName string, sportID int) []FunFact {
+
+	facts := []FunFact{}
+	if len(results) == 0 {
+		return facts
+	}
+
+	names := map[int64]string{homeTeamID: homeName, awayTeamID: awayName}
+
+	var homeWins, awayWins, draws, homeGames, homeGameWins int
+	var biggest, highest *GeniusOddsModelResult
+	for i, result := range results {
+
+		winner := funFactWinner(result)
+		switch winner {
+		case homeTeamID:
+			homeWins++
+		case awayTeamID:
+			awayWins++
+		default:
+			draws++
+		}
+
+		// the home team at its own home ground
+		if result.HomeTeamID == homeTeamID {
+			homeGames++
+			if winner == homeTeamID {
+				homeGameWins++
+			}
+		}
+
+		if biggest == nil || funFactMargin(result) > funFactMargin(*biggest) {
+			biggest = &results[i]
+		}
+		if highest == nil || result.HomeScore+result.AwayScore > highest.HomeScore+highest.AwayScore {
+			highest = &results[i]
+		}
+	}
+
+	meetings := FunFact{Type: FunFactMeetings, Value: len(results)}
+	meetings.Fact = homeName + " and " + awayName + " have met " + funFactTimes(len(results)) + ", " + homeName + " winning " +
+		ConvertNum2Words(homeWins) + " and " + awayName + " " + ConvertNum2Words(awayWins)
+	if draws > 0 {
+		meetings.Fact += " with " + ConvertNum2Words(draws) + " drawn"
+	}
+	meetings.Fact += "."
+	facts = append(facts, meetings)
+
+	// streak of the last winner
+	last := funFactWinner(results[len(results)-1])
+	streak := 0
+	for i := len(results) - 1; i >= 0 && last != 0 && funFactWinner(results[i]) == last; i-- {
+		streak++
+	}
+	if streak > 1 {
+		facts = append(facts, FunFact{Type: FunFactStreak, Value: streak,
+			Fact: names[last] + " have won the last " + ConvertNum2Words(streak) + " meetings."})
+	}
+
+	if margin := funFactMargin(*biggest); margin > 0 {
+		facts = append(facts, FunFact{Type: FunFactBiggestWin, Value: margin,
+			Fact: "The biggest winning margin between the sides is " + ConvertNum2Words(margin) + " " + funFactUnit(sportID, margin) + ", " +
+				names[funFactWinner(*biggest)] + " winning " + funFactScore(*biggest) + " in " + funFactYear(biggest.MatchDate) + "."})
+	}
+
+	total := highest.HomeScore + highest.AwayScore
+	facts = append(facts, FunFact{Type: FunFactHighScore, Value: total,
+		Fact: "The highest scoring meeting produced " + ConvertNum2Words(total) + " " + funFactUnit(sportID, total) + ", " +
+			funFactScore(*highest) + " in " + funFactYear(highest.MatchDate) + "."})
+
+	if homeGames > 0 {
+		facts = append(facts, FunFact{Type: FunFactHomeRecord, Value: homeGameWins,
+			Fact: homeName + " have won " + ConvertNum2Words(homeGameWins) + " of their " + ConvertNum2Words(homeGames) + " home games against " + awayName + "."})
+	}
+
+	lastResult := results[len(results)-1]
+	lastMeeting := FunFact{Type: FunFactLastMeeting}
+	if last == 0 {
+		lastMeeting.Fact = "Their last meeting on " + lastResult.MatchDate + " was drawn " + funFactScore(lastResult) + "."
+	} else {
+		lastMeeting.Fact = "When they last met on " + lastResult.MatchDate + " " + names[last] + " won " + funFactScore(lastResult) + "."
+	}
+	facts = append(facts, lastMeeting)
+
+	return facts
+}
+
+// funFactWinner : team id of the winner, 0 for a draw
+func funFactWinner(result GeniusOddsModelResult) int64 {
+	if result.HomeScore > result.AwayScore {
+		return result.HomeTeamID
+	} else if result.AwayScore > result.HomeScore {
+		return result.AwayTeamID
+	}
+	return 0
+}
+
+// funFactMargin :
+func funFactMargin(result GeniusOddsModelResult) int {
+	if result.HomeScore > result.AwayScore {
+		return result.HomeScore - result.AwayScore
+	}
+	return result.AwayScore - result.HomeScore
+}
+
+// funFactScore : winner's score first
+func funFactScore(result GeniusOddsModelResult) string {
+	if result.AwayScore > result.HomeScore {
+		return strconv.Itoa(result.AwayScore) + "-" + strconv.Itoa(result.HomeScore)
+	}
+	return strconv.Itoa(result.HomeScore) + "-" + strconv.Itoa(result.AwayScore)
+}
+
+// OnThisDayYear : facts of one year on the calendar day
+type OnThisDayYear struct {
+	Year  int         `json:"year"`
+	Facts []OnThisDay `json:"facts"`
+}
+
+// OnThisDayFacts : facts of a calendar day grouped by year, latest year first
+type OnThisDayFacts struct {
+	Month string          `json:"month"`
+	Day   int             `json:"day"`
+	Years []OnThisDayYear `json:"years"`
+}
+
+// ParseOnThisDayDate : month is 1-12 or its name e.g. jan, january. Feb 29 is a valid day.
+func ParseOnThisDayDate(month, day string) (time.Month, int, error) {
+
+	var objMonth time.Month
+
+	if num, err := strconv.Atoi(month); err == nil {
+		if num < 1 || num > 12 {
+			return 0, 0, errors.New(ISGErrBadInputPrefix + "invalid month " + month)
+		}
+		objMonth = time.Month(num)
+	} else {
+		for m := time.January; m <= time.December; m++ {
+			name := strings.ToLower(m.String())
+			if strings.ToLower(month) == name || strings.ToLower(month) == name[:3] {
+				objMonth = m
+			}
+		}
+		if objMonth == 0 {
+			return 0, 0, errors.New(ISGErrBadInputPrefix + "invalid month " + month)
+		}
+	}
+
+	objDay, err := strconv.Atoi(day)
+	// days of the month in a leap year
+	if err != nil || objDay < 1 || objDay > time.Date(2000, objMonth+1, 0, 0, 0, 0, 0, time.UTC).Day() {
+		return 0, 0, errors.New(ISGErrBadInputPrefix + "invalid day " + day)
+	}
+
+	return objMonth, objDay, nil
+}
+
+// BindingOnThisDay : records come latest year first, objSports resolves the sport code of a fact to its name and logo
+func BindingOnThisDay(records []OnThisDayData, month time.Month, day int, objSports map[string]Sport) OnThisDayFacts {
+
+	var objFacts OnThisDayFacts
+	objFacts.Month = month.String()
+	objFacts.Day = day
+	objFacts.Years = []OnThisDayYear{}
+
+	for _, record := range records {
+
+		if !record.OnThisDay.Valid || record.OnThisDay.String == "" {
+			continue
+		}
+
+		var objFact OnThisDay
+		objFact.Year = int(record.Year.Int64)
+		objFact.SportName = record.Sport.String
+		objFact.OnThisDay = record.OnThisDay.String
+		if objSport, ok := objSports[strings.ToLower(record.Sport.String)]; ok {
+			objFact.SportName = objSport.SportName
+			objFact.Icon = objSport.SportLogo
+		}
+
+		last := len(objFacts.Years) - 1
+		if last < 0 || objFacts.Years[last].Year != objFact.Year {
+			objFacts.Years = append(objFacts.Years, OnThisDayYear{Year: objFact.Year})
+			last++
+		}
+		objFacts.Years[last].Facts = append(objFacts.Years[last].Facts, objFact)
+	}
+
+	return objFacts
+}
@@ -0,0 +1,80 @@
+package isg
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBuildMatchFunFacts(t *testing.T) {
+
+	// Cats (1) and Swans (2), the Swans won the last two
+	results := []GeniusOddsModelResult{testResult(1, 1, 2, 100, 40), testResult(2, 2, 1, 70, 70), testResult(3, 1, 2, 80, 90), testResult(4, 2, 1, 95, 60)}
+	for i, date := range []string{"2021-04-10", "2022-05-14", "2023-06-03", "2024-07-20"} {
+		results[i].MatchDate = date
+	}
+
+	facts := BuildMatchFunFacts(results, 1, 2, "Cats", "Swans", 1)
+
+	byType := map[string]FunFact{}
+	for _, objFact := range facts {
+		byType[objFact.Type] = objFact
+	}
+
+	want := map[string]string{
+		FunFactMeetings:    "Cats and Swans have met four times, Cats winning one and Swans two with one drawn.",
+		FunFactStreak:      "Swans have won the last two meetings.",
+		FunFactBiggestWin:  "The biggest winning margin between the sides is sixty points, Cats winning 100-40 in 2021.",
+		FunFactHighScore:   "The highest scoring meeting produced one hundred seventy points, 90-80 in 2023.",
+		FunFactHomeRecord:  "Cats have won one of their two home games against Swans.",
+		FunFactLastMeeting: "When they last met on 2024-07-20 Swans won 95-60.",
+	}
+	for factType, fact := range want {
+		if byType[factType].Fact != fact {
+			t.Errorf("%s fact = %q, want %q", factType, byType[factType].Fact, fact)
+		}
+	}
+
+	if len(BuildMatchFunFacts(nil, 1, 2, "Cats", "Swans", 1)) != 0 {
+		t.Error("facts built without results")
+	}
+}
+
+func TestParseOnThisDayDate(t *testing.T) {
+
+	tests := []struct {
+		month, day string
+		want       time.Month
+		ok         bool
+	}{
+		{"2", "29", time.February, true},
+		{"feb", "30", 0, false},
+		{"September", "30", time.September, true},
+		{"13", "1", 0, false},
+		{"jun", "0", 0, false},
+	}
+
+	for _, tt := range tests {
+		month, _, err := ParseOnThisDayDate(tt.month, tt.day)
+		if (err == nil) != tt.ok || month != tt.want {
+			t.Errorf("ParseOnThisDayDate(%s, %s) = %v, %v", tt.month, tt.day, month, err)
+		}
+	}
+}
+
+func TestBindingOnThisDay(t *testing.T) {
+
+	records := []OnThisDayData{
+		{Year: testNullInt(2010), Sport: testNullString("AR"), OnThisDay: testNullString("First")},
+		{Year: testNullInt(2010), Sport: testNullString("rl"), OnThisDay: testNullString("Second")},
+		{Year: testNullInt(2001), OnThisDay: testNullString("")},
+		{Year: testNullInt(1999), OnThisDay: testNullString("Third")},
+	}
+
+	objFacts := BindingOnThisDay(records, time.March, 4, map[string]Sport{"ar": {SportName: "AFL", SportLogo: "afl.png"}})
+	if len(objFacts.Years) != 2 || objFacts.Years[0].Year != 2010 || len(objFacts.Years[0].Facts) != 2 || objFacts.Years[1].Year != 1999 {
+		t.Fatalf("years = %+v, want 2010 with two facts and 1999", objFacts.Years)
+	}
+	if objFacts.Years[0].Facts[0].SportName != "AFL" || objFacts.Years[0].Facts[0].Icon != "afl.png" || objFacts.Years[0].Facts[1].SportName != "rl" {
+		t.Errorf("2010 facts = %+v", objFacts.Years[0].Facts)
+	}
+}
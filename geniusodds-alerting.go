@@ -0,0 +1,429 @@
+package sports
+
+import (
+	"bytes"
+	"context"
+	"data"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+	"util"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/thegeniusgroup/isgdatalib"
+)
+
+// geniusOddsAlertLogTable : log db table of the alerts sent through the log notifier
+const geniusOddsAlertLogTable = "isg_geniusodds_alert_log"
+
+// geniusOddsAlertRetry : how long a fluc whose alert failed to send holds back the alert cursor
+const geniusOddsAlertRetry = time.Hour
+
+// Failed alerts are retried after a backoff doubling from geniusOddsAlertBackoff, the alert is dead lettered
+// after geniusOddsAlertMaxAttempts failures or once its fluc is older than geniusOddsAlertRetry
+const (
+	geniusOddsAlertBackoff     = time.Minute
+	geniusOddsAlertMaxAttempts = 5
+)
+
+// GeniusOddsWebhookHosts : hosts (and their subdomains) the alert webhooks may post to, set at start up
+var GeniusOddsWebhookHosts []string
+
+// GeniusOddsEmailAllowlist : addresses and domains (with their subdomains) the alert emails may go to, set at start up
+var GeniusOddsEmailAllowlist []string
+
+// geniusOddsAlertAttempt : failed sends of the alert of a rule for one fluc
+type geniusOddsAlertAttempt struct {
+	FlucTime    time.Time
+	Attempts    int
+	NextAttempt time.Time
+	Dead        bool
+}
+
+// failed : counts a failed send and sets the next attempt, true when the alert has to be dead lettered
+func (attempt *geniusOddsAlertAttempt) failed(now time.Time) bool {
+
+	attempt.Attempts++
+	if attempt.Attempts >= geniusOddsAlertMaxAttempts || now.Sub(attempt.FlucTime) >= geniusOddsAlertRetry {
+		attempt.Dead = true
+		return true
+	}
+	attempt.NextAttempt = now.Add(geniusOddsAlertBackoff << uint(attempt.Attempts-1))
+	return false
+}
+
+// GeniusOddsNotifier : sends a fired alert to the target of the rule
+type GeniusOddsNotifier interface {
+	Notify(rule isg.GeniusOddsAlertRule, objAlert isg.GeniusOddsAlert) error
+}
+
+// WebhookNotifier : posts the alert as json to the rule's target url, the url is checked against the allowed hosts again
+// as the rule may predate them
+type WebhookNotifier struct {
+	Client *http.Client
+	Hosts  []string
+}
+
+// NewWebhookNotifier : the client only connects to public addresses, checked on the resolved address of every dial, and doesn't follow redirects
+func NewWebhookNotifier(hosts []string) WebhookNotifier {
+
+	dialer := &net.Dialer{
+		Timeout: 5 * time.Second,
+		Control: func(network, address string, c syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if !isg.GeniusOddsPublicIP(net.ParseIP(host)) {
+				return errors.New("webhook address " + host + " is not public")
+			}
+			return nil
+		},
+	}
+
+	transport := &http.Transport{
+		Proxy: nil,
+		DialContext: func(ctx context.Context, network, address string) (net.Conn, error) {
+			return dialer.DialContext(ctx, network, address)
+		},
+		TLSHandshakeTimeout: 5 * time.Second,
+	}
+
+	client := &http.Client{
+		Timeout:   10 * time.Second,
+		Transport: transport,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	return WebhookNotifier{Client: client, Hosts: hosts}
+}
+
+// Notify :
+func (n WebhookNotifier) Notify(rule isg.GeniusOddsAlertRule, objAlert isg.GeniusOddsAlert) error {
+
+	webhook, err := isg.ValidateGeniusOddsWebhook(rule.Target, n.Hosts)
+	if err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(objAlert)
+	if err != nil {
+		return err
+	}
+
+	resp, err := n.Client.Post(webhook.String(), "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return errors.New("webhook " + rule.Target + " returned " + resp.Status)
+	}
+	return nil
+}
+
+// EmailNotifier : mails the alert to the rule's target address through the SMTP server at Addr, a local stand-in outside production.
+// The target is checked against the allowed addresses again as the rule may predate them.
+type EmailNotifier struct {
+	Addr      string
+	From      string
+	Allowlist []string
+}
+
+// Notify : a header line must not carry a line break, ValidateGeniusOddsEmail refuses them
+func (n EmailNotifier) Notify(rule isg.GeniusOddsAlertRule, objAlert isg.GeniusOddsAlert) error {
+
+	_, err := isg.ValidateGeniusOddsEmail(rule.Target, n.Allowlist)
+	if err != nil {
+		return errors.New(err.Error() + " (rule " + strconv.FormatInt(rule.RuleID, 10) + ")")
+	}
+
+	msg := "From: " + n.From + "\r\n" +
+		"To: " + rule.Target + "\r\n" +
+		"Subject: Genius Odds alert - " + objAlert.RuleType + "\r\n" +
+		"\r\n" +
+		objAlert.Message + "\r\n" +
+		"Match: " + strconv.FormatInt(objAlert.MatchID, 10) + " Market: " + objAlert.Market + "\r\n" +
+		"Updated: " + objAlert.LastUpdate + "\r\n"
+
+	return smtp.SendMail(n.Addr, nil, n.From, []string{rule.Target}, []byte(msg))
+}
+
+// LogNotifier : writes the alert to the log db with the match details, like the data error alerts
+type LogNotifier struct {
+	TableName string
+}
+
+// Notify :
+func (n LogNotifier) Notify(rule isg.GeniusOddsAlertRule, objAlert isg.GeniusOddsAlert) error {
+
+	var objerror isg.ErrorLogRecord
+	for _, objsport := range data.SportObjects {
+		if objsport.SportInternalID != objAlert.SportID {
+			continue
+		}
+		var err error
+		objerror, err = data.GetMatchInfo(objsport, objAlert.LeagueID, int(objAlert.MatchID))
+		if err != nil {
+			return err
+		}
+		break
+	}
+	objerror.ProviderName = objAlert.Provider
+
+	data.InsertErrorLog(objerror, objAlert.SportID, int(rule.RuleID), n.TableName, objAlert.Message)
+	return nil
+}
+
+// NewGeniusOddsNotifiers : notifiers of the alert rules, smtpAddr is the SMTP server of the email notifier
+func NewGeniusOddsNotifiers(smtpAddr, smtpFrom string, webhookHosts, emailAllowlist []string) map[string]GeniusOddsNotifier {
+	return map[string]GeniusOddsNotifier{
+		isg.AlertNotifierWebhook: NewWebhookNotifier(webhookHosts),
+		isg.AlertNotifierEmail:   EmailNotifier{Addr: smtpAddr, From: smtpFrom, Allowlist: emailAllowlist},
+		isg.AlertNotifierLog:     LogNotifier{TableName: geniusOddsAlertLogTable},
+	}
+}
+
+// StartGeniusOddsAlerts : checks the alert rules against the new fluc rows and repeats after every interval
+func StartGeniusOddsAlerts(interval time.Duration, notifiers map[string]GeniusOddsNotifier) {
+
+	from := time.Now().Add(-interval)
+
+	// cooldowns survive a restart
+	sent, err := data.GetGeniusOddsAlertsSent(from.Add(-24 * time.Hour))
+	if err != nil {
+		fmt.Println(err.Error())
+		sent = map[string]time.Time{}
+	}
+
+	attempts := map[string]*geniusOddsAlertAttempt{}
+	for {
+		from = evaluateGeniusOddsAlerts(from, sent, attempts, notifiers)
+		time.Sleep(interval)
+	}
+}
+
+// evaluateGeniusOddsAlerts : fires the rules matched by the flucs after from, returns where the next run starts.
+// The cursor only moves past a fluc once its alerts are sent or dead lettered, a retried fluc skips the alerts already
+// sent for it and the alerts still waiting for their next attempt.
+func evaluateGeniusOddsAlerts(from time.Time, sent map[string]time.Time, attempts map[string]*geniusOddsAlertAttempt,
+	notifiers map[string]GeniusOddsNotifier) time.Time {
+
+	now := time.Now()
+
+	rules, err := data.GetGeniusOddsAlertRules()
+	if err != nil {
+		fmt.Println(err.Error())
+		return from
+	} else if len(rules) == 0 {
+		return now
+	}
+
+	flucs, err := data.GetGeniusOddsFlucEvents(from, now)
+	if err != nil {
+		fmt.Println(err.Error())
+		return from
+	}
+
+	var count int
+	var failedAt *time.Time
+	for _, fluc := range flucs {
+
+		flucTime, err := time.ParseInLocation("2006-01-02 15:04:05", fluc.LastUpdate, data.AEST)
+		if err != nil {
+			flucTime = from
+		}
+
+		for _, rule := range rules {
+
+			fired, message := isg.EvaluateGeniusOddsAlertRule(rule, fluc)
+			if !fired {
+				continue
+			}
+
+			key := isg.GeniusOddsAlertDedupKey(rule, fluc)
+			if isg.GeniusOddsAlertCooling(rule, sent[key], now) || !sent[key].Before(flucTime) {
+				continue
+			}
+
+			notifier, ok := notifiers[rule.Notifier]
+			if !ok {
+				continue
+			}
+
+			// the fluc is read again until the alert is sent or dead lettered
+			attemptKey := key + "-" + fluc.LastUpdate
+			attempt := attempts[attemptKey]
+			if attempt != nil && attempt.Dead {
+				continue
+			}
+			if attempt != nil && now.Before(attempt.NextAttempt) {
+				failedAt = holdGeniusOddsAlerts(failedAt, flucTime)
+				continue
+			}
+
+			objAlert := isg.BindingGeniusOddsAlert(rule, fluc, message)
+			err = notifier.Notify(rule, objAlert)
+			if err != nil {
+				fmt.Println(err.Error())
+				if attempt == nil {
+					attempt = &geniusOddsAlertAttempt{FlucTime: flucTime}
+					attempts[attemptKey] = attempt
+				}
+				if attempt.failed(now) {
+					err = data.InsertGeniusOddsAlertDeadLetter(objAlert, rule, key, attempt.Attempts, err.Error(), now)
+					if err != nil {
+						fmt.Println(err.Error())
+					}
+					continue
+				}
+				failedAt = holdGeniusOddsAlerts(failedAt, flucTime)
+				continue
+			}
+
+			delete(attempts, attemptKey)
+			sent[key] = now
+			err = data.InsertGeniusOddsAlertSent(objAlert, key, now)
+			if err != nil {
+				fmt.Println(err.Error())
+			}
+			count++
+		}
+	}
+
+	// drop the cooldowns which can't apply anymore and the attempts of flucs past their retries
+	for key, sentAt := range sent {
+		if now.Sub(sentAt) > 7*24*time.Hour {
+			delete(sent, key)
+		}
+	}
+	for key, attempt := range attempts {
+		if now.Sub(attempt.FlucTime) > 2*geniusOddsAlertRetry {
+			delete(attempts, key)
+		}
+	}
+
+	if count > 0 {
+		fmt.Println("Genius odds alerts sent:", count)
+	}
+	if failedAt != nil && failedAt.After(from) {
+		return *failedAt
+	} else if failedAt != nil {
+		return from
+	}
+	return now
+}
+
+// holdGeniusOddsAlerts : the alert cursor stays before the earliest fluc with an alert still to retry
+func holdGeniusOddsAlerts(failedAt *time.Time, flucTime time.Time) *time.Time {
+	retryFrom := flucTime.Add(-time.Second)
+	if failedAt == nil || retryFrom.Before(*failedAt) {
+		return &retryFrom
+	}
+	return failedAt
+}
+
+// GeniusOddsAlertRuleList : active alert rules of the signed in user
+// GET  /geniusodds/alerts
+func GeniusOddsAlertRuleList(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+
+	userID, ok := geniusOddsAuthUser(w, r)
+	if !ok {
+		return
+	}
+
+	rules, err := data.GetGeniusOddsAlertRulesByUser(userID)
+	if err != nil {
+		fmt.Println(err.Error())
+		util.WebResponse(w, r, http.StatusNotFound, "record not found")
+		return
+	} else if len(rules) == 0 {
+		util.WebResponse(w, r, http.StatusNotFound, "record not found")
+		return
+	}
+
+	final := util.JSONMessageWrappedObj(http.StatusOK, rules)
+	util.WebResponseJSONObjectNoCache(w, r, http.StatusOK, final)
+	return
+}
+
+// GeniusOddsAlertRuleCreate : adds an alert rule of the signed in user
+// POST /geniusodds/alerts
+// {"rule_type": "price_cross|plunge|line_move|new_provider", "match_id": 0, "market": "win", "threshold": 2.5, "notifier": "webhook|email|log", "target": ""}
+func GeniusOddsAlertRuleCreate(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+
+	userID, ok := geniusOddsAuthUser(w, r)
+	if !ok {
+		return
+	}
+
+	var rule isg.GeniusOddsAlertRule
+	err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1<<16)).Decode(&rule)
+	if err != nil {
+		util.WebResponse(w, r, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	rule.UserID = userID
+	rule.RuleType = strings.ToLower(util.CleanText(rule.RuleType, true, true))
+	rule.Market = strings.ToLower(util.CleanText(rule.Market, true, true))
+	rule.ProviderID = util.CleanText(rule.ProviderID, true, true)
+	rule.Direction = strings.ToLower(util.CleanText(rule.Direction, true, true))
+	rule.Notifier = strings.ToLower(util.CleanText(rule.Notifier, true, true))
+	rule.Target = strings.TrimSpace(rule.Target)
+
+	err = isg.ValidateGeniusOddsAlertRule(&rule, GeniusOddsWebhookHosts, GeniusOddsEmailAllowlist)
+	if err != nil {
+		util.WebResponse(w, r, http.StatusBadRequest, strings.TrimPrefix(err.Error(), isg.ISGErrBadInputPrefix))
+		return
+	}
+
+	rule.RuleID, err = data.InsertGeniusOddsAlertRule(rule)
+	if err != nil {
+		fmt.Println(err.Error())
+		util.WebResponse(w, r, http.StatusInternalServerError, "rule not saved")
+		return
+	}
+
+	final := util.JSONMessageWrappedObj(http.StatusOK, rule)
+	util.WebResponseJSONObjectNoCache(w, r, http.StatusOK, final)
+	return
+}
+
+// GeniusOddsAlertRuleDelete : switches off an alert rule of the signed in user
+// DELETE /geniusodds/alerts/{:ruleid}
+func GeniusOddsAlertRuleDelete(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+
+	userID, ok := geniusOddsAuthUser(w, r)
+	if !ok {
+		return
+	}
+
+	ruleID, err := strconv.ParseInt(util.CleanText(p.ByName("ruleid"), true, true), 10, 64)
+	if err != nil {
+		util.WebResponse(w, r, http.StatusNotFound, "invalid id")
+		return
+	}
+
+	deleted, err := data.DeleteGeniusOddsAlertRule(userID, ruleID)
+	if err != nil {
+		fmt.Println(err.Error())
+		util.WebResponse(w, r, http.StatusInternalServerError, "rule not deleted")
+		return
+	} else if !deleted {
+		util.WebResponse(w, r, http.StatusNotFound, "record not found")
+		return
+	}
+
+	util.WebResponse(w, r, http.StatusOK, "rule deleted")
+	return
+}
@@ -0,0 +1,42 @@
+package sports
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGeniusOddsAlertAttemptBackoff(t *testing.T) {
+
+	flucTime := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	attempt := &geniusOddsAlertAttempt{FlucTime: flucTime}
+
+	// one, two, four and eight minutes between the attempts, then dead lettered
+	now := flucTime
+	for _, wait := range []time.Duration{time.Minute, 2 * time.Minute, 4 * time.Minute, 8 * time.Minute} {
+		if attempt.failed(now) || attempt.NextAttempt != now.Add(wait) {
+			t.Fatalf("attempt %d next = %v, want %v", attempt.Attempts, attempt.NextAttempt, now.Add(wait))
+		}
+		now = attempt.NextAttempt
+	}
+	if !attempt.failed(now) || !attempt.Dead || attempt.Attempts != geniusOddsAlertMaxAttempts {
+		t.Errorf("last attempt = %+v", attempt)
+	}
+
+	// a fluc past the retry window is dead lettered on its first failure
+	attempt = &geniusOddsAlertAttempt{FlucTime: flucTime}
+	if !attempt.failed(flucTime.Add(geniusOddsAlertRetry)) || attempt.Attempts != 1 {
+		t.Errorf("old fluc = %+v", attempt)
+	}
+}
+
+func TestHoldGeniusOddsAlerts(t *testing.T) {
+
+	flucTime := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	failedAt := holdGeniusOddsAlerts(nil, flucTime.Add(time.Minute))
+	failedAt = holdGeniusOddsAlerts(failedAt, flucTime)
+	failedAt = holdGeniusOddsAlerts(failedAt, flucTime.Add(2*time.Minute))
+	if !failedAt.Equal(flucTime.Add(-time.Second)) {
+		t.Errorf("cursor = %v", failedAt)
+	}
+}
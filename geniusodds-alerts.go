@@ -0,0 +1,298 @@
+package isg
+
+import (
+	"errors"
+	"math"
+	"net"
+	"net/mail"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Genius odds alert rule types
+const (
+	AlertRulePriceCross  = "price_cross"
+	AlertRulePlunge      = "plunge"
+	AlertRuleLineMove    = "line_move"
+	AlertRuleNewProvider = "new_provider"
+)
+
+// Genius odds alert notifiers
+const (
+	AlertNotifierWebhook = "webhook"
+	AlertNotifierEmail   = "email"
+	AlertNotifierLog     = "log"
+)
+
+// GeniusOddsAlertDefaultCooldown : minutes before the same rule fires again for the same selection
+const GeniusOddsAlertDefaultCooldown = 30
+
+// GeniusOddsAlertRule : a user's alert rule. Zero / empty filters match everything, Threshold is the price for price_cross,
+// the percentage for plunge and the points for line_move. Direction (above / below) limits price_cross, empty is both ways.
+type GeniusOddsAlertRule struct {
+	RuleID     int64   `json:"rule_id"`
+	UserID     string  `json:"user_id"`
+	RuleType   string  `json:"rule_type"`
+	SportID    int     `json:"sport_id,omitempty"`
+	LeagueID   int     `json:"league_id,omitempty"`
+	MatchID    int64   `json:"match_id,omitempty"`
+	Market     string  `json:"market,omitempty"`
+	TeamID     int64   `json:"team_id,omitempty"`
+	ProviderID string  `json:"provider_id,omitempty"`
+	Threshold  float64 `json:"threshold,omitempty"`
+	Direction  string  `json:"direction,omitempty"`
+	Notifier   string  `json:"notifier"`
+	Target     string  `json:"target,omitempty"`
+	Cooldown   int     `json:"cooldown_mins,omitempty"`
+	Status     int     `json:"status"`
+}
+
+// GeniusOddsFlucEvent : a new fluc row with the previous and the opening price of the same provider selection,
+// PrevPrice is nil for the first price of a provider
+type GeniusOddsFlucEvent struct {
+	MatchID      int64
+	SportID      int
+	LeagueID     int
+	MarketID     int64
+	ISGapiID     string
+	CategoryName string
+	TeamID       int64
+	ProviderID   string
+	ProviderName string
+	Price        *float64
+	Val          *float64
+	PrevPrice    *float64
+	PrevVal      *float64
+	OpenPrice    *float64
+	OpenVal      *float64
+	LastUpdate   string
+}
+
+// GeniusOddsAlert : a fired alert as sent by the notifiers
+type GeniusOddsAlert struct {
+	RuleID     int64    `json:"rule_id"`
+	UserID     string   `json:"user_id"`
+	RuleType   string   `json:"rule_type"`
+	SportID    int      `json:"sport_id"`
+	LeagueID   int      `json:"league_id"`
+	MatchID    int64    `json:"match_id"`
+	Market     string   `json:"market"`
+	TeamID     int64    `json:"team_id,omitempty"`
+	Provider   string   `json:"provider"`
+	Price      *float64 `json:"price,omitempty"`
+	PrevPrice  *float64 `json:"prev_price,omitempty"`
+	OpenPrice  *float64 `json:"open_price,omitempty"`
+	Line       *float64 `json:"line,omitempty"`
+	OpenLine   *float64 `json:"open_line,omitempty"`
+	Message    string   `json:"message"`
+	LastUpdate string   `json:"last_update"`
+}
+
+// ValidateGeniusOddsAlertRule : checks a new rule and fills the defaults. Webhooks must be https urls of the allowed hosts,
+// email targets a single plain address of the allowed addresses and domains.
+func ValidateGeniusOddsAlertRule(rule *GeniusOddsAlertRule, webhookHosts, emailAllowlist []string) error {
+
+	if rule.UserID == "" {
+		return errors.New(ISGErrBadInputPrefix + "user_id not found")
+	}
+
+	switch rule.RuleType {
+	case AlertRulePriceCross:
+		if rule.Threshold <= 1 {
+			return errors.New(ISGErrBadInputPrefix + "threshold must be a price above 1")
+		}
+		if rule.Direction != "" && rule.Direction != "above" && rule.Direction != "below" {
+			return errors.New(ISGErrBadInputPrefix + "invalid direction " + rule.Direction)
+		}
+	case AlertRulePlunge, AlertRuleLineMove:
+		if rule.Threshold <= 0 {
+			return errors.New(ISGErrBadInputPrefix + "threshold must be above 0")
+		}
+	case AlertRuleNewProvider:
+	default:
+		return errors.New(ISGErrBadInputPrefix + "invalid rule_type " + rule.RuleType)
+	}
+
+	switch rule.Notifier {
+	case AlertNotifierWebhook:
+		if rule.Target == "" {
+			return errors.New(ISGErrBadInputPrefix + "target not found for " + rule.Notifier)
+		}
+		if _, err := ValidateGeniusOddsWebhook(rule.Target, webhookHosts); err != nil {
+			return errors.New(ISGErrBadInputPrefix + err.Error())
+		}
+	case AlertNotifierEmail:
+		if rule.Target == "" {
+			return errors.New(ISGErrBadInputPrefix + "target not found for " + rule.Notifier)
+		}
+		address, err := ValidateGeniusOddsEmail(rule.Target, emailAllowlist)
+		if err != nil {
+			return errors.New(ISGErrBadInputPrefix + err.Error())
+		}
+		rule.Target = address
+	case AlertNotifierLog:
+	default:
+		return errors.New(ISGErrBadInputPrefix + "invalid notifier " + rule.Notifier)
+	}
+
+	if rule.Cooldown <= 0 {
+		rule.Cooldown = GeniusOddsAlertDefaultCooldown
+	}
+	rule.Status = 1
+	return nil
+}
+
+// ValidateGeniusOddsWebhook : parses a webhook target, an https url without credentials on one of the allowed hosts or their subdomains
+func ValidateGeniusOddsWebhook(target string, hosts []string) (*url.URL, error) {
+
+	webhook, err := url.Parse(target)
+	if err != nil || webhook.Scheme != "https" || webhook.User != nil || webhook.Hostname() == "" {
+		return nil, errors.New("webhook target must be an https url")
+	}
+
+	hostname := strings.ToLower(strings.TrimSuffix(webhook.Hostname(), "."))
+	for _, host := range hosts {
+		host = strings.ToLower(strings.TrimSpace(host))
+		if host != "" && (hostname == host || strings.HasSuffix(hostname, "."+host)) {
+			return webhook, nil
+		}
+	}
+	return nil, errors.New("webhook host " + hostname + " is not allowed")
+}
+
+// ValidateGeniusOddsEmail : parses an email target, a single plain address which is one of the allowed addresses or on
+// one of the allowed domains or their subdomains
+func ValidateGeniusOddsEmail(target string, allowlist []string) (string, error) {
+
+	address, err := mail.ParseAddress(target)
+	if err != nil || strings.ContainsAny(address.Address, "\r\n") {
+		return "", errors.New("invalid email target")
+	}
+
+	email := strings.ToLower(address.Address)
+	domain := email[strings.LastIndex(email, "@")+1:]
+	for _, allowed := range allowlist {
+		allowed = strings.ToLower(strings.TrimSpace(allowed))
+		if allowed == "" {
+			continue
+		}
+		if strings.Contains(allowed, "@") && email == allowed {
+			return address.Address, nil
+		}
+		if !strings.Contains(allowed, "@") && (domain == allowed || strings.HasSuffix(domain, "."+allowed)) {
+			return address.Address, nil
+		}
+	}
+	return "", errors.New("email target " + address.Address + " is not allowed")
+}
+
+// GeniusOddsPublicIP : whether a webhook may connect to the address, private, loopback, link local and multicast addresses are refused
+func GeniusOddsPublicIP(ip net.IP) bool {
+	return ip != nil && !ip.IsPrivate() && !ip.IsLoopback() && !ip.IsLinkLocalUnicast() && !ip.IsLinkLocalMulticast() &&
+		!ip.IsInterfaceLocalMulticast() && !ip.IsMulticast() && !ip.IsUnspecified()
+}
+
+// EvaluateGeniusOddsAlertRule : whether the fluc fires the rule, with the alert message.
+// Rules fire when the fluc crosses the threshold, so a price sitting past it does not fire on every fluc.
+func EvaluateGeniusOddsAlertRule(rule GeniusOddsAlertRule, fluc GeniusOddsFlucEvent) (bool, string) {
+
+	if (rule.SportID != 0 && rule.SportID != fluc.SportID) || (rule.LeagueID != 0 && rule.LeagueID != fluc.LeagueID) ||
+		(rule.MatchID != 0 && rule.MatchID != fluc.MatchID) || (rule.Market != "" && rule.Market != fluc.ISGapiID) ||
+		(rule.TeamID != 0 && rule.TeamID != fluc.TeamID) || (rule.ProviderID != "" && rule.ProviderID != fluc.ProviderID) {
+		return false, ""
+	}
+
+	switch rule.RuleType {
+	case AlertRulePriceCross:
+		if fluc.Price == nil || fluc.PrevPrice == nil {
+			return false, ""
+		}
+		threshold := strconv.FormatFloat(rule.Threshold, 'f', 2, 64)
+		if rule.Direction != "below" && *fluc.PrevPrice < rule.Threshold && *fluc.Price >= rule.Threshold {
+			return true, fluc.ProviderName + " price moved above " + threshold + " to " + formatAlertFloat(fluc.Price)
+		}
+		if rule.Direction != "above" && *fluc.PrevPrice > rule.Threshold && *fluc.Price <= rule.Threshold {
+			return true, fluc.ProviderName + " price moved below " + threshold + " to " + formatAlertFloat(fluc.Price)
+		}
+	case AlertRulePlunge:
+		if fluc.Price == nil || fluc.PrevPrice == nil || fluc.OpenPrice == nil || *fluc.OpenPrice <= 0 {
+			return false, ""
+		}
+		change := (*fluc.OpenPrice - *fluc.Price) / *fluc.OpenPrice * 100
+		prevChange := (*fluc.OpenPrice - *fluc.PrevPrice) / *fluc.OpenPrice * 100
+		if change >= rule.Threshold && prevChange < rule.Threshold {
+			return true, fluc.ProviderName + " price plunged " + strconv.FormatFloat(Round(change, .5, 2), 'f', 2, 64) + "% from " +
+				formatAlertFloat(fluc.OpenPrice) + " to " + formatAlertFloat(fluc.Price)
+		}
+	case AlertRuleLineMove:
+		if fluc.Val == nil || fluc.PrevVal == nil || fluc.OpenVal == nil {
+			return false, ""
+		}
+		move := math.Abs(*fluc.Val - *fluc.OpenVal)
+		prevMove := math.Abs(*fluc.PrevVal - *fluc.OpenVal)
+		if move >= rule.Threshold && prevMove < rule.Threshold {
+			return true, fluc.ProviderName + " line moved " + strconv.FormatFloat(move, 'f', 1, 64) + " points from " +
+				formatAlertFloat(fluc.OpenVal) + " to " + formatAlertFloat(fluc.Val)
+		}
+	case AlertRuleNewProvider:
+		if fluc.PrevPrice == nil && fluc.Price != nil {
+			return true, fluc.ProviderName + " opened the market at " + formatAlertFloat(fluc.Price)
+		}
+	}
+
+	return false, ""
+}
+
+// GeniusOddsAlertDedupKey : an alert is sent once per rule and selection within the cooldown, new_provider once per match and provider
+// as a provider opens every selection of a match together
+func GeniusOddsAlertDedupKey(rule GeniusOddsAlertRule, fluc GeniusOddsFlucEvent) string {
+
+	key := strconv.FormatInt(rule.RuleID, 10) + "-" + strconv.FormatInt(fluc.MatchID, 10)
+	if rule.RuleType == AlertRuleNewProvider {
+		return key + "-" + fluc.ProviderID
+	}
+
+	key += "-" + strconv.FormatInt(fluc.MarketID, 10) + "-" + strconv.FormatInt(fluc.TeamID, 10)
+	if rule.ProviderID != "" {
+		key += "-" + fluc.ProviderID
+	}
+	return key
+}
+
+// GeniusOddsAlertCooling : whether the last alert of the key is still within the rule's cooldown
+func GeniusOddsAlertCooling(rule GeniusOddsAlertRule, lastSent, now time.Time) bool {
+	return !lastSent.IsZero() && now.Sub(lastSent) < time.Duration(rule.Cooldown)*time.Minute
+}
+
+// BindingGeniusOddsAlert :
+func BindingGeniusOddsAlert(rule GeniusOddsAlertRule, fluc GeniusOddsFlucEvent, message string) GeniusOddsAlert {
+
+	var objAlert GeniusOddsAlert
+	objAlert.RuleID = rule.RuleID
+	objAlert.UserID = rule.UserID
+	objAlert.RuleType = rule.RuleType
+	objAlert.SportID = fluc.SportID
+	objAlert.LeagueID = fluc.LeagueID
+	objAlert.MatchID = fluc.MatchID
+	objAlert.Market = fluc.ISGapiID
+	objAlert.TeamID = fluc.TeamID
+	objAlert.Provider = fluc.ProviderName
+	objAlert.Price = fluc.Price
+	objAlert.PrevPrice = fluc.PrevPrice
+	objAlert.OpenPrice = fluc.OpenPrice
+	objAlert.Line = fluc.Val
+	objAlert.OpenLine = fluc.OpenVal
+	objAlert.Message = message
+	objAlert.LastUpdate = fluc.LastUpdate
+	return objAlert
+}
+
+// formatAlertFloat :
+func formatAlertFloat(val *float64) string {
+	if val == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*val, 'f', -1, 64)
+}
@@ -0,0 +1,57 @@
+package isg
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SignGeniusOddsUser : session token of a user valid until expires, the user id and expiry with their HMAC-SHA256 under the shared secret.
+// The portal signs the token at login and the genius odds user routes read the user from it.
+func SignGeniusOddsUser(secret, userID string, expires time.Time) string {
+	payload := userID + "." + strconv.FormatInt(expires.Unix(), 10)
+	return payload + "." + geniusOddsUserSignature(secret, payload)
+}
+
+// VerifyGeniusOddsUser : user id of a session token made by SignGeniusOddsUser, an error when the signature is wrong or the token expired
+func VerifyGeniusOddsUser(secret, token string, now time.Time) (string, error) {
+
+	invalid := errors.New("invalid session token")
+	if secret == "" {
+		return "", invalid
+	}
+
+	sigAt := strings.LastIndex(token, ".")
+	if sigAt < 0 {
+		return "", invalid
+	}
+	payload, signature := token[:sigAt], token[sigAt+1:]
+	if !hmac.Equal([]byte(signature), []byte(geniusOddsUserSignature(secret, payload))) {
+		return "", invalid
+	}
+
+	expiresAt := strings.LastIndex(payload, ".")
+	if expiresAt <= 0 {
+		return "", invalid
+	}
+	expires, err := strconv.ParseInt(payload[expiresAt+1:], 10, 64)
+	if err != nil {
+		return "", invalid
+	}
+	if now.Unix() >= expires {
+		return "", errors.New("session token expired")
+	}
+
+	return payload[:expiresAt], nil
+}
+
+// geniusOddsUserSignature :
+func geniusOddsUserSignature(secret, payload string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(payload))
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,55 @@
+package sports
+
+import (
+	"net/http"
+	"strings"
+	"time"
+	"util"
+
+	"github.com/thegeniusgroup/isgdatalib"
+)
+
+// genius odds session secret and admin users, set once at start up by InitGeniusOddsAuth
+var (
+	geniusOddsAuthSecret string
+	geniusOddsAdminUsers []string
+)
+
+// InitGeniusOddsAuth : secret of the user session tokens and the users allowed on the admin routes.
+// Without a secret every signed in route is refused.
+func InitGeniusOddsAuth(secret string, adminUsers []string) {
+
+	geniusOddsAuthSecret = secret
+	geniusOddsAdminUsers = nil
+	for _, userID := range adminUsers {
+		if userID = strings.TrimSpace(userID); userID != "" {
+			geniusOddsAdminUsers = append(geniusOddsAdminUsers, userID)
+		}
+	}
+}
+
+// geniusOddsAuthUser : signed in user of the request from its bearer session token, writes the 401 and returns false without one
+func geniusOddsAuthUser(w http.ResponseWriter, r *http.Request) (string, bool) {
+
+	token := strings.TrimSpace(strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer "))
+	userID, err := isg.VerifyGeniusOddsUser(geniusOddsAuthSecret, token, time.Now())
+	if err != nil {
+		util.WebResponse(w, r, http.StatusUnauthorized, err.Error())
+		return "", false
+	}
+	return userID, true
+}
+
+// geniusOddsAuthAdmin : whether the signed in user of the request is an admin, writes the 401 / 403 otherwise
+func geniusOddsAuthAdmin(w http.ResponseWriter, r *http.Request) bool {
+
+	userID, ok := geniusOddsAuthUser(w, r)
+	if !ok {
+		return false
+	}
+	if !isg.CheckValueInArray(geniusOddsAdminUsers, userID) {
+		util.WebResponse(w, r, http.StatusForbidden, "admin only")
+		return false
+	}
+	return true
+}
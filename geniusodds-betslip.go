@@ -0,0 +1,105 @@
+package sports
+
+import (
+	"data"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"util"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/thegeniusgroup/isgdatalib"
+)
+
+// GeniusOddsMulti : prices a multi from the live genius odds with the best provider per leg, or one provider for the whole multi.
+// POST /geniusodds/multi
+// {"provider": "", "legs": [{"sport": "afl", "match_id": 1, "market": "win", "selection": "home", "provider": "", "line": null}]}
+func GeniusOddsMulti(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+
+	objOptions, err := getGeniusOddsOptions(r)
+	if err != nil {
+		util.WebResponse(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var objRequest isg.GeniusOddsMultiRequest
+	err = json.NewDecoder(http.MaxBytesReader(w, r.Body, 1<<16)).Decode(&objRequest)
+	if err != nil {
+		util.WebResponse(w, r, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if len(objRequest.Legs) == 0 {
+		util.WebResponse(w, r, http.StatusBadRequest, "legs not found")
+		return
+	} else if len(objRequest.Legs) > isg.GeniusOddsMultiMaxLegs {
+		util.WebResponse(w, r, http.StatusBadRequest, "too many legs, max "+strconv.Itoa(isg.GeniusOddsMultiMaxLegs))
+		return
+	}
+
+	objRequest.Provider = util.CleanText(objRequest.Provider, true, true)
+	if objRequest.Provider != "" {
+		if _, ok := data.Providers[objRequest.Provider]; !ok {
+			util.WebResponse(w, r, http.StatusBadRequest, "invalid provider value :"+objRequest.Provider)
+			return
+		}
+	}
+
+	// match ids of each sport
+	objSports := map[int]isg.Sport{}
+	matchIDs := map[int][]int64{}
+	for i, leg := range objRequest.Legs {
+
+		objsport, err := data.GetSport(util.CleanText(leg.Sport, true, true))
+		if err != nil {
+			util.WebResponse(w, r, http.StatusNotFound, "sport not found "+leg.Sport)
+			return
+		}
+		if !data.IsGeniusOddsSport(objsport.SportInternalID) {
+			util.WebResponse(w, r, http.StatusNotFound, "sport not supported "+leg.Sport)
+			return
+		}
+		if leg.MatchID <= 0 || leg.Market == "" {
+			util.WebResponse(w, r, http.StatusBadRequest, "invalid leg "+strconv.Itoa(i))
+			return
+		}
+
+		leg.Market = strings.ToLower(util.CleanText(leg.Market, true, true))
+		leg.Selection = strings.ToLower(util.CleanText(leg.Selection, true, true))
+		leg.Provider = util.CleanText(leg.Provider, true, true)
+		if leg.Selection != "" && leg.Selection != "home" && leg.Selection != "away" {
+			util.WebResponse(w, r, http.StatusBadRequest, "invalid selection value :"+leg.Selection)
+			return
+		}
+		if leg.Provider != "" {
+			if _, ok := data.Providers[leg.Provider]; !ok {
+				util.WebResponse(w, r, http.StatusBadRequest, "invalid provider value :"+leg.Provider)
+				return
+			}
+		}
+
+		leg.SportID = objsport.SportInternalID
+		objRequest.Legs[i] = leg
+		objSports[objsport.SportInternalID] = objsport
+		matchIDs[objsport.SportInternalID] = append(matchIDs[objsport.SportInternalID], leg.MatchID)
+	}
+
+	records := map[int][]isg.GeniusOddsMarket{}
+	for sportID, objsport := range objSports {
+		records[sportID], err = data.GetGeniusOddsMultiMarkets(objsport, matchIDs[sportID])
+		if err != nil {
+			fmt.Println(err.Error())
+			util.WebResponse(w, r, http.StatusNotFound, "record not found")
+			return
+		}
+		records[sportID] = excludeGeniusOddsIssues(records[sportID])
+	}
+
+	t := isg.PriceGeniusOddsMulti(objRequest, records, objOptions.OddsFormat)
+
+	final := util.JSONMessageWrappedObj(http.StatusOK, t)
+	util.WebResponseJSONObjectNoCache(w, r, http.StatusOK, final)
+	return
+}
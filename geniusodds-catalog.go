@@ -0,0 +1,123 @@
+package isg
+
+import "strings"
+
+// GeniusOddsCatalog : markets currently priced for a league or a match, grouped the same way as the markets page
+type GeniusOddsCatalog struct {
+	SportID   string                   `json:"sport_id,omitempty"`
+	SportName string                   `json:"sport_name,omitempty"`
+	SportURL  string                   `json:"sport_url,omitempty"`
+	LeagueURL string                   `json:"league_url,omitempty"`
+	MatchID   int64                    `json:"match_id,omitempty"`
+	Groups    []GeniusOddsCatalogGroup `json:"groups,omitempty"`
+}
+
+// GeniusOddsCatalogGroup :
+type GeniusOddsCatalogGroup struct {
+	GroupName  string                      `json:"group_name"`
+	Categories []GeniusOddsCatalogCategory `json:"categories,omitempty"`
+}
+
+// GeniusOddsCatalogCategory :
+type GeniusOddsCatalogCategory struct {
+	CategoryName string                    `json:"category_name"`
+	Markets      []GeniusOddsCatalogMarket `json:"markets,omitempty"`
+}
+
+// GeniusOddsCatalogMarket : a catalog market, the catalog query returns one row per market, display name and provider
+type GeniusOddsCatalogMarket struct {
+	MarketID     int64                        `json:"-"`
+	ISGapiID     string                       `json:"market_id"`
+	MarketName   string                       `json:"market_name"`
+	CategoryName string                       `json:"-"`
+	GroupName    string                       `json:"-"`
+	DisplayName  string                       `json:"-"`
+	HomeTeamName string                       `json:"-"`
+	HomeTeamAbbr string                       `json:"-"`
+	AwayTeamName string                       `json:"-"`
+	AwayTeamAbbr string                       `json:"-"`
+	Matches      int                          `json:"matches"`
+	ProviderInfo GeniusOddsCatalogProvider    `json:"-"`
+	Selections   []GeniusOddsCatalogSelection `json:"selections,omitempty"`
+	Providers    []GeniusOddsCatalogProvider  `json:"providers,omitempty"`
+}
+
+// GeniusOddsCatalogSelection :
+type GeniusOddsCatalogSelection struct {
+	DisplayName string `json:"display_name"`
+	AbbrName    string `json:"abbr_name,omitempty"`
+}
+
+// GeniusOddsCatalogProvider :
+type GeniusOddsCatalogProvider struct {
+	ProviderID string `json:"-"`
+	Name       string `json:"name"`
+	URL        string `json:"url,omitempty"`
+	Icon       string `json:"icon,omitempty"`
+}
+
+// BindingGeniusOddsMarketCatalog : folds the catalog rows into groups, categories and markets with their selections and providers.
+// The rows must be ordered by group, category and market.
+func BindingGeniusOddsMarketCatalog(records []GeniusOddsCatalogMarket) []GeniusOddsCatalogGroup {
+
+	var objGroups []GeniusOddsCatalogGroup
+
+	for _, record := range records {
+
+		if len(objGroups) == 0 || objGroups[len(objGroups)-1].GroupName != record.GroupName {
+			objGroups = append(objGroups, GeniusOddsCatalogGroup{GroupName: record.GroupName})
+		}
+		objGroup := &objGroups[len(objGroups)-1]
+
+		if len(objGroup.Categories) == 0 || objGroup.Categories[len(objGroup.Categories)-1].CategoryName != record.CategoryName {
+			objGroup.Categories = append(objGroup.Categories, GeniusOddsCatalogCategory{CategoryName: record.CategoryName})
+		}
+		objCategory := &objGroup.Categories[len(objGroup.Categories)-1]
+
+		if len(objCategory.Markets) == 0 || objCategory.Markets[len(objCategory.Markets)-1].MarketID != record.MarketID {
+			objMarket := record
+			objMarket.Selections = nil
+			objMarket.Providers = nil
+			objCategory.Markets = append(objCategory.Markets, objMarket)
+		}
+		objMarket := &objCategory.Markets[len(objCategory.Markets)-1]
+
+		if record.Matches > objMarket.Matches {
+			objMarket.Matches = record.Matches
+		}
+
+		if record.DisplayName != "" && !catalogHasSelection(objMarket.Selections, record.DisplayName) {
+			var objSelection GeniusOddsCatalogSelection
+			objSelection.DisplayName = record.DisplayName
+			objSelection.AbbrName = strings.Replace(strings.Replace(record.DisplayName, record.HomeTeamName, record.HomeTeamAbbr, -1),
+				record.AwayTeamName, record.AwayTeamAbbr, -1)
+			objMarket.Selections = append(objMarket.Selections, objSelection)
+		}
+
+		if !catalogHasProvider(objMarket.Providers, record.ProviderInfo.ProviderID) {
+			objMarket.Providers = append(objMarket.Providers, record.ProviderInfo)
+		}
+	}
+
+	return objGroups
+}
+
+// catalogHasSelection :
+func catalogHasSelection(objSelections []GeniusOddsCatalogSelection, displayName string) bool {
+	for _, objSelection := range objSelections {
+		if objSelection.DisplayName == displayName {
+			return true
+		}
+	}
+	return false
+}
+
+// catalogHasProvider :
+func catalogHasProvider(objProviders []GeniusOddsCatalogProvider, providerID string) bool {
+	for _, objProvider := range objProviders {
+		if objProvider.ProviderID == providerID {
+			return true
+		}
+	}
+	return false
+}
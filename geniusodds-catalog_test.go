@@ -0,0 +1,41 @@
+package isg
+
+import "testing"
+
+// testCatalogRow : a catalog row of a match of Home Team (HOM) v Away Team (AWY)
+func testCatalogRow(group, category string, marketID int64, displayName, providerID string, matches int) GeniusOddsCatalogMarket {
+	return GeniusOddsCatalogMarket{MarketID: marketID, GroupName: group, CategoryName: category, DisplayName: displayName,
+		HomeTeamName: "Home Team", HomeTeamAbbr: "HOM", AwayTeamName: "Away Team", AwayTeamAbbr: "AWY", Matches: matches,
+		ProviderInfo: GeniusOddsCatalogProvider{ProviderID: providerID, Name: "P" + providerID}}
+}
+
+func TestBindingGeniusOddsMarketCatalog(t *testing.T) {
+
+	records := []GeniusOddsCatalogMarket{
+		testCatalogRow("Main", "Head to Head", 1, "Home Team", "1", 1),
+		testCatalogRow("Main", "Head to Head", 1, "Home Team", "2", 1),
+		testCatalogRow("Main", "Head to Head", 1, "Away Team", "1", 2),
+		testCatalogRow("Main", "Line", 2, "", "1", 1),
+		testCatalogRow("Scoring", "First Try", 3, "Home Team 1-12", "2", 1),
+	}
+
+	objGroups := BindingGeniusOddsMarketCatalog(records)
+	if len(objGroups) != 2 || len(objGroups[0].Categories) != 2 || len(objGroups[1].Categories) != 1 {
+		t.Fatalf("groups = %+v", objGroups)
+	}
+
+	objMarket := objGroups[0].Categories[0].Markets[0]
+	if len(objGroups[0].Categories[0].Markets) != 1 || objMarket.Matches != 2 || len(objMarket.Providers) != 2 || len(objMarket.Selections) != 2 {
+		t.Errorf("head to head = %+v", objMarket)
+	}
+	if objMarket.Selections[1].DisplayName != "Away Team" || objMarket.Selections[1].AbbrName != "AWY" {
+		t.Errorf("away selection = %+v", objMarket.Selections[1])
+	}
+
+	if objMarket = objGroups[0].Categories[1].Markets[0]; len(objMarket.Selections) != 0 || len(objMarket.Providers) != 1 {
+		t.Errorf("line = %+v", objMarket)
+	}
+	if objMarket = objGroups[1].Categories[0].Markets[0]; objMarket.Selections[0].AbbrName != "HOM 1-12" {
+		t.Errorf("first try = %+v", objMarket)
+	}
+}
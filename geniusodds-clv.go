@@ -0,0 +1,121 @@
+package sports
+
+import (
+	"data"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+	"util"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/thegeniusgroup/isgdatalib"
+)
+
+// StartGeniusOddsClosingLineArchive : archives the closing lines of the started matches and repeats after every interval
+func StartGeniusOddsClosingLineArchive(interval time.Duration) {
+	for {
+		archiveGeniusOddsClosingLines()
+		time.Sleep(interval)
+	}
+}
+
+// archiveGeniusOddsClosingLines : runs the closing line archive for every genius odds sport and league
+func archiveGeniusOddsClosingLines() {
+
+	for _, objsport := range data.GeniusOddsSports() {
+
+		objLeagues := data.SportsLeagues[strconv.Itoa(objsport.SportInternalID)]
+
+		for _, objLeague := range objLeagues {
+			// a day of lookback covers matches that started while the job was down
+			cnt, err := data.ArchiveGeniusOddsClosingLines(objsport, objLeague.LeagueInternalID, 24*time.Hour)
+			if err != nil {
+				fmt.Println(err.Error())
+				continue
+			}
+			if cnt > 0 {
+				fmt.Println("Closing lines archived:", objsport.SportID, objLeague.LeagueEntityKey, cnt)
+			}
+		}
+	}
+}
+
+// GeniusOddsClosingLineValue : open against close price per provider and market, aggregated by league and season.
+// GET  /geniusodds/clv/{:sport}/{:league}/{:season}?provider=
+func GeniusOddsClosingLineValue(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+
+	sportname := util.CleanText(p.ByName("sport"), true, true)
+	leaguename := util.CleanText(p.ByName("league"), true, true)
+	season := util.CleanText(p.ByName("season"), true, true)
+	providername := util.CleanText(r.URL.Query().Get("provider"), true, true)
+
+	objsport, err := data.GetSport(sportname)
+	if err != nil {
+		util.WebResponse(w, r, http.StatusNotFound, "sport not found")
+		return
+	}
+
+	if !data.IsGeniusOddsSport(objsport.SportInternalID) {
+		util.WebResponse(w, r, http.StatusNotFound, "sport not supported")
+		return
+	}
+
+	var leagueID, seasonID int
+	if leaguename != "" {
+		objleague, err := data.GetLeagueID(objsport, leaguename)
+		if err != nil {
+			util.WebResponse(w, r, http.StatusNotFound, "league not found")
+			return
+		}
+		leagueID = objleague.LeagueInternalID
+	}
+
+	if season != "" {
+		seasonID, err = data.GetSeasonID(objsport, season)
+		if err != nil {
+			util.WebResponse(w, r, http.StatusNotFound, "season not found")
+			return
+		}
+	}
+
+	var providerID string
+	if providername != "" {
+		objprovider, ok := data.Providers[providername]
+		if !ok {
+			util.WebResponse(w, r, http.StatusNotFound, "provider not found")
+			return
+		}
+		providerID = objprovider.ProviderId
+	}
+
+	clvList, err := data.GetGeniusOddsClosingLineValue(objsport, leagueID, seasonID, providerID)
+	if err != nil {
+		fmt.Println(err.Error())
+		util.WebResponse(w, r, http.StatusNotFound, "record not found")
+		return
+	} else if len(clvList) == 0 {
+		util.WebResponse(w, r, http.StatusNotFound, "record not found")
+		return
+	}
+
+	objLeagues := data.SportsLeagues[strconv.Itoa(objsport.SportInternalID)]
+	for i := range clvList {
+		for _, objLeague := range objLeagues {
+			if objLeague.LeagueInternalID == clvList[i].LeagueID {
+				clvList[i].LeagueURL = objLeague.LeagueEntityKey
+				break
+			}
+		}
+	}
+
+	var t isg.GeniusOddsClosingLineReport
+	t.SportID = objsport.SportAPICode
+	t.SportName = objsport.SportName
+	t.SportURL = objsport.SportURL
+	t.Providers = clvList
+
+	final := util.JSONMessageWrappedObj(http.StatusOK, t)
+	util.WebResponseJSONObjectNoCache(w, r, http.StatusOK, final)
+	return
+}
@@ -0,0 +1,143 @@
+package isg
+
+import (
+	"html"
+	"regexp"
+	"strings"
+)
+
+// Genius odds include options, ?include= takes a comma separated list
+const (
+	GeniusOddsIncludePreview = "preview"
+	GeniusOddsIncludeTips    = "tips"
+)
+
+// GeniusOddsIncludes :
+var GeniusOddsIncludes = []string{GeniusOddsIncludePreview, GeniusOddsIncludeTips}
+
+// previewAllowedTags : tags kept by the sanitiser, a keeps its http(s) href only
+var previewAllowedTags = map[string]bool{
+	"p": true, "br": true, "b": true, "strong": true, "i": true, "em": true, "u": true,
+	"ul": true, "ol": true, "li": true, "h2": true, "h3": true, "h4": true, "blockquote": true, "a": true,
+}
+
+// previewBlockTags : line breaks of the plain text at the end of a block, paragraphs are followed by a blank line
+var previewBlockTags = map[string]string{
+	"p": "\n\n", "h1": "\n\n", "h2": "\n\n", "h3": "\n\n", "h4": "\n\n", "h5": "\n\n", "h6": "\n\n",
+	"div": "\n\n", "blockquote": "\n\n", "ul": "\n\n", "ol": "\n\n", "li": "\n", "tr": "\n", "br": "\n",
+}
+
+var (
+	previewDropBlocks = regexp.MustCompile(`(?is)<(script|style|iframe|object)\b.*?</(script|style|iframe|object)\s*>|<!--.*?-->`)
+	previewTag        = regexp.MustCompile(`(?s)<(/?)([a-zA-Z][a-zA-Z0-9]*)([^>]*)>`)
+	previewHref       = regexp.MustCompile(`(?i)\bhref\s*=\s*("([^"]*)"|'([^']*)'|([^\s>]+))`)
+	previewSpaces     = regexp.MustCompile(`[ \t\r\f\v]+`)
+	previewBlankLines = regexp.MustCompile(`\n(\s*\n)+`)
+)
+
+// GeniusOddsPreview : written match preview as sanitised HTML and plain text
+type GeniusOddsPreview struct {
+	HTML string `json:"html"`
+	Text string `json:"text"`
+}
+
+// BindingGeniusOddsPreview : nil when the match has no preview
+func BindingGeniusOddsPreview(content string) *GeniusOddsPreview {
+
+	if strings.TrimSpace(content) == "" {
+		return nil
+	}
+
+	var objPreview GeniusOddsPreview
+	objPreview.HTML = SanitisePreviewHTML(content)
+	objPreview.Text = PreviewPlainText(content)
+	return &objPreview
+}
+
+// SanitisePreviewHTML : keeps the formatting tags of the preview without attributes (links keep an http(s) href),
+// scripts, styles, embeds and comments are dropped and the text is re-escaped
+func SanitisePreviewHTML(content string) string {
+
+	content = previewDropBlocks.ReplaceAllString(content, "")
+
+	var b strings.Builder
+	last := 0
+	for _, loc := range previewTag.FindAllStringSubmatchIndex(content, -1) {
+
+		b.WriteString(escapePreviewText(content[last:loc[0]]))
+		last = loc[1]
+
+		closing := content[loc[2]:loc[3]] == "/"
+		tag := strings.ToLower(content[loc[4]:loc[5]])
+		if !previewAllowedTags[tag] {
+			continue
+		}
+
+		switch {
+		case closing:
+			if tag != "br" {
+				b.WriteString("</" + tag + ">")
+			}
+		case tag == "br":
+			b.WriteString("<br>")
+		case tag == "a":
+			href := previewLink(content[loc[6]:loc[7]])
+			if href == "" {
+				b.WriteString("<a>")
+			} else {
+				b.WriteString(`<a href="` + html.EscapeString(href) + `" rel="nofollow noopener" target="_blank">`)
+			}
+		default:
+			b.WriteString("<" + tag + ">")
+		}
+	}
+	b.WriteString(escapePreviewText(content[last:]))
+
+	return strings.TrimSpace(b.String())
+}
+
+// PreviewPlainText : text of the preview with a line per paragraph / list item
+func PreviewPlainText(content string) string {
+
+	content = previewDropBlocks.ReplaceAllString(content, "")
+	content = previewTag.ReplaceAllStringFunc(content, func(tag string) string {
+		match := previewTag.FindStringSubmatch(tag)
+		name := strings.ToLower(match[2])
+		if match[1] == "/" || name == "br" {
+			return previewBlockTags[name]
+		}
+		return ""
+	})
+
+	content = html.UnescapeString(content)
+	content = strings.Replace(content, "\u00a0", " ", -1)
+	content = previewSpaces.ReplaceAllString(content, " ")
+
+	var lines []string
+	for _, line := range strings.Split(content, "\n") {
+		lines = append(lines, strings.TrimSpace(line))
+	}
+	content = previewBlankLines.ReplaceAllString(strings.Join(lines, "\n"), "\n\n")
+
+	return strings.TrimSpace(content)
+}
+
+// escapePreviewText : entities are decoded first so existing ones are not escaped twice
+func escapePreviewText(text string) string {
+	return html.EscapeString(html.UnescapeString(text))
+}
+
+// previewLink : href of the a tag attributes when it is an http(s) link
+func previewLink(attrs string) string {
+
+	match := previewHref.FindStringSubmatch(attrs)
+	if match == nil {
+		return ""
+	}
+	href := strings.TrimSpace(html.UnescapeString(match[2] + match[3] + match[4]))
+	lower := strings.ToLower(href)
+	if !strings.HasPrefix(lower, "http://") && !strings.HasPrefix(lower, "https://") {
+		return ""
+	}
+	return href
+}
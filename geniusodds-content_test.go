@@ -0,0 +1,44 @@
+package isg
+
+import "testing"
+
+func TestSanitisePreviewHTML(t *testing.T) {
+
+	tests := []struct {
+		content string
+		want    string
+	}{
+		{`<p class="lead" onclick="x()">Tigers <b>by 12</b></p>`, `<p>Tigers <b>by 12</b></p>`},
+		{`<p>Hi</p><script>alert(1)</script><style>p{}</style><!-- note -->`, `<p>Hi</p>`},
+		{`<a href="https://example.com/a?b=1&amp;c=2">odds</a>`, `<a href="https://example.com/a?b=1&amp;c=2" rel="nofollow noopener" target="_blank">odds</a>`},
+		{`<a href="javascript:alert(1)">odds</a>`, `<a>odds</a>`},
+		{`<img src=x onerror=alert(1)>Swans<br/>win`, `Swans<br>win`},
+		{`Fish &amp; Chips < 3`, `Fish &amp; Chips &lt; 3`},
+		{`<iframe src="https://example.com"></iframe><div><h2>Team news</h2></div>`, `<h2>Team news</h2>`},
+	}
+
+	for _, tt := range tests {
+		if got := SanitisePreviewHTML(tt.content); got != tt.want {
+			t.Errorf("SanitisePreviewHTML(%q) = %q, want %q", tt.content, got, tt.want)
+		}
+	}
+}
+
+func TestPreviewPlainText(t *testing.T) {
+
+	content := "<p>Tigers&nbsp;by 12</p><ul><li>Lynch out</li><li>Riewoldt in</li></ul><script>x()</script><p>Fish &amp; Chips</p>"
+	want := "Tigers by 12\n\nLynch out\nRiewoldt in\n\nFish & Chips"
+	if got := PreviewPlainText(content); got != want {
+		t.Errorf("PreviewPlainText = %q, want %q", got, want)
+	}
+}
+
+func TestBindingGeniusOddsPreview(t *testing.T) {
+
+	if BindingGeniusOddsPreview("  ") != nil {
+		t.Error("preview bound for empty content")
+	}
+	if objPreview := BindingGeniusOddsPreview("<p>Hi</p>"); objPreview == nil || objPreview.HTML != "<p>Hi</p>" || objPreview.Text != "Hi" {
+		t.Errorf("preview = %+v", objPreview)
+	}
+}
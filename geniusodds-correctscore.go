@@ -0,0 +1,71 @@
+package sports
+
+import (
+	"data"
+	"fmt"
+	"net/http"
+	"strconv"
+	"util"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/thegeniusgroup/isgdatalib"
+)
+
+// GeniusOddsCorrectScore : correct score grid of a match per provider with implied probabilities and the derived result, BTTS, totals and margins.
+// AFL and NRL scores are grouped in bands, see isg.CorrectScoreBandWidth.
+// GET  /geniusodds/correctscore/{:sport}/{:matchid}?odds_format=
+func GeniusOddsCorrectScore(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+
+	sportname := util.CleanText(p.ByName("sport"), true, true)
+	matchid := util.CleanText(p.ByName("matchid"), true, true)
+
+	objsport, err := data.GetSport(sportname)
+	if err != nil {
+		util.WebResponse(w, r, http.StatusNotFound, "sport not found")
+		return
+	}
+
+	objOptions, err := getGeniusOddsOptions(r)
+	if err != nil {
+		util.WebResponse(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	bandWidth, ok := isg.CorrectScoreBandWidth[objsport.SportInternalID]
+	if !ok {
+		util.WebResponse(w, r, http.StatusNotFound, "sport not supported")
+		return
+	}
+
+	matchID, err := strconv.Atoi(matchid)
+	if err != nil {
+		util.WebResponse(w, r, http.StatusNotFound, "invalid id")
+		return
+	}
+
+	records, err := data.GetCorrectScoreOdds(objsport, matchID)
+	if err != nil {
+		fmt.Println(err.Error())
+		util.WebResponse(w, r, http.StatusNotFound, "record not found")
+		return
+	}
+
+	grids := isg.BindingCorrectScoreGrids(records, bandWidth)
+	if len(grids) == 0 {
+		util.WebResponse(w, r, http.StatusNotFound, "record not found")
+		return
+	}
+	isg.FormatCorrectScoreGrids(grids, objOptions.OddsFormat)
+
+	var t isg.CorrectScoreMatch
+	t.SportID = objsport.SportAPICode
+	t.SportName = objsport.SportName
+	t.SportURL = objsport.SportURL
+	t.MatchID = int64(matchID)
+	t.BandWidth = bandWidth
+	t.Providers = grids
+
+	final := util.JSONMessageWrappedObj(http.StatusOK, t)
+	util.WebResponseJSONObjectNoCache(w, r, http.StatusOK, final)
+	return
+}
@@ -0,0 +1,312 @@
+package isg
+
+import (
+	"math"
+	"sort"
+	"strconv"
+)
+
+// GeniusOddsExoticMarketIDs : isg api ids of the Australian exotic team markets. The home, away or any side of a selection
+// comes from its team, the min win margin from its market value.
+var GeniusOddsExoticMarketIDs = []string{"big_win", "little_win", "wire_to_wire", "htft", "htft_draw", "htft_trail", "htft_team_draw", "min_win", "min_win_tie", "tri_bet"}
+
+// geniusOddsExoticSlot : price and market id fields of Odds, nil when the side has no such selection
+type geniusOddsExoticSlot func(objOdds *Odds, side string) (**float64, **string)
+
+// geniusOddsExoticOption : one market type of an exotic market, Band tells the min win selections apart
+type geniusOddsExoticOption struct {
+	MarketType string
+	ISGapiID   string
+	Band       string
+	Slot       geniusOddsExoticSlot
+}
+
+// geniusOddsExoticBandIDs : isg api ids whose selections are told apart by the margin band of their market value
+var geniusOddsExoticBandIDs = []string{"min_win"}
+
+// geniusOddsExoticMarkets : exotic markets and their options in page order, each option reads its prices from the Odds exotic fields
+var geniusOddsExoticMarkets = []struct {
+	MarketName string
+	Options    []geniusOddsExoticOption
+}{
+	{"Big Win Little Win", []geniusOddsExoticOption{
+		{"Big Win", "big_win", "", func(objOdds *Odds, side string) (**float64, **string) {
+			if market := objOdds.bigWinLittleWin(side); market != nil {
+				return &market.BigWinOdds, &market.BigWinMarketID
+			}
+			return nil, nil
+		}},
+		{"Little Win", "little_win", "", func(objOdds *Odds, side string) (**float64, **string) {
+			if market := objOdds.bigWinLittleWin(side); market != nil {
+				return &market.LittleWinOdds, &market.LittleWinMarketID
+			}
+			return nil, nil
+		}},
+	}},
+	{"Wire to Wire", []geniusOddsExoticOption{
+		{"Wire to Wire", "wire_to_wire", "", func(objOdds *Odds, side string) (**float64, **string) {
+			market := &objOdds.AnyWireToWire
+			switch side {
+			case "home":
+				market = &objOdds.HomeWireToWire
+			case "away":
+				market = &objOdds.AwayWireToWire
+			}
+			return &market.WireToWireOdds, &market.WireToWireMarket
+		}},
+	}},
+	{"HT/FT", []geniusOddsExoticOption{
+		{"Win/Win", "htft", "", func(objOdds *Odds, side string) (**float64, **string) {
+			if market := objOdds.htft(side); market != nil {
+				return &market.HTFTOdds, &market.HTFTMarketID
+			}
+			return nil, nil
+		}},
+		{"Draw/Win", "htft_draw", "", func(objOdds *Odds, side string) (**float64, **string) {
+			if market := objOdds.htft(side); market != nil {
+				return &market.DrawHTFTOdds, &market.DrawHTFTMarketID
+			}
+			// draw at half time and full time
+			return &objOdds.AnyHTFTOdds, &objOdds.AnyHTFTMarketID
+		}},
+		{"Lose/Win", "htft_trail", "", func(objOdds *Odds, side string) (**float64, **string) {
+			if market := objOdds.htft(side); market != nil {
+				return &market.TeamHTFTOdds, &market.TeamHTFTMarketID
+			}
+			return nil, nil
+		}},
+		{"Win/Draw", "htft_team_draw", "", func(objOdds *Odds, side string) (**float64, **string) {
+			if market := objOdds.htft(side); market != nil {
+				return &market.TeamDrawHTFTOdds, &market.TeamDrawHTFTMarketID
+			}
+			return nil, nil
+		}},
+	}},
+	{"Min Win", []geniusOddsExoticOption{
+		{"1", "min_win", "1", func(objOdds *Odds, side string) (**float64, **string) {
+			if market := objOdds.minWin(side); market != nil {
+				return &market.MinWin1Odds, &market.MinWin1MarketID
+			}
+			return nil, nil
+		}},
+		{"2", "min_win", "2", func(objOdds *Odds, side string) (**float64, **string) {
+			if market := objOdds.minWin(side); market != nil {
+				return &market.MinWin2Odds, &market.MinWin2MarketID
+			}
+			return nil, nil
+		}},
+		{"3+", "min_win", "3+", func(objOdds *Odds, side string) (**float64, **string) {
+			if market := objOdds.minWin(side); market != nil {
+				return &market.MinWinMoreOdds, &market.MinWinMoreMarketID
+			}
+			return nil, nil
+		}},
+		{"Tie", "min_win_tie", "", func(objOdds *Odds, side string) (**float64, **string) {
+			if side == "any" {
+				return &objOdds.MinWinTieOdds, &objOdds.MinWinTieMarketID
+			}
+			return nil, nil
+		}},
+	}},
+	{"Tri-Bet", []geniusOddsExoticOption{
+		{"Tri-Bet", "tri_bet", "", func(objOdds *Odds, side string) (**float64, **string) {
+			market := &objOdds.AnyTriBet
+			switch side {
+			case "home":
+				market = &objOdds.HomeTriBet
+			case "away":
+				market = &objOdds.AwayTriBet
+			}
+			return &market.TriBetOdds, &market.TriBetMarket
+		}},
+	}},
+}
+
+// bigWinLittleWin : team side only
+func (objOdds *Odds) bigWinLittleWin(side string) *BigWinLittleWinMarket {
+	switch side {
+	case "home":
+		return &objOdds.HomeBigWinLittleWin
+	case "away":
+		return &objOdds.AwayBigWinLittleWin
+	}
+	return nil
+}
+
+// htft : team side only, the any side is the draw/draw selection
+func (objOdds *Odds) htft(side string) *HTFTMarket {
+	switch side {
+	case "home":
+		return &objOdds.HomeHTFT
+	case "away":
+		return &objOdds.AwayHTFT
+	}
+	return nil
+}
+
+// minWin : team side only
+func (objOdds *Odds) minWin(side string) *MinWinMarket {
+	switch side {
+	case "home":
+		return &objOdds.HomeMinWin
+	case "away":
+		return &objOdds.AwayMinWin
+	}
+	return nil
+}
+
+// IsGeniusOddsExoticMarket :
+func IsGeniusOddsExoticMarket(isgAPIID string) bool {
+	return CheckValueInArray(GeniusOddsExoticMarketIDs, isgAPIID)
+}
+
+// SplitGeniusOddsExoticMarkets : separates the exotic team market selections from the other provider odds of a match
+func SplitGeniusOddsExoticMarkets(liveOdds []GeniusOddsMarket) ([]GeniusOddsMarket, []GeniusOddsMarket) {
+
+	var otherOdds, exoticOdds []GeniusOddsMarket
+	for _, objOdds := range liveOdds {
+		if IsGeniusOddsExoticMarket(objOdds.ISGapiID) {
+			exoticOdds = append(exoticOdds, objOdds)
+			continue
+		}
+		otherOdds = append(otherOdds, objOdds)
+	}
+	return otherOdds, exoticOdds
+}
+
+// geniusOddsExoticSide : home, away or any side of a selection from its team
+func geniusOddsExoticSide(objOdds GeniusOddsMarket) string {
+	switch objOdds.MarketTeamID {
+	case 0:
+		return "any"
+	case objOdds.HomeTeamID:
+		return "home"
+	case objOdds.AwayTeamID:
+		return "away"
+	}
+	return "any"
+}
+
+// geniusOddsExoticBand : margin band of a min win selection, margins of 3 or more share the 3+ band. Empty for the
+// other markets, their market value is not part of the selection.
+func geniusOddsExoticBand(selection GeniusOddsMarket) string {
+
+	if !CheckValueInArray(geniusOddsExoticBandIDs, selection.ISGapiID) || selection.MarketVal == nil {
+		return ""
+	}
+
+	switch margin := int(math.Abs(*selection.MarketVal)); {
+	case margin >= 3:
+		return "3+"
+	case margin > 0:
+		return strconv.Itoa(margin)
+	}
+	return ""
+}
+
+// geniusOddsExoticOptionSlot : Odds field of an exotic selection by its isg api id and band, nil when the selection has no field
+func geniusOddsExoticOptionSlot(objOdds *Odds, selection GeniusOddsMarket) (**float64, **string) {
+
+	band := geniusOddsExoticBand(selection)
+	for _, market := range geniusOddsExoticMarkets {
+		for _, option := range market.Options {
+			if option.ISGapiID == selection.ISGapiID && option.Band == band {
+				return option.Slot(objOdds, geniusOddsExoticSide(selection))
+			}
+		}
+	}
+	return nil, nil
+}
+
+// bindingGeniusOddsExoticMarkets : loads the exotic selections of each provider into the Odds exotic fields and binds them
+// into home, away and any sides with the best price first
+func bindingGeniusOddsExoticMarkets(exoticOdds []GeniusOddsMarket, objhometeam, objawayteam Team) []GeniusMarketOdds {
+
+	var objMarketOdds []GeniusMarketOdds
+	if len(exoticOdds) == 0 {
+		return objMarketOdds
+	}
+
+	// one Odds per provider, the selection keeps the open price and flucs of its field
+	var providers []string
+	providerOdds := map[string]*Odds{}
+	selections := map[string]GeniusOddsMarket{}
+	for _, selection := range exoticOdds {
+
+		providerID := selection.ProviderInfo.ProviderId
+		objOdds, ok := providerOdds[providerID]
+		if !ok {
+			objOdds = &Odds{}
+			providerOdds[providerID] = objOdds
+			providers = append(providers, providerID)
+		}
+
+		price, marketID := geniusOddsExoticOptionSlot(objOdds, selection)
+		if price == nil || selection.MarketPrice == nil {
+			continue
+		}
+		providerMarketID := selection.ProviderMarketID
+		*price, *marketID = selection.MarketPrice, &providerMarketID
+		selections[providerID+"-"+providerMarketID] = selection
+	}
+
+	for _, market := range geniusOddsExoticMarkets {
+
+		var objMarketOdd GeniusMarketOdds
+		objMarketOdd.MarketName = market.MarketName
+
+		for _, option := range market.Options {
+
+			objMarketOption := GeniusMarketOptions{MarketType: option.MarketType}
+			objMarketOption.GeniusHomeMarketOdds = bindingGeniusOddsExoticSide(option, "home", providers, providerOdds, selections, &objhometeam)
+			objMarketOption.GeniusAwayMarketOdds = bindingGeniusOddsExoticSide(option, "away", providers, providerOdds, selections, &objawayteam)
+			objMarketOption.GeniusAnyMarketOdds = bindingGeniusOddsExoticSide(option, "any", providers, providerOdds, selections, nil)
+
+			if objMarketOption.GeniusHomeMarketOdds != nil || objMarketOption.GeniusAwayMarketOdds != nil || objMarketOption.GeniusAnyMarketOdds != nil {
+				objMarketOdd.MarketOption = append(objMarketOdd.MarketOption, objMarketOption)
+			}
+		}
+
+		if len(objMarketOdd.MarketOption) > 0 {
+			objMarketOdds = append(objMarketOdds, objMarketOdd)
+		}
+	}
+
+	return objMarketOdds
+}
+
+// bindingGeniusOddsExoticSide : provider prices of one side of an exotic option, nil when no provider prices it
+func bindingGeniusOddsExoticSide(option geniusOddsExoticOption, side string, providers []string, providerOdds map[string]*Odds,
+	selections map[string]GeniusOddsMarket, objteam *Team) *MarketOddsList {
+
+	var providerList []OddsInfo
+	for _, providerID := range providers {
+
+		price, marketID := option.Slot(providerOdds[providerID], side)
+		if price == nil || *price == nil || *marketID == nil {
+			continue
+		}
+
+		selection := selections[providerID+"-"+**marketID]
+		var objOdd OddsInfo
+		objOdd.Name = selection.ProviderInfo.Name
+		objOdd.Icon = selection.ProviderInfo.Icon
+		objOdd.ProviderSequence = selection.ProviderInfo.GeniusOddsSequence
+		objOdd.MarketID = **marketID
+		objOdd.OpenOdds = selection.MarketFlucPrice
+		objOdd.NewOdds = *price
+		objOdd.MarketInternalID = int(selection.MarketID)
+		objOdd.MarketName = option.MarketType
+		objOdd.CategoryName = selection.CategoryName
+		objOdd.Flucs = selection.Flucs
+		objOdd.TypeVal = "market"
+		providerList = append(providerList, objOdd)
+	}
+
+	if len(providerList) == 0 {
+		return nil
+	}
+
+	sort.Sort(MatchMarketSort(providerList))
+	return &MarketOddsList{TeamInfo: objteam, ProviderList: providerList}
+}
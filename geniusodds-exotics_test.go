@@ -0,0 +1,65 @@
+package isg
+
+import "testing"
+
+// testExoticMarket : an exotic selection of match 1 with the provider market id of its price
+func testExoticMarket(providerID, isgAPIID, providerMarketID string, teamID int64, price float64, val *float64) GeniusOddsMarket {
+	objMarket := testMarket(providerID, 200, teamID, "Exotics", price, val)
+	objMarket.ISGapiID = isgAPIID
+	objMarket.ProviderMarketID = providerMarketID
+	return objMarket
+}
+
+func TestBindingGeniusOddsExoticMarketsShareMargin(t *testing.T) {
+
+	// the big win and the first min win selection both carry a market value of 3
+	exoticOdds := []GeniusOddsMarket{
+		testExoticMarket("1", "big_win", "bw", 10, 3.40, testFloat(3)),
+		testExoticMarket("1", "min_win", "mw3", 10, 2.10, testFloat(3)),
+		testExoticMarket("1", "min_win", "mw1", 10, 9.00, testFloat(1)),
+		testExoticMarket("1", "min_win_tie", "tie", 0, 26.00, testFloat(0)),
+	}
+
+	objMarketOdds := bindingGeniusOddsExoticMarkets(exoticOdds, Team{TeamName: "Home"}, Team{TeamName: "Away"})
+	if len(objMarketOdds) != 2 || objMarketOdds[0].MarketName != "Big Win Little Win" || objMarketOdds[1].MarketName != "Min Win" {
+		t.Fatalf("markets = %+v", objMarketOdds)
+	}
+
+	bigWin := objMarketOdds[0].MarketOption[0]
+	if bigWin.MarketType != "Big Win" || bigWin.GeniusHomeMarketOdds == nil || *bigWin.GeniusHomeMarketOdds.ProviderList[0].NewOdds != 3.40 {
+		t.Errorf("big win = %+v", bigWin)
+	}
+
+	prices := map[string]float64{}
+	for _, objOption := range objMarketOdds[1].MarketOption {
+		objSide := objOption.GeniusHomeMarketOdds
+		if objSide == nil {
+			objSide = objOption.GeniusAnyMarketOdds
+		}
+		prices[objOption.MarketType] = *objSide.ProviderList[0].NewOdds
+	}
+	if len(prices) != 3 || prices["1"] != 9.00 || prices["3+"] != 2.10 || prices["Tie"] != 26.00 {
+		t.Errorf("min win = %v", prices)
+	}
+}
+
+func TestGeniusOddsExoticBand(t *testing.T) {
+
+	cases := []struct {
+		isgAPIID string
+		val      *float64
+		band     string
+	}{
+		{"min_win", testFloat(1), "1"},
+		{"min_win", testFloat(-2), "2"},
+		{"min_win", testFloat(7), "3+"},
+		{"min_win", nil, ""},
+		{"big_win", testFloat(3), ""},
+		{"tri_bet", testFloat(12.5), ""},
+	}
+	for _, c := range cases {
+		if band := geniusOddsExoticBand(testExoticMarket("1", c.isgAPIID, "m", 10, 2, c.val)); band != c.band {
+			t.Errorf("%s %v = %q, want %q", c.isgAPIID, c.val, band, c.band)
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package sports
+
+import (
+	"data"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+	"util"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/thegeniusgroup/isgdatalib"
+)
+
+// StartGeniusOddsSettlement : settles the finished matches and repeats after every interval
+func StartGeniusOddsSettlement(interval time.Duration) {
+	for {
+		settleGeniusOddsMatches()
+		time.Sleep(interval)
+	}
+}
+
+// settleGeniusOddsMatches : settles H2H, Line, Total and BTTS selections of every genius odds sport and league
+func settleGeniusOddsMatches() {
+
+	for _, objsport := range data.GeniusOddsSports() {
+
+		// tennis results are sets and games with no _scores table, its prices are not settled
+		if objsport.SportInternalID == 6 {
+			continue
+		}
+
+		objLeagues := data.SportsLeagues[strconv.Itoa(objsport.SportInternalID)]
+
+		for _, objLeague := range objLeagues {
+
+			records, err := data.GetGeniusOddsUnsettledMarkets(objsport, objLeague.LeagueInternalID, 7*24*time.Hour)
+			if err != nil {
+				fmt.Println(err.Error())
+				continue
+			}
+			if len(records) == 0 {
+				continue
+			}
+
+			settled := isg.ScoreGeniusOddsSettlements(records)
+			err = data.InsertGeniusOddsSettlements(settled)
+			if err != nil {
+				fmt.Println(err.Error())
+				continue
+			}
+			fmt.Println("Genius odds settled:", objsport.SportID, objLeague.LeagueEntityKey, len(settled))
+		}
+	}
+}
+
+// GeniusOddsAccuracyLeaderboard : provider accuracy leaderboard (Brier score and log-loss) per sport and season.
+// GET  /geniusodds/leaderboard/{:sport}/{:league}/{:season}
+func GeniusOddsAccuracyLeaderboard(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+
+	sportname := util.CleanText(p.ByName("sport"), true, true)
+	leaguename := util.CleanText(p.ByName("league"), true, true)
+	season := util.CleanText(p.ByName("season"), true, true)
+
+	objsport, err := data.GetSport(sportname)
+	if err != nil {
+		util.WebResponse(w, r, http.StatusNotFound, "sport not found")
+		return
+	}
+
+	// tennis prices are not settled
+	if !data.IsGeniusOddsSport(objsport.SportInternalID) || objsport.SportInternalID == 6 {
+		util.WebResponse(w, r, http.StatusNotFound, "sport not supported")
+		return
+	}
+
+	var objleague isg.League
+	if leaguename != "" {
+		objleague, err = data.GetLeagueID(objsport, leaguename)
+		if err != nil {
+			util.WebResponse(w, r, http.StatusNotFound, "league not found")
+			return
+		}
+	}
+
+	var seasonID int
+	if season != "" {
+		seasonID, err = data.GetSeasonID(objsport, season)
+		if err != nil {
+			util.WebResponse(w, r, http.StatusNotFound, "season not found")
+			return
+		}
+	}
+
+	leaderboard, err := data.GetGeniusOddsAccuracyLeaderboard(objsport, objleague.LeagueInternalID, seasonID)
+	if err != nil {
+		fmt.Println(err.Error())
+		util.WebResponse(w, r, http.StatusNotFound, "record not found")
+		return
+	} else if len(leaderboard) == 0 {
+		util.WebResponse(w, r, http.StatusNotFound, "record not found")
+		return
+	}
+
+	var t isg.GeniusOddsLeaderboard
+	t.SportID = objsport.SportAPICode
+	t.SportName = objsport.SportName
+	t.SportURL = objsport.SportURL
+	t.LeagueURL = objleague.LeagueEntityKey
+	t.Providers = leaderboard
+
+	final := util.JSONMessageWrappedObj(http.StatusOK, t)
+	util.WebResponseJSONObjectNoCache(w, r, http.StatusOK, final)
+	return
+}
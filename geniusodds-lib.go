@@ -1,14 +1,17 @@
 package isg
 
 import (
+	"encoding/base64"
+	"errors"
 	"math"
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // BindingGeniusOddsMatches :
-func BindingGeniusOddsMatches(objMatches []GeniusSportsMatch, typeVal string) GeniusOddsSportMatch {
+func BindingGeniusOddsMatches(objMatches []GeniusSportsMatch, typeVal string, objOptions GeniusOddsOptions) GeniusOddsSportMatch {
 
 	var objSportMatch GeniusOddsSportMatch
 	var objGeniusLeague GeniusOddsMatch
@@ -31,8 +34,10 @@ func BindingGeniusOddsMatches(objMatches []GeniusSportsMatch, typeVal string) Ge
 		if objmatch.TimeZone.Valid {
 			matchesInfo.TimeZone = objmatch.TimeZone.String
 		}
+		setGeniusOddsStartTime(&matchesInfo, objmatch, objOptions.TimeZone)
 
 		matchesInfo.Weather = objmatch.MatchWeather.String
+		matchesInfo.WeatherDetail = objmatch.WeatherInfo
 		matchesInfo.Status = "not_started"
 		matchesInfo.DayNight = objmatch.MatchDayNight.String
 		matchesInfo.IsReschedule = objmatch.MatchReschedule
@@ -72,6 +77,14 @@ func BindingGeniusOddsMatches(objMatches []GeniusSportsMatch, typeVal string) Ge
 		matchesInfo.HomeTeamInfo = objhometeam
 		matchesInfo.AwayTeamInfo = objawayteam
 		matchesInfo.VenueInfo = objvenue
+		matchesInfo.HomeTeamInfo.Form = objmatch.HomeFormInfo
+		matchesInfo.AwayTeamInfo.Form = objmatch.AwayFormInfo
+		matchesInfo.HomeTeamInfo.Travel = objmatch.HomeTravelInfo
+		matchesInfo.AwayTeamInfo.Travel = objmatch.AwayTravelInfo
+		matchesInfo.Tournament = BindingGeniusOddsTournament(objmatch)
+		setGeniusOddsPlayerHands(&matchesInfo, objmatch)
+		matchesInfo.Preview = BindingGeniusOddsPreview(objmatch.PreviewInfo)
+		matchesInfo.Tips = objmatch.TipsInfo
 
 		// for other markets
 		if len(objmatch.IntMatchOdds) > 0 {
@@ -183,6 +196,9 @@ func BindingGeniusOddsMatches(objMatches []GeniusSportsMatch, typeVal string) Ge
 			objMarketOdds = []GeniusMarketOdds{}
 		}
 
+		// model fair prices with the edge of the best provider price
+		matchesInfo.Model = bindingGeniusOddsModel(objmatch.ModelInfo, objmatch.IntMatchOdds, objOptions.OddsFormat)
+
 		/*------------------------------------PLUNGE----------------------------------------*/
 
 		var objPlungeList MarketOddsList
@@ -219,16 +235,16 @@ func BindingGeniusOddsMatches(objMatches []GeniusSportsMatch, typeVal string) Ge
 		}
 		/*------------------------------------//PLUNGE----------------------------------------*/
 
+		// Apply the requested odds format to every price
+		FormatGeniusOddsMatchesInfo(&matchesInfo, objOptions.OddsFormat)
+
 		if (len(objMatches)-1) == i || objmatch.MatchID != objMatches[i+1].MatchID {
 			objLeagueMatch.Matches = append(objLeagueMatch.Matches, matchesInfo)
 		}
 
-		if (len(objMatches)-1) != i && (objmatch.LeagueInfo.LeagueInternalID != objMatches[i+1].LeagueInfo.LeagueInternalID ||
-			objmatch.SportInfo.SportInternalID != objMatches[i+1].SportInfo.SportInternalID) {
+		if (len(objMatches)-1) != i && geniusOddsNewGroup(objmatch, objMatches[i+1]) {
 
-			leagues := strings.Split(objmatch.LeagueInfo.LeagueName, " - ")
-			objLeagueMatch.Leaguename = leagues[1]
-			objLeagueMatch.LeagueURL = objmatch.LeagueInfo.LeagueEntityKey
+			bindingGeniusOddsLeagueGroup(&objLeagueMatch, objmatch)
 			objGeniusLeague.Leagues = append(objGeniusLeague.Leagues, objLeagueMatch)
 
 			objLeagueMatch = GeniusOddsMatchLeague{}
@@ -245,9 +261,7 @@ func BindingGeniusOddsMatches(objMatches []GeniusSportsMatch, typeVal string) Ge
 		}
 		if (len(objMatches) - 1) == i {
 
-			leagues := strings.Split(objmatch.LeagueInfo.LeagueName, " - ")
-			objLeagueMatch.Leaguename = leagues[1]
-			objLeagueMatch.LeagueURL = objmatch.LeagueInfo.LeagueEntityKey
+			bindingGeniusOddsLeagueGroup(&objLeagueMatch, objmatch)
 			objGeniusLeague.Leagues = append(objGeniusLeague.Leagues, objLeagueMatch)
 			objGeniusLeague.SportID = objmatch.SportInfo.SportAPICode
 			objGeniusLeague.SportName = objmatch.SportInfo.SportName
@@ -262,7 +276,7 @@ func BindingGeniusOddsMatches(objMatches []GeniusSportsMatch, typeVal string) Ge
 }
 
 // BindingGeniusOddsMarketMatches :
-func BindingGeniusOddsMarketMatches(objMatches []GeniusSportsMatch, typeVal string, plungeMatch []GeniusOddsPlunge) GeniusOddsSportMatch {
+func BindingGeniusOddsMarketMatches(objMatches []GeniusSportsMatch, typeVal string, plungeMatch []GeniusOddsPlunge, objOptions GeniusOddsOptions) GeniusOddsSportMatch {
 
 	var objSportMatch GeniusOddsSportMatch
 	var objGeniusLeague GeniusOddsMatch
@@ -287,8 +301,10 @@ func BindingGeniusOddsMarketMatches(objMatches []GeniusSportsMatch, typeVal stri
 		if objmatch.TimeZone.Valid {
 			matchesInfo.TimeZone = objmatch.TimeZone.String
 		}
+		setGeniusOddsStartTime(&matchesInfo, objmatch, objOptions.TimeZone)
 
 		matchesInfo.Weather = objmatch.MatchWeather.String
+		matchesInfo.WeatherDetail = objmatch.WeatherInfo
 		matchesInfo.Status = "not_started"
 		matchesInfo.DayNight = objmatch.MatchDayNight.String
 		matchesInfo.IsReschedule = objmatch.MatchReschedule
@@ -328,6 +344,16 @@ func BindingGeniusOddsMarketMatches(objMatches []GeniusSportsMatch, typeVal stri
 		matchesInfo.HomeTeamInfo = objhometeam
 		matchesInfo.AwayTeamInfo = objawayteam
 		matchesInfo.VenueInfo = objvenue
+		matchesInfo.HomeTeamInfo.Form = objmatch.HomeFormInfo
+		matchesInfo.AwayTeamInfo.Form = objmatch.AwayFormInfo
+		matchesInfo.HomeTeamInfo.Travel = objmatch.HomeTravelInfo
+		matchesInfo.AwayTeamInfo.Travel = objmatch.AwayTravelInfo
+		matchesInfo.Tournament = BindingGeniusOddsTournament(objmatch)
+		setGeniusOddsPlayerHands(&matchesInfo, objmatch)
+		matchesInfo.Preview = BindingGeniusOddsPreview(objmatch.PreviewInfo)
+		matchesInfo.Tips = objmatch.TipsInfo
+		matchesInfo.Market = bindingGeniusOddsExoticMarkets(objmatch.ExoticMatchOdds, objhometeam, objawayteam)
+		matchesInfo.Model = bindingGeniusOddsModel(objmatch.ModelInfo, objmatch.ModelMatchOdds, objOptions.OddsFormat)
 		if len(plungeMatch) > 0 {
 			if int(objmatch.HomeTeamInternalID.Int64) == plungeMatch[0].Plunge.TeamID {
 				matchesInfo.IsPlunge = objmatch.HomeTeamID.String
@@ -369,6 +395,7 @@ func BindingGeniusOddsMarketMatches(objMatches []GeniusSportsMatch, typeVal stri
 						anyOdd.ProviderSequence = marketOdd.ProviderSequence
 						anyOddsInfo = append(anyOddsInfo, anyOdd)
 						objany.MarketType = marketOdd.DisplayName
+						objany.PlayerInfo = marketOdd.PlayerInfo
 
 						objany.AbbrName = strings.Replace(strings.Replace(marketOdd.DisplayName, objmatch.HomeTeamName.String, objmatch.HomeTeamAbbr.String, -1),
 							objmatch.AwayTeamName.String, objmatch.AwayTeamAbbr.String, -1)
@@ -383,8 +410,8 @@ func BindingGeniusOddsMarketMatches(objMatches []GeniusSportsMatch, typeVal stri
 					}
 				}
 
-				// Sort the fgs according to thier best provider new odds
-				if MarketName == "First Goal Scorer" {
+				// Sort the fgs and player markets according to thier best provider new odds
+				if MarketName == "First Goal Scorer" || intMarket.GroupName == "Player" {
 					sort.Sort(FGSSort(objMarketOptions))
 				}
 
@@ -410,16 +437,16 @@ func BindingGeniusOddsMarketMatches(objMatches []GeniusSportsMatch, typeVal stri
 			objMarketGroup = []GeniusGroups{}
 		}
 
+		// Apply the requested odds format to every price
+		FormatGeniusOddsMatchesInfo(&matchesInfo, objOptions.OddsFormat)
+
 		if (len(objMatches)-1) == i || objmatch.MatchID != objMatches[i+1].MatchID {
 			objLeagueMatch.Matches = append(objLeagueMatch.Matches, matchesInfo)
 		}
 
-		if (len(objMatches)-1) != i && (objmatch.LeagueInfo.LeagueInternalID != objMatches[i+1].LeagueInfo.LeagueInternalID ||
-			objmatch.SportInfo.SportInternalID != objMatches[i+1].SportInfo.SportInternalID) {
+		if (len(objMatches)-1) != i && geniusOddsNewGroup(objmatch, objMatches[i+1]) {
 
-			leagues := strings.Split(objmatch.LeagueInfo.LeagueName, " - ")
-			objLeagueMatch.Leaguename = leagues[1]
-			objLeagueMatch.LeagueURL = objmatch.LeagueInfo.LeagueEntityKey
+			bindingGeniusOddsLeagueGroup(&objLeagueMatch, objmatch)
 			objGeniusLeague.Leagues = append(objGeniusLeague.Leagues, objLeagueMatch)
 
 			objLeagueMatch = GeniusOddsMatchLeague{}
@@ -436,9 +463,7 @@ func BindingGeniusOddsMarketMatches(objMatches []GeniusSportsMatch, typeVal stri
 		}
 		if (len(objMatches) - 1) == i {
 
-			leagues := strings.Split(objmatch.LeagueInfo.LeagueName, " - ")
-			objLeagueMatch.Leaguename = leagues[1]
-			objLeagueMatch.LeagueURL = objmatch.LeagueInfo.LeagueEntityKey
+			bindingGeniusOddsLeagueGroup(&objLeagueMatch, objmatch)
 			objGeniusLeague.Leagues = append(objGeniusLeague.Leagues, objLeagueMatch)
 			objGeniusLeague.SportID = objmatch.SportInfo.SportAPICode
 			objGeniusLeague.SportName = objmatch.SportInfo.SportName
@@ -470,45 +495,51 @@ func (a GeniusSortMatchesISG) Less(i, j int) bool {
 	return isLess
 }
 
-// sortingGeniusMatchesISG :
+// sortingGeniusMatchesISG : order of the listing type by GeniusOddsSortKey, the pages of a listing follow the same order
 func sortingGeniusMatchesISG(typeVal string, objMatchs, objMatchs1 GeniusSportsMatch) bool {
+	return GeniusOddsSortKey(typeVal, objMatchs).Less(GeniusOddsSortKey(typeVal, objMatchs1))
+}
 
-	sequence := SportsSequence[objMatchs.SportInfo.SportInternalID]
-	sequence1 := SportsSequence[objMatchs1.SportInfo.SportInternalID]
-	
-	if typeVal == "upcoming" {
-
-		if objMatchs.CounterDate.String < objMatchs1.CounterDate.String {
-			return true
-		} else if objMatchs.CounterDate.String == objMatchs1.CounterDate.String && objMatchs.CounterTime.String < objMatchs1.CounterTime.String {
-			return true
-		} else if objMatchs.CounterDate.String == objMatchs1.CounterDate.String && objMatchs.CounterTime.String == objMatchs1.CounterTime.String && sequence < sequence1 {
-			return true
-		}
-	} else if typeVal == "plunge" {
-		if objMatchs.PlungeOddsList != nil && objMatchs1.PlungeOddsList != nil {
-			if math.Abs(*objMatchs.PlungeOddsList[0].Plunge.ChangePercentage) > math.Abs(*objMatchs1.PlungeOddsList[0].Plunge.ChangePercentage) {
-				return true
-			} else if math.Abs(*objMatchs.PlungeOddsList[0].Plunge.ChangePercentage) == math.Abs(*objMatchs1.PlungeOddsList[0].Plunge.ChangePercentage) && sequence < sequence1 {
-				return true
+// GeniusOddsSortKey : sort key of a match in the listing type. Best matches without both H2H prices come first,
+// plunge matches without a plunge last.
+func GeniusOddsSortKey(typeVal string, objmatch GeniusSportsMatch) GeniusOddsCursor {
+
+	key := GeniusOddsCursor{Type: typeVal, Sequence: SportsSequence[objmatch.SportInfo.SportInternalID], MatchID: objmatch.MatchID.Int64}
+
+	switch typeVal {
+	case "upcoming":
+		key.CounterDateTime = objmatch.CounterDate.String + " " + objmatch.CounterTime.String
+	case "best":
+		key.Rank = -1
+		if len(objmatch.IntMatchOdds) > 0 && len(objmatch.IntMatchOdds[0].HomeMarketOdds) > 0 && len(objmatch.IntMatchOdds[0].AwayMarketOdds) > 0 {
+			homeOdds := objmatch.IntMatchOdds[0].HomeMarketOdds[0].NewOdds
+			awayOdds := objmatch.IntMatchOdds[0].AwayMarketOdds[0].NewOdds
+			if homeOdds != nil && awayOdds != nil {
+				key.Rank = math.Abs(*homeOdds - *awayOdds)
 			}
 		}
-	} else if typeVal == "best" {
-
-		if objMatchs.IntMatchOdds[0].HomeMarketOdds[0].NewOdds != nil && objMatchs1.IntMatchOdds[0].HomeMarketOdds[0].NewOdds != nil &&
-			(math.Abs(*objMatchs.IntMatchOdds[0].HomeMarketOdds[0].NewOdds-*objMatchs.IntMatchOdds[0].AwayMarketOdds[0].NewOdds) < math.Abs(*objMatchs1.IntMatchOdds[0].HomeMarketOdds[0].NewOdds-*objMatchs1.IntMatchOdds[0].AwayMarketOdds[0].NewOdds)) {
-			return true
-		} else if objMatchs.IntMatchOdds[0].HomeMarketOdds[0].NewOdds == nil && objMatchs1.IntMatchOdds[0].HomeMarketOdds[0].NewOdds != nil {
-			return true
-		} else if objMatchs.IntMatchOdds[0].HomeMarketOdds[0].NewOdds != nil && objMatchs1.IntMatchOdds[0].HomeMarketOdds[0].NewOdds != nil &&
-			(math.Abs(*objMatchs.IntMatchOdds[0].HomeMarketOdds[0].NewOdds-*objMatchs.IntMatchOdds[0].AwayMarketOdds[0].NewOdds) == math.Abs(*objMatchs1.IntMatchOdds[0].HomeMarketOdds[0].NewOdds-*objMatchs1.IntMatchOdds[0].AwayMarketOdds[0].NewOdds)) &&
-			sequence < sequence1 {
-			return true
+	case "plunge":
+		if len(objmatch.PlungeOddsList) > 0 && objmatch.PlungeOddsList[0].Plunge.ChangePercentage != nil {
+			key.Rank = -math.Abs(*objmatch.PlungeOddsList[0].Plunge.ChangePercentage)
 		}
+	}
+
+	return key
+}
 
-	} 
+// Less : true when the key sorts before key1
+func (key GeniusOddsCursor) Less(key1 GeniusOddsCursor) bool {
 
-	return false
+	if key.CounterDateTime != key1.CounterDateTime {
+		return key.CounterDateTime < key1.CounterDateTime
+	}
+	if key.Rank != key1.Rank {
+		return key.Rank < key1.Rank
+	}
+	if key.Sequence != key1.Sequence {
+		return key.Sequence < key1.Sequence
+	}
+	return key.MatchID < key1.MatchID
 }
 
 // PlungeSort :
@@ -616,6 +647,7 @@ func MakingGeniusLiveOddsSort(objLiveOdds []GeniusOddsMarket, sportID int, typeV
 				objOdd.NewOdds = objbest.MarketPrice
 				objOdd.MarketInternalID = int(objbest.MarketID)
 				objOdd.MarketName = objbest.MarketName
+				objOdd.ISGapiID = objbest.ISGapiID
 				objOdd.Flucs = objbest.Flucs
 				//condition for line
 				if objbest.CategoryName == "Line" && objbest.MarketVal != nil {
@@ -629,6 +661,7 @@ func MakingGeniusLiveOddsSort(objLiveOdds []GeniusOddsMarket, sportID int, typeV
 				objOdd.NewOdds = objbest.MarketPrice
 				objOdd.MarketInternalID = int(objbest.MarketID)
 				objOdd.MarketName = objbest.MarketName
+				objOdd.ISGapiID = objbest.ISGapiID
 				objOdd.Flucs = objbest.Flucs
 				//condition for line
 				if objbest.CategoryName == "Line" && objbest.MarketVal != nil {
@@ -642,6 +675,7 @@ func MakingGeniusLiveOddsSort(objLiveOdds []GeniusOddsMarket, sportID int, typeV
 				objOdd.NewOdds = objbest.MarketPrice
 				objOdd.MarketInternalID = int(objbest.MarketID)
 				objOdd.MarketName = objbest.MarketName
+				objOdd.ISGapiID = objbest.ISGapiID
 				objOdd.Flucs = objbest.Flucs
 				//condition for closing total
 				if objbest.CategoryName == "Total" && objbest.MarketVal != nil {
@@ -704,6 +738,10 @@ func MakingGeniusLiveMarketOddsSort(objLiveOdds []GeniusOddsMarket, sportID int,
 			objOdd.Flucs = objbest.Flucs
 			objOdd.MarketName = objbest.MarketName
 			objOdd.TypeVal = "market"
+			if objbest.PlayerInfo != nil {
+				objOdd.PlayerInfo = objbest.PlayerInfo
+				objOdd.PlayerName = objbest.PlayerInfo.Name
+			}
 
 			//condition for line
 			if strings.Contains(objbest.CategoryName, "Line") && objbest.MarketVal != nil {
@@ -869,3 +907,105 @@ func MakingGeniusLiveOddsSortBgProc(objLiveOdds []GeniusOddsMarket, sportID int,
 
 	return geniusMarketOdds
 }
+
+// setGeniusOddsStartTime : ISO-8601 kick off times in UTC, at the venue and in the requested time zone.
+// match_date / match_time follow the requested time zone when one is passed, AEST otherwise.
+func setGeniusOddsStartTime(matchesInfo *GeniusOddsMatchesInfo, objmatch GeniusSportsMatch, loc *time.Location) {
+
+	if objmatch.StartTime.IsZero() {
+		return
+	}
+
+	matchesInfo.StartTimeUTC = objmatch.StartTime.UTC().Format(time.RFC3339)
+	matchesInfo.StartTime = objmatch.StartTime.Format(time.RFC3339)
+
+	if objmatch.TimeZone.Valid && objmatch.TimeZone.String != "" {
+		venueLoc, err := time.LoadLocation(objmatch.TimeZone.String)
+		if err == nil {
+			matchesInfo.StartTimeLocal = objmatch.StartTime.In(venueLoc).Format(time.RFC3339)
+		}
+	}
+
+	if loc != nil {
+		startTime := objmatch.StartTime.In(loc)
+		matchesInfo.StartTime = startTime.Format(time.RFC3339)
+		matchesInfo.MatchDate = startTime.Format("2006-01-02")
+		matchesInfo.MatchTime = startTime.Format("15:04:05")
+	}
+}
+
+// EncodeGeniusOddsCursor : opaque page token of a sort key, type|kick off or rank|sequence|match id
+func EncodeGeniusOddsCursor(key GeniusOddsCursor) string {
+	value := key.CounterDateTime
+	if key.Type != "upcoming" {
+		value = strconv.FormatFloat(key.Rank, 'f', -1, 64)
+	}
+	cursor := key.Type + "|" + value + "|" + strconv.Itoa(key.Sequence) + "|" + strconv.FormatInt(key.MatchID, 10)
+	return base64.RawURLEncoding.EncodeToString([]byte(cursor))
+}
+
+// DecodeGeniusOddsCursor : validates a page token made by EncodeGeniusOddsCursor
+func DecodeGeniusOddsCursor(token string) (*GeniusOddsCursor, error) {
+
+	invalid := errors.New(ISGErrBadInputPrefix + "invalid cursor " + token)
+
+	cursor, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, invalid
+	}
+
+	parts := strings.Split(string(cursor), "|")
+	if len(parts) != 4 {
+		return nil, invalid
+	}
+
+	key := GeniusOddsCursor{Type: parts[0]}
+	switch key.Type {
+	case "upcoming":
+		_, err = time.Parse("2006-01-02 15:04:05", parts[1])
+		key.CounterDateTime = parts[1]
+	case "best", "plunge":
+		key.Rank, err = strconv.ParseFloat(parts[1], 64)
+	default:
+		return nil, invalid
+	}
+	if err != nil {
+		return nil, invalid
+	}
+
+	key.Sequence, err = strconv.Atoi(parts[2])
+	if err != nil {
+		return nil, invalid
+	}
+
+	key.MatchID, err = strconv.ParseInt(parts[3], 10, 64)
+	if err != nil {
+		return nil, invalid
+	}
+
+	return &key, nil
+}
+
+// PageGeniusOddsMatches : the matches after the cursor in the order of the listing type, the first limit of them and the
+// cursor of the next page. The upcoming page queries already start after the cursor, best and plunge matches are ranked
+// by their live prices so their cursor is applied here.
+func PageGeniusOddsMatches(objMatches []GeniusSportsMatch, typeVal string, limit int, cursor *GeniusOddsCursor) ([]GeniusSportsMatch, string) {
+
+	var objPage []GeniusSportsMatch
+	for _, objmatch := range objMatches {
+		if cursor == nil || cursor.Less(GeniusOddsSortKey(typeVal, objmatch)) {
+			objPage = append(objPage, objmatch)
+		}
+	}
+
+	sort.Slice(objPage, func(i, j int) bool {
+		return sortingGeniusMatchesISG(typeVal, objPage[i], objPage[j])
+	})
+
+	if limit <= 0 || len(objPage) <= limit {
+		return objPage, ""
+	}
+
+	objPage = objPage[:limit]
+	return objPage, EncodeGeniusOddsCursor(GeniusOddsSortKey(typeVal, objPage[limit-1]))
+}
@@ -0,0 +1,157 @@
+package isg
+
+import (
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestSetGeniusOddsStartTime(t *testing.T) {
+
+	objmatch := testMatch(1, 1)
+	objmatch.StartTime = time.Date(2024, 5, 1, 9, 40, 0, 0, time.UTC)
+	objmatch.TimeZone = testNullString("Australia/Perth")
+
+	var matchesInfo GeniusOddsMatchesInfo
+	setGeniusOddsStartTime(&matchesInfo, objmatch, nil)
+	if matchesInfo.StartTimeUTC != "2024-05-01T09:40:00Z" || matchesInfo.StartTimeLocal != "2024-05-01T17:40:00+08:00" {
+		t.Errorf("start times = %q, %q", matchesInfo.StartTimeUTC, matchesInfo.StartTimeLocal)
+	}
+
+	loc, err := time.LoadLocation("America/Argentina/Buenos_Aires")
+	if err != nil {
+		t.Fatal(err)
+	}
+	matchesInfo = GeniusOddsMatchesInfo{}
+	setGeniusOddsStartTime(&matchesInfo, objmatch, loc)
+	if matchesInfo.StartTime != "2024-05-01T06:40:00-03:00" || matchesInfo.MatchDate != "2024-05-01" || matchesInfo.MatchTime != "06:40:00" {
+		t.Errorf("requested tz = %q %q %q", matchesInfo.StartTime, matchesInfo.MatchDate, matchesInfo.MatchTime)
+	}
+
+	// matches without a kick off keep the stored date and time
+	matchesInfo = GeniusOddsMatchesInfo{MatchDate: "2024-05-01"}
+	setGeniusOddsStartTime(&matchesInfo, testMatch(2, 1), loc)
+	if matchesInfo.StartTime != "" || matchesInfo.MatchDate != "2024-05-01" {
+		t.Errorf("no kick off = %+v", matchesInfo)
+	}
+}
+
+func TestGeniusOddsCursorRoundTrip(t *testing.T) {
+
+	keys := []GeniusOddsCursor{
+		{Type: "upcoming", CounterDateTime: "2024-05-01 19:40:00", Sequence: 2, MatchID: 11},
+		{Type: "best", Rank: 0.35, Sequence: 1, MatchID: 12},
+		{Type: "best", Rank: -1, Sequence: 10, MatchID: 13},
+		{Type: "plunge", Rank: -12.5, Sequence: 1, MatchID: 14},
+	}
+	for _, key := range keys {
+		cursor, err := DecodeGeniusOddsCursor(EncodeGeniusOddsCursor(key))
+		if err != nil || *cursor != key {
+			t.Errorf("%+v decoded as %+v, %v", key, cursor, err)
+		}
+	}
+
+	for _, token := range []string{"", "bm90IGEgY3Vyc29y", EncodeGeniusOddsCursor(GeniusOddsCursor{Type: "market", MatchID: 1}),
+		EncodeGeniusOddsCursor(GeniusOddsCursor{Type: "upcoming", CounterDateTime: "tomorrow"})} {
+		if _, err := DecodeGeniusOddsCursor(token); err == nil {
+			t.Errorf("token %q accepted", token)
+		}
+	}
+}
+
+func TestPageGeniusOddsMatchesUpcoming(t *testing.T) {
+
+	// NRL (sequence 2) sorts after AFL (sequence 1) at the same kick off, the match id breaks the remaining ties
+	objMatches := []GeniusSportsMatch{
+		testKickOffMatch(5, 1, "2024-05-02", "19:40:00"),
+		testKickOffMatch(4, 7, "2024-05-01", "19:40:00"),
+		testKickOffMatch(3, 1, "2024-05-01", "19:40:00"),
+		testKickOffMatch(2, 1, "2024-05-01", "19:40:00"),
+		testKickOffMatch(1, 7, "2024-05-01", "16:05:00"),
+	}
+
+	var pages [][]int64
+	var cursor *GeniusOddsCursor
+	for {
+		objPage, token := PageGeniusOddsMatches(append([]GeniusSportsMatch{}, objMatches...), "upcoming", 2, cursor)
+		var ids []int64
+		for _, objmatch := range objPage {
+			ids = append(ids, objmatch.MatchID.Int64)
+		}
+		pages = append(pages, ids)
+		if token == "" {
+			break
+		}
+		var err error
+		cursor, err = DecodeGeniusOddsCursor(token)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if len(pages) != 3 || len(pages[0]) != 2 || pages[0][0] != 1 || pages[0][1] != 2 || pages[1][0] != 3 || pages[1][1] != 4 ||
+		len(pages[2]) != 1 || pages[2][0] != 5 {
+		t.Errorf("pages = %v", pages)
+	}
+}
+
+func TestPageGeniusOddsMatchesBest(t *testing.T) {
+
+	// best is ordered by the gap between the home and away price, matches without prices first
+	objMatches := []GeniusSportsMatch{
+		testBestMatch(1, 1, testFloat(1.20), testFloat(4.50)),
+		testBestMatch(2, 7, testFloat(1.90), testFloat(1.90)),
+		testBestMatch(3, 1, nil, testFloat(1.90)),
+		testBestMatch(4, 1, testFloat(1.85), testFloat(1.95)),
+	}
+
+	objPage, token := PageGeniusOddsMatches(objMatches, "best", 2, nil)
+	if len(objPage) != 2 || objPage[0].MatchID.Int64 != 3 || objPage[1].MatchID.Int64 != 2 || token == "" {
+		t.Fatalf("first page = %v %q", objPage, token)
+	}
+
+	cursor, err := DecodeGeniusOddsCursor(token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	objPage, token = PageGeniusOddsMatches(objMatches, "best", 2, cursor)
+	if len(objPage) != 2 || objPage[0].MatchID.Int64 != 4 || objPage[1].MatchID.Int64 != 1 || token != "" {
+		t.Errorf("second page = %v %q", objPage, token)
+	}
+}
+
+func TestMakingGeniusLiveMarketOddsSortPlayers(t *testing.T) {
+
+	objLiveOdds := []GeniusOddsMarket{
+		testPlayerMarket("1", "Anytime Try Scorer", 7, "Player Seven", 3.50),
+		testPlayerMarket("2", "Anytime Try Scorer", 7, "Player Seven", 3.80),
+		testPlayerMarket("1", "Anytime Try Scorer", 9, "Player Nine", 1.90),
+		testPlayerMarket("1", "First Try Scorer", 9, "Player Nine", 6.00),
+	}
+
+	objMarkets := MakingGeniusLiveMarketOddsSort(objLiveOdds, 7, "market")
+	if len(objMarkets) != 2 || objMarkets[0].GroupName != "Player" || objMarkets[1].CategoryName != "First Try Scorer" {
+		t.Fatalf("markets = %+v", objMarkets)
+	}
+
+	// the selections of a player stay together, best price first
+	objOdds := objMarkets[0].AnyMarketOdds
+	if len(objOdds) != 3 || objOdds[0].PlayerName != "Player Seven" || *objOdds[0].NewOdds != 3.80 || objOdds[2].PlayerInfo.PlayerInternalID != 9 {
+		t.Errorf("anytime try scorer = %+v", objOdds)
+	}
+}
+
+func TestFGSSortPlayers(t *testing.T) {
+
+	objOptions := []MarketOddsList{
+		{MarketType: "Player Seven", ProviderList: []OddsInfo{{NewOdds: testFloat(3.80)}}},
+		{MarketType: "Player Nine", ProviderList: []OddsInfo{{NewOdds: testFloat(1.90)}}},
+		{MarketType: "Player Eight", ProviderList: []OddsInfo{{NewOdds: testFloat(3.80)}}},
+	}
+
+	// shortest price first, the name breaks the ties
+	sort.Sort(FGSSort(objOptions))
+	if objOptions[0].MarketType != "Player Nine" || objOptions[1].MarketType != "Player Eight" || objOptions[2].MarketType != "Player Seven" {
+		t.Errorf("order = %+v", objOptions)
+	}
+}
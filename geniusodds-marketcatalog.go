@@ -0,0 +1,70 @@
+package sports
+
+import (
+	"data"
+	"fmt"
+	"net/http"
+	"strconv"
+	"util"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/thegeniusgroup/isgdatalib"
+)
+
+// GeniusOddsMarketCatalog : every market currently priced for a league or a match with its category, group, display names and providers.
+// GET  /geniusodds/catalog/{:sport}/{:league}
+// GET  /geniusodds/catalog/{:sport}/{:league}/{:matchid}
+func GeniusOddsMarketCatalog(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+
+	sportname := util.CleanText(p.ByName("sport"), true, true)
+	leaguename := util.CleanText(p.ByName("league"), true, true)
+	matchid := util.CleanText(p.ByName("matchid"), true, true)
+
+	objsport, err := data.GetSport(sportname)
+	if err != nil {
+		util.WebResponse(w, r, http.StatusNotFound, "sport not found")
+		return
+	}
+
+	if !data.IsGeniusOddsSport(objsport.SportInternalID) {
+		util.WebResponse(w, r, http.StatusNotFound, "sport not supported")
+		return
+	}
+
+	objleague, err := data.GetLeagueID(objsport, leaguename)
+	if err != nil {
+		util.WebResponse(w, r, http.StatusNotFound, "league not found")
+		return
+	}
+
+	var matchID int
+	if matchid != "" {
+		matchID, err = strconv.Atoi(matchid)
+		if err != nil {
+			util.WebResponse(w, r, http.StatusNotFound, "invalid id")
+			return
+		}
+	}
+
+	records, err := data.GetGeniusOddsMarketCatalog(objsport, objleague.LeagueInternalID, matchID)
+	if err != nil {
+		fmt.Println(err.Error())
+		util.WebResponse(w, r, http.StatusNotFound, "record not found")
+		return
+	} else if len(records) == 0 {
+		util.WebResponse(w, r, http.StatusNotFound, "record not found")
+		return
+	}
+
+	var t isg.GeniusOddsCatalog
+	t.SportID = objsport.SportAPICode
+	t.SportName = objsport.SportName
+	t.SportURL = objsport.SportURL
+	t.LeagueURL = objleague.LeagueEntityKey
+	t.MatchID = int64(matchID)
+	t.Groups = isg.BindingGeniusOddsMarketCatalog(records)
+
+	final := util.JSONMessageWrappedObj(http.StatusOK, t)
+	util.WebResponseJSONObjectNoCache(w, r, http.StatusOK, final)
+	return
+}
@@ -0,0 +1,55 @@
+package sports
+
+import (
+	"data"
+	"fmt"
+
+	"github.com/thegeniusgroup/isgdatalib"
+)
+
+// setGeniusOddsContent : loads the previews and tips asked for by ?include= for the matches of the page, one query per league
+func setGeniusOddsContent(objMatches []isg.GeniusSportsMatch, include []string) {
+
+	withPreview := isg.CheckValueInArray(include, isg.GeniusOddsIncludePreview)
+	withTips := isg.CheckValueInArray(include, isg.GeniusOddsIncludeTips)
+	if !withPreview && !withTips {
+		return
+	}
+
+	leagueMatches := map[[2]int][]int64{}
+	leagueInfo := map[[2]int]isg.GeniusSportsMatch{}
+	for _, objmatch := range objMatches {
+		key := [2]int{objmatch.SportInfo.SportInternalID, objmatch.LeagueInfo.LeagueInternalID}
+		leagueMatches[key] = append(leagueMatches[key], objmatch.MatchID.Int64)
+		leagueInfo[key] = objmatch
+	}
+
+	previews := map[[2]int]map[int64]string{}
+	tips := map[[2]int]map[int64][]isg.Tips{}
+	for key, matchIDs := range leagueMatches {
+
+		objsport, objleague := leagueInfo[key].SportInfo, leagueInfo[key].LeagueInfo
+
+		if withPreview {
+			leaguePreviews, err := data.GetGeniusOddsMatchPreviews(objsport, objleague, matchIDs)
+			if err != nil {
+				fmt.Println(err.Error())
+			}
+			previews[key] = leaguePreviews
+		}
+
+		if withTips {
+			leagueTips, err := data.GetGeniusOddsMatchTips(objsport, objleague, matchIDs)
+			if err != nil {
+				fmt.Println(err.Error())
+			}
+			tips[key] = leagueTips
+		}
+	}
+
+	for i, objmatch := range objMatches {
+		key := [2]int{objmatch.SportInfo.SportInternalID, objmatch.LeagueInfo.LeagueInternalID}
+		objMatches[i].PreviewInfo = previews[key][objmatch.MatchID.Int64]
+		objMatches[i].TipsInfo = tips[key][objmatch.MatchID.Int64]
+	}
+}
@@ -0,0 +1,159 @@
+package sports
+
+import (
+	"data"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"util"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/thegeniusgroup/isgdatalib"
+)
+
+// GeniusOddsMarketByID : markets page of a match id. Match ids are per sport, ?sport= picks the sport when the id is
+// found in more than one of them.
+// GET  /geniusodds/match/{:matchid}
+func GeniusOddsMarketByID(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+
+	matchID, err := strconv.Atoi(util.CleanText(p.ByName("matchid"), true, true))
+	if err != nil || matchID <= 0 {
+		util.WebResponse(w, r, http.StatusBadRequest, "invalid id")
+		return
+	}
+
+	objOptions, err := getGeniusOddsOptions(r)
+	if err != nil {
+		util.WebResponse(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	objFilter, err := getGeniusOddsFilter(r)
+	if err != nil {
+		util.WebResponse(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var sportIDs []int
+	for _, objSportLookup := range data.GeniusOddsSports() {
+		sportIDs = append(sportIDs, objSportLookup.SportInternalID)
+	}
+	sportname := util.CleanText(r.URL.Query().Get("sport"), true, true)
+	if sportname != "" {
+		objsport, err := data.GetSport(sportname)
+		if err != nil {
+			util.WebResponse(w, r, http.StatusNotFound, "sport not found")
+			return
+		}
+		if !data.IsGeniusOddsSport(objsport.SportInternalID) {
+			util.WebResponse(w, r, http.StatusNotFound, "sport not supported")
+			return
+		}
+		sportIDs = []int{objsport.SportInternalID}
+	}
+
+	var objsport isg.Sport
+	var leagueID, found int
+	for _, sportID := range sportIDs {
+
+		objSportLookup, err := data.GetSport(strconv.Itoa(sportID))
+		if err != nil {
+			continue
+		}
+
+		matchLeagueID, err := data.GetGeniusOddsMatchLeague(objSportLookup, matchID)
+		if err != nil {
+			fmt.Println(err.Error())
+			continue
+		}
+		if matchLeagueID == 0 {
+			continue
+		}
+		objsport, leagueID = objSportLookup, matchLeagueID
+		found++
+	}
+
+	if found == 0 {
+		util.WebResponse(w, r, http.StatusNotFound, "match not found")
+		return
+	}
+	if found > 1 {
+		util.WebResponse(w, r, http.StatusBadRequest, "match id found in more than one sport, pass ?sport=")
+		return
+	}
+
+	objleague, err := getGeniusOddsLeague(objsport, leagueID)
+	if err != nil {
+		util.WebResponse(w, r, http.StatusNotFound, "league not found")
+		return
+	}
+
+	writeGeniusOddsMarketMatch(w, r, objsport, objleague, matchID, objOptions, objFilter)
+	return
+}
+
+// GeniusOddsMarketByEvent : markets page of a partner event, :provider is the partner id of the event mapping
+// GET  /geniusodds/event/{:provider}/{:eventid}
+func GeniusOddsMarketByEvent(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+
+	providerID, err := strconv.Atoi(util.CleanText(p.ByName("provider"), true, true))
+	if err != nil || providerID <= 0 {
+		util.WebResponse(w, r, http.StatusBadRequest, "invalid provider")
+		return
+	}
+
+	eventID := util.CleanText(p.ByName("eventid"), true, true)
+	if eventID == "" {
+		util.WebResponse(w, r, http.StatusBadRequest, "invalid id")
+		return
+	}
+
+	objOptions, err := getGeniusOddsOptions(r)
+	if err != nil {
+		util.WebResponse(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	objFilter, err := getGeniusOddsFilter(r)
+	if err != nil {
+		util.WebResponse(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	matchID, sportID, leagueID, err := data.GetMatchIDFromEventID(providerID, eventID, 0)
+	if err != nil {
+		util.WebResponse(w, r, http.StatusNotFound, "event not found")
+		return
+	}
+
+	if !data.IsGeniusOddsSport(sportID) {
+		util.WebResponse(w, r, http.StatusNotFound, "sport not supported")
+		return
+	}
+
+	objsport, err := data.GetSport(strconv.Itoa(sportID))
+	if err != nil {
+		util.WebResponse(w, r, http.StatusNotFound, "sport not found")
+		return
+	}
+
+	objleague, err := getGeniusOddsLeague(objsport, leagueID)
+	if err != nil {
+		util.WebResponse(w, r, http.StatusNotFound, "league not found")
+		return
+	}
+
+	writeGeniusOddsMarketMatch(w, r, objsport, objleague, matchID, objOptions, objFilter)
+	return
+}
+
+// getGeniusOddsLeague : cached league of the sport by its internal id
+func getGeniusOddsLeague(objsport isg.Sport, leagueID int) (isg.League, error) {
+	for _, objLeague := range data.SportsLeagues[strconv.Itoa(objsport.SportInternalID)] {
+		if objLeague.LeagueInternalID == leagueID {
+			return objLeague, nil
+		}
+	}
+	return isg.League{}, errors.New("No matching league found")
+}
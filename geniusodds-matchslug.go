@@ -0,0 +1,75 @@
+package isg
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// gameSuffix : -g2 marks the second game of a same day doubleheader, -2 is the older form of it
+var gameSuffix = regexp.MustCompile(`-(g[0-9]+|2)$`)
+
+// GeniusOddsMatchSlug : the round and team segments of the markets route resolved to the home and away team slugs.
+// Date is the -MM-DD suffix of a date round e.g. jan-05, Game is the game of a same day doubleheader starting at 1.
+type GeniusOddsMatchSlug struct {
+	Round    string
+	Date     string
+	HomeTeam string
+	AwayTeam string
+	Game     int
+}
+
+// GeniusOddsAwayFirst : sports whose slugs list the away team first (NFL, NBA, MLB and NHL)
+func GeniusOddsAwayFirst(sportID, leagueID int) bool {
+	return sportID == 2 || (sportID == 3 && leagueID == 1) || sportID == 8 || sportID == 9
+}
+
+// ParseGeniusOddsMatchSlug : reads the round / date, the team order of the sport and the doubleheader game of the slug
+func ParseGeniusOddsMatchSlug(sportID, leagueID int, round, team1, team2 string) (GeniusOddsMatchSlug, error) {
+
+	var objSlug GeniusOddsMatchSlug
+	objSlug.Round = round
+	objSlug.Game = 1
+
+	// dates are month-day e.g. jan-05, anything else is a round / week name
+	date, err := time.Parse("Jan-2", round)
+	if err == nil {
+		objSlug.Date = date.Format("-01-02")
+	}
+
+	if suffix := gameSuffix.FindString(team2); suffix != "" {
+		game := suffix[1:]
+		if game[0] == 'g' {
+			game = game[1:]
+		}
+		objSlug.Game, err = strconv.Atoi(game)
+		if err != nil || objSlug.Game < 1 {
+			return objSlug, errors.New(ISGErrBadInputPrefix + "invalid game " + suffix[1:])
+		}
+		team2 = team2[:len(team2)-len(suffix)]
+	}
+
+	if team1 == "" || team2 == "" {
+		return objSlug, errors.New(ISGErrBadInputPrefix + "teams not found")
+	}
+
+	if GeniusOddsAwayFirst(sportID, leagueID) {
+		objSlug.HomeTeam, objSlug.AwayTeam = team2, team1
+	} else {
+		objSlug.HomeTeam, objSlug.AwayTeam = team1, team2
+	}
+
+	return objSlug, nil
+}
+
+// SelectGeniusOddsMatchGame : match of the slug's game from the matches of the pairing in kick off order
+func SelectGeniusOddsMatchGame(matchIDs []int, game int) (int, error) {
+	if len(matchIDs) == 0 {
+		return 0, errors.New("match not found")
+	}
+	if game > len(matchIDs) {
+		return 0, errors.New("game " + strconv.Itoa(game) + " not found, " + strconv.Itoa(len(matchIDs)) + " game(s) scheduled")
+	}
+	return matchIDs[game-1], nil
+}
@@ -0,0 +1,43 @@
+package isg
+
+import "testing"
+
+func TestParseGeniusOddsMatchSlug(t *testing.T) {
+
+	tests := []struct {
+		sportID, leagueID int
+		round, team1      string
+		team2             string
+		want              GeniusOddsMatchSlug
+	}{
+		{1, 1, "round-5", "carlton", "collingwood", GeniusOddsMatchSlug{Round: "round-5", HomeTeam: "carlton", AwayTeam: "collingwood", Game: 1}},
+		{9, 1, "jan-05", "yankees", "red-sox-g2", GeniusOddsMatchSlug{Round: "jan-05", Date: "-01-05", HomeTeam: "red-sox", AwayTeam: "yankees", Game: 2}},
+		{9, 1, "jun-5", "yankees", "red-sox-2", GeniusOddsMatchSlug{Round: "jun-5", Date: "-06-05", HomeTeam: "red-sox", AwayTeam: "yankees", Game: 2}},
+		{3, 2, "week-1", "home", "away", GeniusOddsMatchSlug{Round: "week-1", HomeTeam: "home", AwayTeam: "away", Game: 1}},
+	}
+	for _, tt := range tests {
+		objSlug, err := ParseGeniusOddsMatchSlug(tt.sportID, tt.leagueID, tt.round, tt.team1, tt.team2)
+		if err != nil || objSlug != tt.want {
+			t.Errorf("%s/%s/%s = %+v, %v", tt.round, tt.team1, tt.team2, objSlug, err)
+		}
+	}
+
+	for _, team2 := range []string{"-g0", ""} {
+		if _, err := ParseGeniusOddsMatchSlug(1, 1, "round-5", "carlton", team2); err == nil {
+			t.Errorf("team %q accepted", team2)
+		}
+	}
+}
+
+func TestSelectGeniusOddsMatchGame(t *testing.T) {
+
+	if matchID, err := SelectGeniusOddsMatchGame([]int{11, 12}, 2); err != nil || matchID != 12 {
+		t.Errorf("game 2 = %d, %v", matchID, err)
+	}
+	if _, err := SelectGeniusOddsMatchGame([]int{11}, 2); err == nil {
+		t.Error("game 2 of a single game found")
+	}
+	if _, err := SelectGeniusOddsMatchGame(nil, 1); err == nil {
+		t.Error("game of no matches found")
+	}
+}
@@ -0,0 +1,520 @@
+package isg
+
+import (
+	"math"
+	"strings"
+)
+
+// Genius odds pricing models
+const (
+	GeniusOddsModelElo     = "elo"
+	GeniusOddsModelPoisson = "poisson"
+)
+
+// GeniusOddsH2HMarketIDs : isg api ids of the head to head selections the model H2H prices are compared with
+var GeniusOddsH2HMarketIDs = []string{"win", "loss", "draw"}
+
+// GeniusOddsModelParams : Elo K factor and home advantage (Elo points).
+// MarginSD / TotalSD are the starting spreads of the margin and total, they are learnt from the results afterwards.
+type GeniusOddsModelParams struct {
+	K             float64
+	HomeAdvantage float64
+	MarginSD      float64
+	TotalSD       float64
+	Poisson       bool
+	MaxGoals      int
+}
+
+// GeniusOddsModelSports : model settings of the sports which are priced
+var GeniusOddsModelSports = map[int]GeniusOddsModelParams{
+	1:  {K: 30, HomeAdvantage: 35, MarginSD: 36, TotalSD: 27},   // AFL
+	4:  {K: 20, HomeAdvantage: 60, Poisson: true, MaxGoals: 10}, // Soccer
+	7:  {K: 30, HomeAdvantage: 40, MarginSD: 14, TotalSD: 12},   // NRL
+	8:  {K: 12, HomeAdvantage: 30, Poisson: true, MaxGoals: 12}, // Hockey
+	10: {K: 30, HomeAdvantage: 45, MarginSD: 14, TotalSD: 13},   // Rugby Union
+}
+
+// Genius odds model rating defaults
+const (
+	geniusOddsModelBaseElo    = 1500
+	geniusOddsModelSeasonKeep = 0.67
+	geniusOddsModelFormAlpha  = 0.1
+	geniusOddsModelSpreadRate = 0.02
+)
+
+// GeniusOddsModelResult : a finished match of the sport's matches table, in kick off order
+type GeniusOddsModelResult struct {
+	MatchID    int64
+	SeasonID   int64
+	HomeTeamID int64
+	AwayTeamID int64
+	HomeScore  int
+	AwayScore  int
+	MatchDate  string
+}
+
+// GeniusOddsTeamRating : Elo rating of a team with the running average of its scores for and against
+type GeniusOddsTeamRating struct {
+	Elo      float64
+	Scored   float64
+	Conceded float64
+	Played   int
+	SeasonID int64
+}
+
+// GeniusOddsModel : team ratings of a sport built from its results
+type GeniusOddsModel struct {
+	SportID      int
+	Params       GeniusOddsModelParams
+	Ratings      map[int64]*GeniusOddsTeamRating
+	AvgHomeScore float64
+	AvgAwayScore float64
+	MarginSD     float64
+	TotalSD      float64
+	Matches      int
+}
+
+// GeniusOddsModelMatch : model expectation of one match, prices any line or total offered by the providers
+type GeniusOddsModelMatch struct {
+	Model         string
+	HomeRating    float64
+	AwayRating    float64
+	HomeExpected  float64
+	AwayExpected  float64
+	HomeWinProb   float64
+	DrawProb      float64
+	AwayWinProb   float64
+	MarginSD      float64
+	TotalSD       float64
+	ScoreMatrix   [][]float64
+	expectedDiff  float64
+	expectedTotal float64
+}
+
+// GeniusOddsModelPrices : model fair prices of a match with the edge of the best provider price
+type GeniusOddsModelPrices struct {
+	Model        string                 `json:"model"`
+	HomeRating   float64                `json:"home_rating"`
+	AwayRating   float64                `json:"away_rating"`
+	HomeExpected float64                `json:"home_expected_score"`
+	AwayExpected float64                `json:"away_expected_score"`
+	Markets      []GeniusOddsModelPrice `json:"markets,omitempty"`
+}
+
+// GeniusOddsModelPrice : Edge is the expected return in percent of the best provider price at the model probability
+type GeniusOddsModelPrice struct {
+	MarketName   string   `json:"market_name"`
+	Selection    string   `json:"selection"`
+	Line         *float64 `json:"line,omitempty"`
+	ModelProb    float64  `json:"model_prob"`
+	FairPrice    *float64 `json:"fair_price,omitempty"`
+	FairPriceFmt string   `json:"fair_price_fmt,omitempty"`
+	BestPrice    *float64 `json:"best_price,omitempty"`
+	BestProvider string   `json:"best_provider,omitempty"`
+	Edge         *float64 `json:"edge,omitempty"`
+}
+
+// BuildGeniusOddsModel : plays the results in order, updating the Elo ratings, the team scoring form and the spreads of the margin and total.
+// Ratings are pulled back to the base rating when a team starts a new season.
+func BuildGeniusOddsModel(sportID int, results []GeniusOddsModelResult) *GeniusOddsModel {
+
+	params, ok := GeniusOddsModelSports[sportID]
+	if !ok {
+		return nil
+	}
+
+	objModel := &GeniusOddsModel{
+		SportID:  sportID,
+		Params:   params,
+		Ratings:  map[int64]*GeniusOddsTeamRating{},
+		MarginSD: params.MarginSD,
+		TotalSD:  params.TotalSD,
+	}
+
+	var sumHome, sumAway float64
+	for _, result := range results {
+
+		if objModel.Matches > 0 {
+			objModel.AvgHomeScore = sumHome / float64(objModel.Matches)
+			objModel.AvgAwayScore = sumAway / float64(objModel.Matches)
+		} else {
+			objModel.AvgHomeScore = float64(result.HomeScore)
+			objModel.AvgAwayScore = float64(result.AwayScore)
+		}
+
+		home := objModel.getTeamRating(result.HomeTeamID, result.SeasonID)
+		away := objModel.getTeamRating(result.AwayTeamID, result.SeasonID)
+
+		margin := float64(result.HomeScore - result.AwayScore)
+		total := float64(result.HomeScore + result.AwayScore)
+
+		// spreads are learnt before the ratings move so they measure the pre-match error
+		if !params.Poisson && home.Played > 0 && away.Played > 0 {
+			objMatch := objModel.MatchModel(result.HomeTeamID, result.AwayTeamID)
+			objModel.MarginSD = updateGeniusOddsSpread(objModel.MarginSD, margin-objMatch.expectedDiff)
+			objModel.TotalSD = updateGeniusOddsSpread(objModel.TotalSD, total-objMatch.expectedTotal)
+		}
+
+		expected := eloWinProb(home.Elo + params.HomeAdvantage - away.Elo)
+		actual := 0.5
+		if margin > 0 {
+			actual = 1
+		} else if margin < 0 {
+			actual = 0
+		}
+
+		// bigger wins move the ratings further, measured in margin spreads
+		k := params.K
+		if !params.Poisson {
+			k = params.K * (1 + math.Log1p(math.Abs(margin)/objModel.MarginSD)) / 2
+		}
+		home.Elo += k * (actual - expected)
+		away.Elo -= k * (actual - expected)
+
+		home.Scored += geniusOddsModelFormAlpha * (float64(result.HomeScore) - home.Scored)
+		home.Conceded += geniusOddsModelFormAlpha * (float64(result.AwayScore) - home.Conceded)
+		away.Scored += geniusOddsModelFormAlpha * (float64(result.AwayScore) - away.Scored)
+		away.Conceded += geniusOddsModelFormAlpha * (float64(result.HomeScore) - away.Conceded)
+		home.Played++
+		away.Played++
+
+		sumHome += float64(result.HomeScore)
+		sumAway += float64(result.AwayScore)
+		objModel.Matches++
+	}
+
+	if objModel.Matches > 0 {
+		objModel.AvgHomeScore = sumHome / float64(objModel.Matches)
+		objModel.AvgAwayScore = sumAway / float64(objModel.Matches)
+	}
+
+	return objModel
+}
+
+// getTeamRating : rating of a team, new teams start at the base rating with the league average scores
+func (objModel *GeniusOddsModel) getTeamRating(teamID, seasonID int64) *GeniusOddsTeamRating {
+
+	avgScore := (objModel.AvgHomeScore + objModel.AvgAwayScore) / 2
+
+	rating, ok := objModel.Ratings[teamID]
+	if !ok {
+		rating = &GeniusOddsTeamRating{Elo: geniusOddsModelBaseElo, Scored: avgScore, Conceded: avgScore, SeasonID: seasonID}
+		objModel.Ratings[teamID] = rating
+	} else if seasonID != 0 && rating.SeasonID != seasonID {
+		rating.Elo = geniusOddsModelBaseElo + (rating.Elo-geniusOddsModelBaseElo)*geniusOddsModelSeasonKeep
+		rating.SeasonID = seasonID
+	}
+	return rating
+}
+
+// MatchModel : expected scores and result probabilities of a match, Poisson scoring for soccer and hockey, Elo margins otherwise.
+// Both models share the Elo rating gap with the home advantage and the expected total of the teams' scoring form.
+func (objModel *GeniusOddsModel) MatchModel(homeTeamID, awayTeamID int64) *GeniusOddsModelMatch {
+
+	if objModel == nil || objModel.Matches == 0 {
+		return nil
+	}
+
+	avgScore := (objModel.AvgHomeScore + objModel.AvgAwayScore) / 2
+	newTeam := GeniusOddsTeamRating{Elo: geniusOddsModelBaseElo, Scored: avgScore, Conceded: avgScore}
+
+	home, away := &newTeam, &newTeam
+	if rating, ok := objModel.Ratings[homeTeamID]; ok {
+		home = rating
+	}
+	if rating, ok := objModel.Ratings[awayTeamID]; ok {
+		away = rating
+	}
+
+	objMatch := &GeniusOddsModelMatch{
+		HomeRating: Round(home.Elo, .5, 1),
+		AwayRating: Round(away.Elo, .5, 1),
+		MarginSD:   objModel.MarginSD,
+		TotalSD:    objModel.TotalSD,
+	}
+	eloDiff := home.Elo + objModel.Params.HomeAdvantage - away.Elo
+
+	// both models expect the same total from the scoring form of the teams
+	objMatch.expectedTotal = (home.Scored + away.Conceded + away.Scored + home.Conceded) / 2
+
+	if objModel.Params.Poisson {
+		objMatch.Model = GeniusOddsModelPoisson
+
+		// the Elo expectation with the home advantage splits the expected goals
+		share := eloWinProb(eloDiff)
+		homeExpected := objMatch.expectedTotal * share
+		awayExpected := objMatch.expectedTotal * (1 - share)
+		objMatch.HomeExpected = Round(homeExpected, .5, 2)
+		objMatch.AwayExpected = Round(awayExpected, .5, 2)
+		objMatch.ScoreMatrix = poissonScoreMatrix(homeExpected, awayExpected, objModel.Params.MaxGoals)
+
+		for h, row := range objMatch.ScoreMatrix {
+			for a, prob := range row {
+				if h > a {
+					objMatch.HomeWinProb += prob
+				} else if h == a {
+					objMatch.DrawProb += prob
+				} else {
+					objMatch.AwayWinProb += prob
+				}
+			}
+		}
+		objMatch.expectedDiff = homeExpected - awayExpected
+		return objMatch
+	}
+
+	objMatch.Model = GeniusOddsModelElo
+	objMatch.HomeWinProb = eloWinProb(eloDiff)
+	objMatch.AwayWinProb = 1 - objMatch.HomeWinProb
+
+	// expected margin which gives the Elo win probability with the margin spread, so the H2H and Line prices agree
+	winProb := math.Min(math.Max(objMatch.HomeWinProb, 0.001), 0.999)
+	objMatch.expectedDiff = objModel.MarginSD * math.Sqrt2 * math.Erfinv(2*winProb-1)
+	objMatch.HomeExpected = Round((objMatch.expectedTotal+objMatch.expectedDiff)/2, .5, 1)
+	objMatch.AwayExpected = Round((objMatch.expectedTotal-objMatch.expectedDiff)/2, .5, 1)
+
+	return objMatch
+}
+
+// CoverProb : probability of the home (or away) team covering its handicap line, pushes are left out
+func (objMatch *GeniusOddsModelMatch) CoverProb(line float64, isHome bool) float64 {
+
+	if objMatch.ScoreMatrix != nil {
+		var win, push float64
+		for h, row := range objMatch.ScoreMatrix {
+			for a, prob := range row {
+				margin := float64(h - a)
+				if !isHome {
+					margin = -margin
+				}
+				if margin+line > 0 {
+					win += prob
+				} else if margin+line == 0 {
+					push += prob
+				}
+			}
+		}
+		return withoutPush(win, push)
+	}
+
+	if isHome {
+		return normalCDF((objMatch.expectedDiff + line) / objMatch.MarginSD)
+	}
+	return normalCDF((line - objMatch.expectedDiff) / objMatch.MarginSD)
+}
+
+// OverProb : probability of the match total going over the closing total, pushes are left out
+func (objMatch *GeniusOddsModelMatch) OverProb(total float64) float64 {
+
+	if objMatch.ScoreMatrix != nil {
+		var over, push float64
+		for h, row := range objMatch.ScoreMatrix {
+			for a, prob := range row {
+				if float64(h+a) > total {
+					over += prob
+				} else if float64(h+a) == total {
+					push += prob
+				}
+			}
+		}
+		return withoutPush(over, push)
+	}
+
+	return 1 - normalCDF((total-objMatch.expectedTotal)/objMatch.TotalSD)
+}
+
+// bindingGeniusOddsModel : model fair prices for H2H, Line and Total with the edge of the best provider price of each selection.
+// Lines and totals are priced at the line of the best provider so the edge compares like with like.
+func bindingGeniusOddsModel(objMatch *GeniusOddsModelMatch, intMarkets []IntMarketInfo, oddsFormat string) *GeniusOddsModelPrices {
+
+	if objMatch == nil {
+		return nil
+	}
+
+	objPrices := &GeniusOddsModelPrices{
+		Model:        objMatch.Model,
+		HomeRating:   objMatch.HomeRating,
+		AwayRating:   objMatch.AwayRating,
+		HomeExpected: objMatch.HomeExpected,
+		AwayExpected: objMatch.AwayExpected,
+	}
+
+	var h2hMarket, lineMarket, totalMarket *IntMarketInfo
+	for i := range intMarkets {
+		if strings.Contains(intMarkets[i].CategoryName, "Line") {
+			lineMarket = &intMarkets[i]
+		} else if strings.Contains(intMarkets[i].CategoryName, "Total") {
+			totalMarket = &intMarkets[i]
+		} else if h2hMarket == nil && isGeniusOddsH2HMarket(intMarkets[i]) {
+			h2hMarket = &intMarkets[i]
+		}
+	}
+
+	// H2H
+	var homeOdds, awayOdds, drawOdds *OddsInfo
+	if h2hMarket != nil {
+		homeOdds = bestGeniusOddsPrice(h2hMarket.HomeMarketOdds)
+		awayOdds = bestGeniusOddsPrice(h2hMarket.AwayMarketOdds)
+		drawOdds = bestGeniusOddsPrice(h2hMarket.AnyMarketOdds)
+	}
+	objPrices.Markets = append(objPrices.Markets, getGeniusOddsModelPrice("H2H", "home", nil, objMatch.HomeWinProb, homeOdds, oddsFormat))
+	if objMatch.Model == GeniusOddsModelPoisson {
+		objPrices.Markets = append(objPrices.Markets, getGeniusOddsModelPrice("H2H", "draw", nil, objMatch.DrawProb, drawOdds, oddsFormat))
+	}
+	objPrices.Markets = append(objPrices.Markets, getGeniusOddsModelPrice("H2H", "away", nil, objMatch.AwayWinProb, awayOdds, oddsFormat))
+
+	// Line
+	if lineMarket != nil {
+		if objOdds := bestGeniusOddsLine(lineMarket.HomeMarketOdds, ""); objOdds != nil && objOdds.NewLine != nil {
+			objPrices.Markets = append(objPrices.Markets, getGeniusOddsModelPrice("Line", "home", objOdds.NewLine, objMatch.CoverProb(*objOdds.NewLine, true), objOdds, oddsFormat))
+		}
+		if objOdds := bestGeniusOddsLine(lineMarket.AwayMarketOdds, ""); objOdds != nil && objOdds.NewLine != nil {
+			objPrices.Markets = append(objPrices.Markets, getGeniusOddsModelPrice("Line", "away", objOdds.NewLine, objMatch.CoverProb(*objOdds.NewLine, false), objOdds, oddsFormat))
+		}
+	}
+
+	// Total
+	if totalMarket != nil {
+		if objOdds := bestGeniusOddsLine(totalMarket.AnyMarketOdds, "Over"); objOdds != nil && objOdds.NewTotal != nil {
+			objPrices.Markets = append(objPrices.Markets, getGeniusOddsModelPrice("Total", "over", objOdds.NewTotal, objMatch.OverProb(*objOdds.NewTotal), objOdds, oddsFormat))
+		}
+		if objOdds := bestGeniusOddsLine(totalMarket.AnyMarketOdds, "Under"); objOdds != nil && objOdds.NewTotal != nil {
+			objPrices.Markets = append(objPrices.Markets, getGeniusOddsModelPrice("Total", "under", objOdds.NewTotal, 1-objMatch.OverProb(*objOdds.NewTotal), objOdds, oddsFormat))
+		}
+	}
+
+	return objPrices
+}
+
+// isGeniusOddsH2HMarket : true when the market category holds the head to head win, loss or draw selections
+func isGeniusOddsH2HMarket(intMarket IntMarketInfo) bool {
+	for _, objOdds := range [][]OddsInfo{intMarket.HomeMarketOdds, intMarket.AwayMarketOdds, intMarket.AnyMarketOdds} {
+		for _, objOdd := range objOdds {
+			if CheckValueInArray(GeniusOddsH2HMarketIDs, objOdd.ISGapiID) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// bestGeniusOddsPrice : highest provider price of the first market of the list, the H2H lists can hold more than one market
+func bestGeniusOddsPrice(objOdds []OddsInfo) *OddsInfo {
+
+	var best *OddsInfo
+	for i := range objOdds {
+		if objOdds[i].NewOdds == nil || objOdds[i].MarketInternalID != objOdds[0].MarketInternalID {
+			continue
+		}
+		if best == nil || *objOdds[i].NewOdds > *best.NewOdds {
+			best = &objOdds[i]
+		}
+	}
+	return best
+}
+
+// bestGeniusOddsLine : the lists are already sorted by sortingForOdds, so the first line or total is the best one.
+// Returns the highest provider price at that line, marketName limits it to the Over or Under selections.
+func bestGeniusOddsLine(objOdds []OddsInfo, marketName string) *OddsInfo {
+
+	var best *OddsInfo
+	for i := range objOdds {
+		line := getGeniusOddsLine(objOdds[i])
+		if objOdds[i].NewOdds == nil || line == nil || (marketName != "" && objOdds[i].MarketName != marketName) {
+			continue
+		}
+		if best == nil {
+			best = &objOdds[i]
+		} else if *line == *getGeniusOddsLine(*best) && *objOdds[i].NewOdds > *best.NewOdds {
+			best = &objOdds[i]
+		}
+	}
+	return best
+}
+
+// getGeniusOddsLine : handicap line or closing total of a provider price
+func getGeniusOddsLine(objOdds OddsInfo) *float64 {
+	if objOdds.NewLine != nil {
+		return objOdds.NewLine
+	}
+	return objOdds.NewTotal
+}
+
+// getGeniusOddsModelPrice :
+func getGeniusOddsModelPrice(marketName, selection string, line *float64, prob float64, objOdds *OddsInfo, oddsFormat string) GeniusOddsModelPrice {
+
+	var objPrice GeniusOddsModelPrice
+	objPrice.MarketName = marketName
+	objPrice.Selection = selection
+	objPrice.Line = line
+	objPrice.ModelProb = Round(prob, .5, 4)
+
+	if prob > 0 {
+		fairPrice := Round(1/prob, .5, 2)
+		objPrice.FairPrice = &fairPrice
+		if oddsFormat != "" && oddsFormat != OddsFormatDecimal {
+			objPrice.FairPriceFmt = ConvertOdds(&fairPrice, oddsFormat)
+		}
+	}
+
+	if objOdds != nil && objOdds.NewOdds != nil {
+		objPrice.BestPrice = objOdds.NewOdds
+		objPrice.BestProvider = objOdds.Name
+		if prob > 0 {
+			edge := Round((*objOdds.NewOdds*prob-1)*100, .5, 2)
+			objPrice.Edge = &edge
+		}
+	}
+
+	return objPrice
+}
+
+// poissonScoreMatrix : independent Poisson probabilities of every home / away score up to maxGoals
+func poissonScoreMatrix(homeExpected, awayExpected float64, maxGoals int) [][]float64 {
+
+	homeProbs := poissonProbs(homeExpected, maxGoals)
+	awayProbs := poissonProbs(awayExpected, maxGoals)
+
+	matrix := make([][]float64, maxGoals+1)
+	for h := range matrix {
+		matrix[h] = make([]float64, maxGoals+1)
+		for a := range matrix[h] {
+			matrix[h][a] = homeProbs[h] * awayProbs[a]
+		}
+	}
+	return matrix
+}
+
+// poissonProbs :
+func poissonProbs(lambda float64, maxGoals int) []float64 {
+
+	probs := make([]float64, maxGoals+1)
+	probs[0] = math.Exp(-lambda)
+	for k := 1; k <= maxGoals; k++ {
+		probs[k] = probs[k-1] * lambda / float64(k)
+	}
+	return probs
+}
+
+// eloWinProb : expected result of a team with the Elo rating gap
+func eloWinProb(eloDiff float64) float64 {
+	return 1 / (1 + math.Pow(10, -eloDiff/400))
+}
+
+// normalCDF :
+func normalCDF(z float64) float64 {
+	return 0.5 * (1 + math.Erf(z/math.Sqrt2))
+}
+
+// updateGeniusOddsSpread : running standard deviation of the model error
+func updateGeniusOddsSpread(spread, diff float64) float64 {
+	return math.Sqrt(spread*spread + geniusOddsModelSpreadRate*(diff*diff-spread*spread))
+}
+
+// withoutPush :
+func withoutPush(win, push float64) float64 {
+	if push >= 1 {
+		return 0
+	}
+	return win / (1 - push)
+}
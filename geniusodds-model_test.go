@@ -0,0 +1,126 @@
+package isg
+
+import (
+	"math"
+	"testing"
+)
+
+func TestMatchModelProbabilities(t *testing.T) {
+
+	for _, sportID := range []int{1, 4, 7, 8, 10} {
+		objMatch := testModel(sportID, 1550, 1500, 1.5).MatchModel(1, 2)
+		if objMatch == nil {
+			t.Fatalf("sport %d: no model", sportID)
+		}
+
+		sum := objMatch.HomeWinProb + objMatch.DrawProb + objMatch.AwayWinProb
+		tolerance := 0.0001
+		if objMatch.ScoreMatrix != nil {
+			// scores above MaxGoals are left out of the matrix
+			tolerance = 0.001
+		}
+		if math.Abs(sum-1) > tolerance {
+			t.Errorf("sport %d: result probabilities sum to %v", sportID, sum)
+		}
+	}
+}
+
+func TestMatchModelEloAndHomeAdvantage(t *testing.T) {
+
+	for _, sportID := range []int{1, 4, 8} {
+
+		even := testModel(sportID, 1500, 1500, 2).MatchModel(1, 2)
+		stronger := testModel(sportID, 1600, 1500, 2).MatchModel(1, 2)
+		if stronger.HomeWinProb <= even.HomeWinProb {
+			t.Errorf("sport %d: higher Elo home win %v, want above %v", sportID, stronger.HomeWinProb, even.HomeWinProb)
+		}
+
+		objModel := testModel(sportID, 1500, 1500, 2)
+		objModel.Params.HomeAdvantage = 0
+		neutral := objModel.MatchModel(1, 2)
+		if even.HomeWinProb <= neutral.HomeWinProb {
+			t.Errorf("sport %d: home advantage home win %v, want above %v", sportID, even.HomeWinProb, neutral.HomeWinProb)
+		}
+		if math.Abs(neutral.HomeWinProb-neutral.AwayWinProb) > 0.0001 {
+			t.Errorf("sport %d: even teams on neutral ground %v v %v", sportID, neutral.HomeWinProb, neutral.AwayWinProb)
+		}
+	}
+}
+
+func TestMatchModelPoissonExpected(t *testing.T) {
+
+	objMatch := testModel(4, 1600, 1500, 1.5).MatchModel(1, 2)
+
+	// the expected goals keep the total of the scoring form and are split by the Elo expectation
+	if math.Abs(objMatch.HomeExpected+objMatch.AwayExpected-3) > 0.01 {
+		t.Errorf("expected goals %v + %v, want a total of 3", objMatch.HomeExpected, objMatch.AwayExpected)
+	}
+	share := eloWinProb(1600 + GeniusOddsModelSports[4].HomeAdvantage - 1500)
+	if want := Round(3*share, .5, 2); objMatch.HomeExpected != want {
+		t.Errorf("home expected %v, want %v", objMatch.HomeExpected, want)
+	}
+}
+
+func TestMatchModelEloCover(t *testing.T) {
+
+	objMatch := testModel(1, 1600, 1500, 90).MatchModel(1, 2)
+
+	// the line at the expected margin is even money, so the H2H and Line prices agree
+	if prob := objMatch.CoverProb(-objMatch.expectedDiff, true); math.Abs(prob-0.5) > 0.0001 {
+		t.Errorf("cover prob at the expected margin %v, want 0.5", prob)
+	}
+	if prob := objMatch.CoverProb(0, true); math.Abs(prob-objMatch.HomeWinProb) > 0.001 {
+		t.Errorf("cover prob at 0 %v, want the home win %v", prob, objMatch.HomeWinProb)
+	}
+}
+
+func TestBuildGeniusOddsModel(t *testing.T) {
+
+	if BuildGeniusOddsModel(6, nil) != nil {
+		t.Error("tennis is not modelled")
+	}
+
+	var results []GeniusOddsModelResult
+	for i := 0; i < 10; i++ {
+		results = append(results, testResult(int64(i), 1, 2, 2, 0))
+	}
+
+	objModel := BuildGeniusOddsModel(4, results)
+	if objModel.Matches != 10 || objModel.AvgHomeScore != 2 || objModel.AvgAwayScore != 0 {
+		t.Errorf("matches %d, averages %v v %v", objModel.Matches, objModel.AvgHomeScore, objModel.AvgAwayScore)
+	}
+	if objModel.Ratings[1].Elo <= objModel.Ratings[2].Elo {
+		t.Errorf("winner rated %v, loser %v", objModel.Ratings[1].Elo, objModel.Ratings[2].Elo)
+	}
+}
+
+func TestBindingGeniusOddsModelMarkets(t *testing.T) {
+
+	objMatch := testModel(1, 1550, 1500, 80).MatchModel(1, 2)
+
+	intMarkets := []IntMarketInfo{
+		// a category ahead of the head to head one with no H2H selections
+		{CategoryName: "Half Time", HomeMarketOdds: []OddsInfo{{Name: "P1", NewOdds: testFloat(1.50), ISGapiID: "ht_win", MarketInternalID: 5}}},
+		{CategoryName: "Head to Head", HomeMarketOdds: []OddsInfo{{Name: "P1", NewOdds: testFloat(1.80), ISGapiID: "win", MarketInternalID: 1}},
+			AwayMarketOdds: []OddsInfo{{Name: "P2", NewOdds: testFloat(2.10), ISGapiID: "win", MarketInternalID: 1}}},
+		// a line price without a handicap line
+		{CategoryName: "Line", HomeMarketOdds: []OddsInfo{{Name: "P1", NewOdds: testFloat(1.90), NewTotal: testFloat(160.5)}},
+			AwayMarketOdds: []OddsInfo{{Name: "P1", NewOdds: testFloat(1.90), NewLine: testFloat(6.5)}}},
+	}
+
+	objPrices := bindingGeniusOddsModel(objMatch, intMarkets, "")
+	prices := map[string]GeniusOddsModelPrice{}
+	for _, objPrice := range objPrices.Markets {
+		prices[objPrice.MarketName+" "+objPrice.Selection] = objPrice
+	}
+
+	if home := prices["H2H home"]; home.BestPrice == nil || *home.BestPrice != 1.80 || home.BestProvider != "P1" {
+		t.Errorf("H2H home = %+v", home)
+	}
+	if _, ok := prices["Line home"]; ok {
+		t.Errorf("home line priced without a line")
+	}
+	if away := prices["Line away"]; away.Line == nil || *away.Line != 6.5 {
+		t.Errorf("Line away = %+v", away)
+	}
+}
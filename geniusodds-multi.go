@@ -0,0 +1,223 @@
+package isg
+
+import (
+	"math"
+	"strconv"
+)
+
+// GeniusOddsMultiMaxLegs : legs accepted by a single multi
+const GeniusOddsMultiMaxLegs = 20
+
+// GeniusOddsMultiRequest : body of POST /geniusodds/multi, Provider prices the whole multi with one provider
+type GeniusOddsMultiRequest struct {
+	Provider string               `json:"provider,omitempty"`
+	Legs     []GeniusOddsMultiLeg `json:"legs"`
+}
+
+// GeniusOddsMultiLeg : a selection of the multi. Market is the isg api market id (win, draw, cover, over, under ...),
+// Selection is home / away for the team markets. Provider and Line are optional, the best price is used without them.
+type GeniusOddsMultiLeg struct {
+	Sport     string   `json:"sport"`
+	MatchID   int64    `json:"match_id"`
+	Market    string   `json:"market"`
+	Selection string   `json:"selection,omitempty"`
+	Provider  string   `json:"provider,omitempty"`
+	Line      *float64 `json:"line,omitempty"`
+	SportID   int      `json:"-"`
+}
+
+// GeniusOddsMultiLegPrice : priced leg, FairProb is the provider's probability with its market margin removed
+type GeniusOddsMultiLegPrice struct {
+	Sport     string   `json:"sport"`
+	MatchID   int64    `json:"match_id"`
+	Market    string   `json:"market"`
+	Selection string   `json:"selection,omitempty"`
+	Line      *float64 `json:"line,omitempty"`
+	Price     *float64 `json:"price,omitempty"`
+	PriceFmt  string   `json:"price_fmt,omitempty"`
+	Name      string   `json:"name,omitempty"`
+	Icon      string   `json:"icon,omitempty"`
+	FairProb  float64  `json:"fair_prob,omitempty"`
+	Margin    *float64 `json:"margin,omitempty"`
+	SameMatch []int    `json:"same_match,omitempty"`
+	Error     string   `json:"error,omitempty"`
+}
+
+// GeniusOddsMulti : combined price of the multi. Combinable is false when a leg is not available or two legs are of the
+// same match, the price is left out then. Margin is the effective margin in percent.
+type GeniusOddsMulti struct {
+	Provider   string                    `json:"provider,omitempty"`
+	Legs       []GeniusOddsMultiLegPrice `json:"legs"`
+	Combinable bool                      `json:"combinable"`
+	Price      *float64                  `json:"price,omitempty"`
+	PriceFmt   string                    `json:"price_fmt,omitempty"`
+	FairPrice  *float64                  `json:"fair_price,omitempty"`
+	Margin     *float64                  `json:"margin,omitempty"`
+	OddsFormat string                    `json:"odds_format,omitempty"`
+}
+
+// PriceGeniusOddsMulti : prices each leg from the live provider prices of its sport and combines them.
+// Legs of the same match are flagged and not combined, there is no same game model to price them together.
+func PriceGeniusOddsMulti(objRequest GeniusOddsMultiRequest, records map[int][]GeniusOddsMarket, oddsFormat string) GeniusOddsMulti {
+
+	var objMulti GeniusOddsMulti
+	objMulti.Combinable = true
+	if oddsFormat != OddsFormatDecimal {
+		objMulti.OddsFormat = oddsFormat
+	}
+
+	for _, leg := range objRequest.Legs {
+
+		provider := leg.Provider
+		if objRequest.Provider != "" {
+			provider = objRequest.Provider
+		}
+
+		objLeg := priceGeniusOddsMultiLeg(leg, records[leg.SportID], provider)
+		if objLeg.Error != "" {
+			objMulti.Combinable = false
+		} else if objRequest.Provider != "" {
+			objMulti.Provider = objLeg.Name
+		}
+		if oddsFormat != OddsFormatDecimal {
+			objLeg.PriceFmt = ConvertOdds(objLeg.Price, oddsFormat)
+		}
+		objMulti.Legs = append(objMulti.Legs, objLeg)
+	}
+
+	// same match legs
+	for i := range objRequest.Legs {
+		for j := range objRequest.Legs {
+			if i == j || objRequest.Legs[i].SportID != objRequest.Legs[j].SportID || objRequest.Legs[i].MatchID != objRequest.Legs[j].MatchID {
+				continue
+			}
+			objMulti.Legs[i].SameMatch = append(objMulti.Legs[i].SameMatch, j)
+			if i < j && objMulti.Legs[j].Error == "" {
+				objMulti.Legs[j].Error = "same match as leg " + strconv.Itoa(i) + ", same game multis are not priced"
+				objMulti.Combinable = false
+			}
+		}
+	}
+
+	if !objMulti.Combinable || len(objMulti.Legs) == 0 {
+		return objMulti
+	}
+
+	price, fairProb := 1.0, 1.0
+	for _, objLeg := range objMulti.Legs {
+		price *= *objLeg.Price
+		fairProb *= objLeg.FairProb
+	}
+
+	price = Round(price, .5, 2)
+	objMulti.Price = &price
+	if oddsFormat != OddsFormatDecimal {
+		objMulti.PriceFmt = ConvertOdds(&price, oddsFormat)
+	}
+	if fairProb > 0 {
+		fairPrice := Round(1/fairProb, .5, 2)
+		margin := Round((1/(price*fairProb)-1)*100, .5, 2)
+		objMulti.FairPrice = &fairPrice
+		objMulti.Margin = &margin
+	}
+
+	return objMulti
+}
+
+// priceGeniusOddsMultiLeg : highest live price of the selection, limited to the provider and line when they are given
+func priceGeniusOddsMultiLeg(leg GeniusOddsMultiLeg, records []GeniusOddsMarket, provider string) GeniusOddsMultiLegPrice {
+
+	var objLeg GeniusOddsMultiLegPrice
+	objLeg.Sport = leg.Sport
+	objLeg.MatchID = leg.MatchID
+	objLeg.Market = leg.Market
+	objLeg.Selection = leg.Selection
+
+	bookSum := map[string]float64{}
+	for _, record := range records {
+		if record.MatchID == leg.MatchID && record.MarketPrice != nil && *record.MarketPrice > 1 {
+			bookSum[multiBookKey(record)] += 1 / *record.MarketPrice
+		}
+	}
+
+	var best *GeniusOddsMarket
+	for i, record := range records {
+
+		if record.MatchID != leg.MatchID || record.ISGapiID != leg.Market || record.MarketPrice == nil || *record.MarketPrice <= 1 {
+			continue
+		}
+		if provider != "" && record.ProviderInfo.URL != provider {
+			continue
+		}
+		if leg.Line != nil && (record.MarketVal == nil || *record.MarketVal != *leg.Line) {
+			continue
+		}
+
+		switch leg.Selection {
+		case "home":
+			if record.MarketTeamID != record.HomeTeamID {
+				continue
+			}
+		case "away":
+			if record.MarketTeamID != record.AwayTeamID {
+				continue
+			}
+		default:
+			if record.MarketTeamID != 0 {
+				continue
+			}
+		}
+
+		if best == nil || *record.MarketPrice > *best.MarketPrice ||
+			(*record.MarketPrice == *best.MarketPrice && record.ProviderInfo.GeniusOddsSequence < best.ProviderInfo.GeniusOddsSequence) {
+			best = &records[i]
+		}
+	}
+
+	if best == nil {
+		if provider != "" {
+			objLeg.Error = "market not available with " + provider
+		} else {
+			objLeg.Error = "market not available"
+		}
+		return objLeg
+	}
+
+	objLeg.Line = best.MarketVal
+	objLeg.Price = best.MarketPrice
+	objLeg.Name = best.ProviderInfo.Name
+	objLeg.Icon = best.ProviderInfo.Icon
+
+	objLeg.FairProb = 1 / *best.MarketPrice
+	if sum := bookSum[multiBookKey(*best)]; sum > 1 {
+		objLeg.FairProb = objLeg.FairProb / sum
+		margin := Round((sum-1)*100, .5, 2)
+		objLeg.Margin = &margin
+	}
+	objLeg.FairProb = Round(objLeg.FairProb, .5, 4)
+
+	return objLeg
+}
+
+// multiBookKey : groups the selections of one bookmaker market of a match, lines and totals are grouped per value
+func multiBookKey(record GeniusOddsMarket) string {
+
+	key := strconv.Itoa(int(record.MatchID)) + "-" + record.ProviderInfo.ProviderId + "-" + geniusOddsMultiMarketGroup(record.ISGapiID)
+	if record.MarketVal != nil {
+		key += "-" + strconv.FormatFloat(math.Abs(*record.MarketVal), 'f', 1, 64)
+	}
+	return key
+}
+
+// geniusOddsMultiMarketGroup : selections which make up one book, e.g. the win and draw of one match
+func geniusOddsMultiMarketGroup(market string) string {
+	switch market {
+	case "win", "loss", "draw":
+		return "h2h"
+	case "over", "under":
+		return "total"
+	case "btts_yes", "btts_no":
+		return "btts"
+	}
+	return market
+}
@@ -0,0 +1,98 @@
+package isg
+
+import "testing"
+
+// testMultiMarket : a live price of the match with its isg api market id, home team 10 v away team 20
+func testMultiMarket(matchID int64, providerID, isgAPIID, category string, teamID int64, price float64, val *float64) GeniusOddsMarket {
+	objMarket := testMarket(providerID, 1, teamID, category, price, val)
+	objMarket.MatchID = matchID
+	objMarket.ISGapiID = isgAPIID
+	objMarket.ProviderInfo.URL = "p" + providerID
+	return objMarket
+}
+
+// testMultiRecords : H2H books of matches 1 and 2 from providers 1 and 2 and a line book of match 1 from provider 1
+func testMultiRecords() map[int][]GeniusOddsMarket {
+	return map[int][]GeniusOddsMarket{7: {
+		testMultiMarket(1, "1", "win", "Head to Head", 10, 1.80, nil),
+		testMultiMarket(1, "1", "win", "Head to Head", 20, 2.00, nil),
+		testMultiMarket(1, "2", "win", "Head to Head", 10, 1.70, nil),
+		testMultiMarket(1, "2", "win", "Head to Head", 20, 2.10, nil),
+		testMultiMarket(1, "1", "cover", "Line", 10, 1.90, testFloat(-4.5)),
+		testMultiMarket(1, "1", "cover", "Line", 20, 1.90, testFloat(4.5)),
+		testMultiMarket(2, "1", "win", "Head to Head", 10, 1.50, nil),
+		testMultiMarket(2, "1", "win", "Head to Head", 20, 2.50, nil),
+	}}
+}
+
+func TestPriceGeniusOddsMulti(t *testing.T) {
+
+	objRequest := GeniusOddsMultiRequest{Legs: []GeniusOddsMultiLeg{
+		{Sport: "rl", SportID: 7, MatchID: 1, Market: "win", Selection: "home"},
+		{Sport: "rl", SportID: 7, MatchID: 2, Market: "win", Selection: "away"},
+	}}
+
+	objMulti := PriceGeniusOddsMulti(objRequest, testMultiRecords(), OddsFormatDecimal)
+	if !objMulti.Combinable || objMulti.Price == nil || *objMulti.Price != 4.5 {
+		t.Fatalf("multi = %+v", objMulti)
+	}
+
+	// the home leg is provider 1 at 1.80, its book is 1/1.80 + 1/2.00 of provider 1 only
+	home := objMulti.Legs[0]
+	if home.Name != "P1" || home.Margin == nil || *home.Margin != 5.56 || home.FairProb != 0.5263 {
+		t.Errorf("home leg = %+v", home)
+	}
+	if objMulti.FairPrice == nil || objMulti.Margin == nil || *objMulti.Margin <= 0 {
+		t.Errorf("fair price = %v margin = %v", objMulti.FairPrice, objMulti.Margin)
+	}
+}
+
+func TestPriceGeniusOddsMultiRejected(t *testing.T) {
+
+	records := testMultiRecords()
+
+	// legs of the same match, even of different markets
+	objMulti := PriceGeniusOddsMulti(GeniusOddsMultiRequest{Legs: []GeniusOddsMultiLeg{
+		{SportID: 7, MatchID: 1, Market: "win", Selection: "home"},
+		{SportID: 7, MatchID: 1, Market: "cover", Selection: "away"},
+	}}, records, OddsFormatDecimal)
+	if objMulti.Combinable || objMulti.Price != nil || objMulti.Legs[0].Error != "" || objMulti.Legs[1].Error == "" ||
+		len(objMulti.Legs[0].SameMatch) != 1 {
+		t.Errorf("same match = %+v", objMulti)
+	}
+
+	// a provider without the market
+	objMulti = PriceGeniusOddsMulti(GeniusOddsMultiRequest{Provider: "p2", Legs: []GeniusOddsMultiLeg{
+		{SportID: 7, MatchID: 1, Market: "win", Selection: "home"},
+		{SportID: 7, MatchID: 2, Market: "win", Selection: "home"},
+	}}, records, OddsFormatDecimal)
+	if objMulti.Combinable || objMulti.Price != nil || objMulti.Legs[1].Error != "market not available with p2" {
+		t.Errorf("provider = %+v", objMulti)
+	}
+
+	// a line that is not offered
+	objMulti = PriceGeniusOddsMulti(GeniusOddsMultiRequest{Legs: []GeniusOddsMultiLeg{
+		{SportID: 7, MatchID: 1, Market: "cover", Selection: "home", Line: testFloat(-6.5)},
+	}}, records, OddsFormatDecimal)
+	if objMulti.Combinable || objMulti.Legs[0].Error == "" {
+		t.Errorf("line = %+v", objMulti)
+	}
+}
+
+func TestMultiBookKey(t *testing.T) {
+
+	// one book per bookmaker market, the category name does not split it
+	win := testMultiMarket(1, "1", "win", "Head to Head", 10, 1.80, nil)
+	draw := testMultiMarket(1, "1", "draw", "Match Result", 0, 15.00, nil)
+	other := testMultiMarket(1, "2", "win", "Head to Head", 10, 1.70, nil)
+	if multiBookKey(win) != multiBookKey(draw) || multiBookKey(win) == multiBookKey(other) {
+		t.Errorf("keys = %s %s %s", multiBookKey(win), multiBookKey(draw), multiBookKey(other))
+	}
+
+	home := testMultiMarket(1, "1", "cover", "Line", 10, 1.90, testFloat(-4.5))
+	away := testMultiMarket(1, "1", "cover", "Line Alt", 20, 1.90, testFloat(4.5))
+	alt := testMultiMarket(1, "1", "cover", "Line", 10, 2.40, testFloat(-9.5))
+	if multiBookKey(home) != multiBookKey(away) || multiBookKey(home) == multiBookKey(alt) {
+		t.Errorf("line keys = %s %s %s", multiBookKey(home), multiBookKey(away), multiBookKey(alt))
+	}
+}
@@ -0,0 +1,179 @@
+package sports
+
+import (
+	"crypto/sha256"
+	"data"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+	"util"
+
+	"github.com/thegeniusgroup/isgdatalib"
+)
+
+// geniusOddsSnapshotFolder : storage folder of the snapshots, S3PutJSONItem adds the _dev/ prefix outside production
+const geniusOddsSnapshotFolder = "geniusodds"
+
+// geniusOddsSnapshotIndex : manifest listing every published snapshot with its content hash
+const geniusOddsSnapshotIndex = "index.json"
+
+// StartGeniusOddsPublisher : publishes the genius odds snapshots and repeats after every interval
+func StartGeniusOddsPublisher(bucket string, interval time.Duration) {
+	for {
+		PublishGeniusOddsSnapshots(bucket)
+		time.Sleep(interval)
+	}
+}
+
+// PublishGeniusOddsSnapshots : renders the listing and markets payloads and writes the changed ones to storage
+func PublishGeniusOddsSnapshots(bucket string) {
+
+	prevManifest := getGeniusOddsSnapshotManifest(bucket)
+	prevItems := map[string]isg.GeniusOddsSnapshotManifestItem{}
+	for _, item := range prevManifest.Snapshots {
+		prevItems[item.Key] = item
+	}
+
+	var manifest isg.GeniusOddsSnapshotManifest
+	changed := false
+
+	for _, typeVal := range []string{"upcoming", "best", "plunge"} {
+		for _, objsport := range data.GeniusOddsSports() {
+
+			objLeagues := data.SportsLeagues[strconv.Itoa(objsport.SportInternalID)]
+
+			for _, objLeague := range objLeagues {
+
+				objMatch, err := getGeniusOddsMatches([]isg.Sport{objsport}, objsport, objLeague, objLeague.LeagueEntityKey, typeVal, "", isg.GeniusOddsWindow{Limit: isg.GeniusOddsPageLimit})
+				if err != nil || len(objMatch) == 0 {
+					// nothing to list, the key drops out of the manifest
+					continue
+				}
+				objMatch, _ = isg.PageGeniusOddsMatches(objMatch, typeVal, isg.GeniusOddsPageLimit, nil)
+				setGeniusOddsDetails(objMatch)
+
+				objMatch[0].TypeVal = typeVal
+				sort.Sort(isg.GeniusSortMatchesISG(objMatch))
+				objMatch = isg.GroupGeniusOddsTournaments(objMatch)
+
+				var item isg.GeniusOddsSnapshotManifestItem
+				item.Key = "matches/" + typeVal + "/" + objsport.SportURL + "/" + objLeague.LeagueEntityKey + ".json"
+				item.Type = typeVal
+				item.SportURL = objsport.SportURL
+				item.LeagueURL = objLeague.LeagueEntityKey
+				t := isg.BindingGeniusOddsMatches(objMatch, typeVal, isg.GeniusOddsOptions{})
+
+				item, written := publishGeniusOddsSnapshot(bucket, item, t, prevItems)
+				if item.Key != "" {
+					manifest.Snapshots = append(manifest.Snapshots, item)
+				}
+				changed = changed || written
+
+				if typeVal != "upcoming" {
+					continue
+				}
+
+				// per match markets page for every upcoming match
+				for _, match := range objMatch {
+
+					matchID := int(match.MatchID.Int64)
+					objMarketMatch, plungeMatches, err := getGeniusOddsMarketMatch(objsport, objLeague, matchID, "market", isg.GeniusOddsFilter{})
+					if err != nil {
+						fmt.Println(err.Error())
+						continue
+					}
+					if len(objMarketMatch) == 0 {
+						continue
+					}
+
+					item = isg.GeniusOddsSnapshotManifestItem{}
+					item.Key = "markets/" + objsport.SportURL + "/" + objLeague.LeagueEntityKey + "/" + strconv.Itoa(matchID) + ".json"
+					item.Type = "market"
+					item.SportURL = objsport.SportURL
+					item.LeagueURL = objLeague.LeagueEntityKey
+					item.MatchID = matchID
+					t = isg.BindingGeniusOddsMarketMatches(objMarketMatch, "market", plungeMatches, isg.GeniusOddsOptions{})
+
+					item, written = publishGeniusOddsSnapshot(bucket, item, t, prevItems)
+					if item.Key != "" {
+						manifest.Snapshots = append(manifest.Snapshots, item)
+					}
+					changed = changed || written
+				}
+			}
+		}
+	}
+
+	if !changed && len(manifest.Snapshots) == len(prevManifest.Snapshots) {
+		fmt.Println("Genius odds snapshots unchanged.")
+		return
+	}
+
+	manifest.GeneratedAt = time.Now().In(data.AEST).Format("2006-01-02 15:04:05")
+	body, err := json.Marshal(manifest)
+	if err != nil {
+		fmt.Println(err.Error())
+		return
+	}
+
+	err = data.S3PutJSONItem(bucket, geniusOddsSnapshotFolder, geniusOddsSnapshotIndex, body)
+	if err != nil {
+		return
+	}
+
+	fmt.Println("Genius odds snapshots published:", len(manifest.Snapshots))
+}
+
+// publishGeniusOddsSnapshot : writes one payload unless its hash matches the previous manifest entry
+func publishGeniusOddsSnapshot(bucket string, item isg.GeniusOddsSnapshotManifestItem, t isg.GeniusOddsSportMatch, prevItems map[string]isg.GeniusOddsSnapshotManifestItem) (isg.GeniusOddsSnapshotManifestItem, bool) {
+
+	final := util.JSONMessageWrappedObj(http.StatusOK, t)
+	body, err := json.Marshal(final)
+	if err != nil {
+		fmt.Println(err.Error())
+		return prevItems[item.Key], false
+	}
+
+	sum := sha256.Sum256(body)
+	hash := hex.EncodeToString(sum[:])
+
+	prevItem, ok := prevItems[item.Key]
+	if ok && prevItem.Hash == hash {
+		return prevItem, false
+	}
+
+	err = data.S3PutJSONItem(bucket, geniusOddsSnapshotFolder, item.Key, body)
+	if err != nil {
+		// keep the last good entry so the next run retries the write
+		return prevItem, false
+	}
+
+	item.Hash = hash
+	item.Size = len(body)
+	item.UpdatedAt = time.Now().In(data.AEST).Format("2006-01-02 15:04:05")
+
+	return item, true
+}
+
+// getGeniusOddsSnapshotManifest : reads the last published manifest, empty on the first run
+func getGeniusOddsSnapshotManifest(bucket string) isg.GeniusOddsSnapshotManifest {
+
+	var manifest isg.GeniusOddsSnapshotManifest
+
+	body, err := data.S3GetItem(bucket, geniusOddsSnapshotFolder, geniusOddsSnapshotIndex)
+	if err != nil {
+		return manifest
+	}
+
+	err = json.Unmarshal(body, &manifest)
+	if err != nil {
+		fmt.Println(err.Error())
+		return isg.GeniusOddsSnapshotManifest{}
+	}
+
+	return manifest
+}
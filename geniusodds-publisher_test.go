@@ -0,0 +1,31 @@
+package sports
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"util"
+
+	"github.com/thegeniusgroup/isgdatalib"
+)
+
+func TestPublishGeniusOddsSnapshotUnchanged(t *testing.T) {
+
+	var objSportMatch isg.GeniusOddsSportMatch
+	body, err := json.Marshal(util.JSONMessageWrappedObj(http.StatusOK, objSportMatch))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sum := sha256.Sum256(body)
+
+	prevItem := isg.GeniusOddsSnapshotManifestItem{Key: "matches/upcoming/afl/afl.json", Hash: hex.EncodeToString(sum[:]), UpdatedAt: "2024-05-01 12:00:00"}
+	prevItems := map[string]isg.GeniusOddsSnapshotManifestItem{prevItem.Key: prevItem}
+
+	// the same payload keeps the previous entry and isn't written again
+	item, written := publishGeniusOddsSnapshot("bucket", isg.GeniusOddsSnapshotManifestItem{Key: prevItem.Key}, objSportMatch, prevItems)
+	if written || item != prevItem {
+		t.Errorf("publishGeniusOddsSnapshot = %+v, %v, want the previous entry unwritten", item, written)
+	}
+}
@@ -0,0 +1,183 @@
+package sports
+
+import (
+	"data"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+	"util"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/thegeniusgroup/isgdatalib"
+)
+
+// geniusOddsQualityLogTable : log db table of the price issues
+const geniusOddsQualityLogTable = "isg_geniusodds_quality_log"
+
+// Genius odds quality alert ids of the log table
+const (
+	qualityAlertStale = iota + 1
+	qualityAlertOutlier
+	qualityAlertInvalidPrice
+	qualityAlertLineMismatch
+)
+
+// geniusOddsQualityAlertIDs :
+var geniusOddsQualityAlertIDs = map[string]int{
+	isg.QualityIssueStale:        qualityAlertStale,
+	isg.QualityIssueOutlier:      qualityAlertOutlier,
+	isg.QualityIssueInvalidPrice: qualityAlertInvalidPrice,
+	isg.QualityIssueLineMismatch: qualityAlertLineMismatch,
+}
+
+// geniusOddsIssues : current issues per sport and league, with the keys of the prices left out of the ranking
+var geniusOddsIssues = map[[2]int][]isg.GeniusOddsQualityIssue{}
+var geniusOddsIssueKeys = map[string]bool{}
+var geniusOddsIssuesLock sync.RWMutex
+
+// StartGeniusOddsQuality : validates the live provider prices and repeats after every interval
+func StartGeniusOddsQuality(interval time.Duration, objConfig isg.GeniusOddsQualityConfig) {
+	for {
+		validateGeniusOddsPrices(objConfig)
+		time.Sleep(interval)
+	}
+}
+
+// validateGeniusOddsPrices : flags the bad prices of every genius odds league, new issues are written to the log db
+func validateGeniusOddsPrices(objConfig isg.GeniusOddsQualityConfig) {
+
+	now := time.Now()
+	current := map[[2]int][]isg.GeniusOddsQualityIssue{}
+
+	for _, objsport := range data.GeniusOddsSports() {
+
+		objLeagues := data.SportsLeagues[strconv.Itoa(objsport.SportInternalID)]
+
+		for _, objLeague := range objLeagues {
+
+			records, err := data.GetGeniusOddsQualityPrices(objsport, objLeague.LeagueInternalID)
+			if err != nil {
+				fmt.Println(err.Error())
+				continue
+			}
+			if len(records) == 0 {
+				continue
+			}
+
+			issues := isg.ValidateGeniusOddsPrices(records, objsport.SportInternalID, objLeague.LeagueInternalID, objConfig, now, data.AEST)
+			if len(issues) == 0 {
+				continue
+			}
+			current[[2]int{objsport.SportInternalID, objLeague.LeagueInternalID}] = issues
+
+			logGeniusOddsIssues(objsport, issues)
+		}
+	}
+
+	keys := map[string]bool{}
+	for _, issues := range current {
+		for _, objIssue := range issues {
+			keys[isg.GeniusOddsQualityKey(objIssue.MatchID, objIssue.MarketID, objIssue.TeamID, objIssue.PlayerID, objIssue.ProviderID)] = true
+		}
+	}
+
+	geniusOddsIssuesLock.Lock()
+	geniusOddsIssues = current
+	geniusOddsIssueKeys = keys
+	geniusOddsIssuesLock.Unlock()
+}
+
+// logGeniusOddsIssues : records the issues not logged by the previous run, with the match details
+func logGeniusOddsIssues(objsport isg.Sport, issues []isg.GeniusOddsQualityIssue) {
+
+	geniusOddsIssuesLock.RLock()
+	previous := geniusOddsIssues[[2]int{issues[0].SportID, issues[0].LeagueID}]
+	geniusOddsIssuesLock.RUnlock()
+
+	logged := map[string]bool{}
+	for _, objIssue := range previous {
+		logged[objIssue.Issue+"-"+isg.GeniusOddsQualityKey(objIssue.MatchID, objIssue.MarketID, objIssue.TeamID, objIssue.PlayerID, objIssue.ProviderID)] = true
+	}
+
+	matchInfo := map[int64]isg.ErrorLogRecord{}
+	for _, objIssue := range issues {
+
+		if logged[objIssue.Issue+"-"+isg.GeniusOddsQualityKey(objIssue.MatchID, objIssue.MarketID, objIssue.TeamID, objIssue.PlayerID, objIssue.ProviderID)] {
+			continue
+		}
+
+		objerror, ok := matchInfo[objIssue.MatchID]
+		if !ok {
+			var err error
+			objerror, err = data.GetMatchInfo(objsport, objIssue.LeagueID, int(objIssue.MatchID))
+			if err != nil {
+				fmt.Println(err.Error())
+			}
+			matchInfo[objIssue.MatchID] = objerror
+		}
+		objerror.ProviderName = objIssue.ProviderName
+
+		data.InsertErrorLog(objerror, objIssue.SportID, geniusOddsQualityAlertIDs[objIssue.Issue], geniusOddsQualityLogTable,
+			objIssue.Issue+": "+objIssue.Market+" "+objIssue.Detail)
+	}
+}
+
+// excludeGeniusOddsIssues : leaves the flagged and invalid prices out before the best prices are sorted
+func excludeGeniusOddsIssues(records []isg.GeniusOddsMarket) []isg.GeniusOddsMarket {
+
+	geniusOddsIssuesLock.RLock()
+	defer geniusOddsIssuesLock.RUnlock()
+
+	return isg.ExcludeGeniusOddsIssues(records, geniusOddsIssueKeys)
+}
+
+// GeniusOddsQualityIssues : admin list of the current provider price issues, admin users only
+// GET  /geniusodds/admin/quality
+// GET  /geniusodds/admin/quality/{:sport}
+func GeniusOddsQualityIssues(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+
+	if !geniusOddsAuthAdmin(w, r) {
+		return
+	}
+
+	sportname := util.CleanText(p.ByName("sport"), true, true)
+
+	var sportID int
+	if sportname != "" {
+		objsport, err := data.GetSport(sportname)
+		if err != nil {
+			util.WebResponse(w, r, http.StatusNotFound, "sport not found")
+			return
+		}
+		sportID = objsport.SportInternalID
+	}
+
+	var issues []isg.GeniusOddsQualityIssue
+
+	geniusOddsIssuesLock.RLock()
+	for key, leagueIssues := range geniusOddsIssues {
+		if sportID == 0 || key[0] == sportID {
+			issues = append(issues, leagueIssues...)
+		}
+	}
+	geniusOddsIssuesLock.RUnlock()
+
+	if len(issues) == 0 {
+		util.WebResponse(w, r, http.StatusNotFound, "record not found")
+		return
+	}
+
+	sort.SliceStable(issues, func(i, j int) bool {
+		if issues[i].MatchID != issues[j].MatchID {
+			return issues[i].MatchID < issues[j].MatchID
+		}
+		return issues[i].MarketID < issues[j].MarketID
+	})
+
+	final := util.JSONMessageWrappedObj(http.StatusOK, issues)
+	util.WebResponseJSONObjectNoCache(w, r, http.StatusOK, final)
+	return
+}
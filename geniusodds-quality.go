@@ -0,0 +1,227 @@
+package isg
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Genius odds price quality issues
+const (
+	QualityIssueStale        = "stale"
+	QualityIssueOutlier      = "outlier"
+	QualityIssueInvalidPrice = "invalid_price"
+	QualityIssueLineMismatch = "line_mismatch"
+)
+
+// GeniusOddsMinPrice : lowest price accepted from a provider
+const GeniusOddsMinPrice = 1.01
+
+// GeniusOddsQualityConfig : StaleAfter is the time a price may go without an update, OutlierPct the distance from the
+// consensus (median) price in percent and MinProviders the providers needed for a consensus
+type GeniusOddsQualityConfig struct {
+	StaleAfter   time.Duration
+	OutlierPct   float64
+	MinProviders int
+}
+
+// DefaultGeniusOddsQualityConfig :
+var DefaultGeniusOddsQualityConfig = GeniusOddsQualityConfig{
+	StaleAfter:   6 * time.Hour,
+	OutlierPct:   30,
+	MinProviders: 3,
+}
+
+// GeniusOddsQualityIssue : a provider price flagged by the validator
+type GeniusOddsQualityIssue struct {
+	Issue        string   `json:"issue"`
+	Detail       string   `json:"detail"`
+	SportID      int      `json:"sport_id"`
+	LeagueID     int      `json:"league_id"`
+	MatchID      int64    `json:"match_id"`
+	MarketID     int64    `json:"market_id"`
+	Market       string   `json:"market"`
+	CategoryName string   `json:"category_name"`
+	TeamID       int64    `json:"team_id,omitempty"`
+	PlayerID     int64    `json:"player_id,omitempty"`
+	ProviderID   string   `json:"provider_id"`
+	ProviderName string   `json:"provider"`
+	Price        *float64 `json:"price,omitempty"`
+	Val          *float64 `json:"line,omitempty"`
+	LastUpdate   string   `json:"last_update,omitempty"`
+}
+
+// GeniusOddsQualityKey : identifies a provider price, the ranking drops prices with an issue under this key
+func GeniusOddsQualityKey(matchID, marketID, teamID, playerID int64, providerID string) string {
+	return strconv.FormatInt(matchID, 10) + "-" + strconv.FormatInt(marketID, 10) + "-" + strconv.FormatInt(teamID, 10) + "-" +
+		strconv.FormatInt(playerID, 10) + "-" + providerID
+}
+
+// IsValidGeniusOddsPrice : negative, missing and sub 1.01 prices are never ranked
+func IsValidGeniusOddsPrice(price *float64) bool {
+	return price != nil && *price >= GeniusOddsMinPrice
+}
+
+// ValidateGeniusOddsPrices : flags stale prices, prices far from the consensus of the other providers, invalid prices and
+// line / total mismatches between the two sides of a provider market. lastUpdates are in the AEST counter format.
+// A mismatch flags both sides, alternate lines are left out as their pairs can't be told apart.
+func ValidateGeniusOddsPrices(records []GeniusOddsMarket, sportID, leagueID int, objConfig GeniusOddsQualityConfig, now time.Time, loc *time.Location) []GeniusOddsQualityIssue {
+
+	var issues []GeniusOddsQualityIssue
+
+	consensus := map[string][]float64{}
+	for _, record := range records {
+		if IsValidGeniusOddsPrice(record.MarketPrice) {
+			key := qualitySelectionKey(record)
+			consensus[key] = append(consensus[key], *record.MarketPrice)
+		}
+	}
+
+	// lines and totals of a provider market by side, the home / away team of a line or the over / under market of a total
+	var markets []string
+	sides := map[string]map[string][]GeniusOddsMarket{}
+	for _, record := range records {
+		if record.MarketVal != nil && (strings.Contains(record.CategoryName, "Line") || strings.Contains(record.CategoryName, "Total")) {
+			key := strconv.FormatInt(record.MatchID, 10) + "-" + record.ProviderInfo.ProviderId + "-" + record.CategoryName + "-" +
+				strconv.FormatInt(record.PlayerID, 10)
+			if _, ok := sides[key]; !ok {
+				sides[key] = map[string][]GeniusOddsMarket{}
+				markets = append(markets, key)
+			}
+			side := strconv.FormatInt(record.MarketID, 10) + "-" + strconv.FormatInt(record.MarketTeamID, 10)
+			sides[key][side] = append(sides[key][side], record)
+		}
+	}
+
+	for _, record := range records {
+
+		if !IsValidGeniusOddsPrice(record.MarketPrice) {
+			issues = append(issues, bindingQualityIssue(record, sportID, leagueID, QualityIssueInvalidPrice, "price "+formatAlertFloat(record.MarketPrice)+" is below "+
+				strconv.FormatFloat(GeniusOddsMinPrice, 'f', 2, 64)))
+			continue
+		}
+
+		if objConfig.StaleAfter > 0 && record.LastUpdate != "" {
+			lastUpdate, err := time.ParseInLocation("2006-01-02 15:04:05", record.LastUpdate, loc)
+			if err == nil && now.Sub(lastUpdate) > objConfig.StaleAfter {
+				issues = append(issues, bindingQualityIssue(record, sportID, leagueID, QualityIssueStale, "not updated for "+now.Sub(lastUpdate).Round(time.Minute).String()))
+			}
+		}
+
+		prices := consensus[qualitySelectionKey(record)]
+		if len(prices) >= objConfig.MinProviders {
+			median := medianPrice(prices)
+			diff := math.Abs(*record.MarketPrice-median) / median * 100
+			if diff > objConfig.OutlierPct {
+				issues = append(issues, bindingQualityIssue(record, sportID, leagueID, QualityIssueOutlier, "price "+formatAlertFloat(record.MarketPrice)+" is "+
+					strconv.FormatFloat(Round(diff, .5, 1), 'f', 1, 64)+"% from the consensus "+strconv.FormatFloat(Round(median, .5, 2), 'f', 2, 64)))
+			}
+		}
+	}
+
+	for _, key := range markets {
+		pair := lineMismatchPair(sides[key])
+		if pair == nil {
+			continue
+		}
+		// home / away lines mirror each other, over / under share the total
+		expected := *pair[0].MarketVal
+		if strings.Contains(pair[0].CategoryName, "Line") {
+			expected = -expected
+		}
+		if *pair[1].MarketVal != expected {
+			issues = append(issues, bindingQualityIssue(pair[0], sportID, leagueID, QualityIssueLineMismatch, pair[0].CategoryName+" "+
+				formatAlertFloat(pair[0].MarketVal)+" does not match "+formatAlertFloat(pair[1].MarketVal)))
+			issues = append(issues, bindingQualityIssue(pair[1], sportID, leagueID, QualityIssueLineMismatch, pair[1].CategoryName+" "+
+				formatAlertFloat(pair[1].MarketVal)+" does not match "+formatAlertFloat(pair[0].MarketVal)))
+		}
+	}
+
+	return issues
+}
+
+// ExcludeGeniusOddsIssues : drops the invalid prices and the prices with an issue before they are sorted for the best price
+func ExcludeGeniusOddsIssues(records []GeniusOddsMarket, issues map[string]bool) []GeniusOddsMarket {
+
+	var valid []GeniusOddsMarket
+	for _, record := range records {
+		if !IsValidGeniusOddsPrice(record.MarketPrice) {
+			continue
+		}
+		if issues[GeniusOddsQualityKey(record.MatchID, record.MarketID, record.MarketTeamID, record.PlayerID, record.ProviderInfo.ProviderId)] {
+			continue
+		}
+		valid = append(valid, record)
+	}
+	return valid
+}
+
+// lineMismatchPair : the two sides of a provider market with one line each, in side order, nil when a side is missing or
+// offers alternate lines
+func lineMismatchPair(sides map[string][]GeniusOddsMarket) []GeniusOddsMarket {
+
+	if len(sides) != 2 {
+		return nil
+	}
+
+	var pair []GeniusOddsMarket
+	for _, side := range sides {
+		if len(side) != 1 {
+			return nil
+		}
+		pair = append(pair, side[0])
+	}
+
+	sort.Slice(pair, func(i, j int) bool {
+		if pair[i].MarketID != pair[j].MarketID {
+			return pair[i].MarketID < pair[j].MarketID
+		}
+		return pair[i].MarketTeamID < pair[j].MarketTeamID
+	})
+	return pair
+}
+
+// qualitySelectionKey : a selection across providers, lines and totals are compared at the same value only
+func qualitySelectionKey(record GeniusOddsMarket) string {
+	key := strconv.FormatInt(record.MatchID, 10) + "-" + strconv.FormatInt(record.MarketID, 10) + "-" + strconv.FormatInt(record.MarketTeamID, 10) +
+		"-" + strconv.FormatInt(record.PlayerID, 10)
+	if record.MarketVal != nil {
+		key += "-" + strconv.FormatFloat(*record.MarketVal, 'f', 1, 64)
+	}
+	return key
+}
+
+// medianPrice :
+func medianPrice(prices []float64) float64 {
+	sorted := append([]float64{}, prices...)
+	sort.Float64s(sorted)
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
+
+// bindingQualityIssue :
+func bindingQualityIssue(record GeniusOddsMarket, sportID, leagueID int, issue, detail string) GeniusOddsQualityIssue {
+
+	var objIssue GeniusOddsQualityIssue
+	objIssue.Issue = issue
+	objIssue.Detail = detail
+	objIssue.SportID = sportID
+	objIssue.LeagueID = leagueID
+	objIssue.MatchID = record.MatchID
+	objIssue.MarketID = record.MarketID
+	objIssue.Market = record.ISGapiID
+	objIssue.CategoryName = record.CategoryName
+	objIssue.TeamID = record.MarketTeamID
+	objIssue.PlayerID = record.PlayerID
+	objIssue.ProviderID = record.ProviderInfo.ProviderId
+	objIssue.ProviderName = record.ProviderInfo.Name
+	objIssue.Price = record.MarketPrice
+	objIssue.Val = record.MarketVal
+	objIssue.LastUpdate = record.LastUpdate
+	return objIssue
+}
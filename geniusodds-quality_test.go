@@ -0,0 +1,99 @@
+package isg
+
+import (
+	"testing"
+	"time"
+)
+
+// qualityIssueCount : issues of a type by provider price key
+func qualityIssueCount(issues []GeniusOddsQualityIssue, issue string) map[string]int {
+	count := map[string]int{}
+	for _, objIssue := range issues {
+		if objIssue.Issue == issue {
+			count[GeniusOddsQualityKey(objIssue.MatchID, objIssue.MarketID, objIssue.TeamID, objIssue.PlayerID, objIssue.ProviderID)]++
+		}
+	}
+	return count
+}
+
+var qualityTestNow = time.Date(2024, 5, 1, 12, 30, 0, 0, time.UTC)
+
+func TestValidateGeniusOddsPricesInvalidAndStale(t *testing.T) {
+
+	records := []GeniusOddsMarket{
+		testMarket("1", 1, 10, "H2H", 1.005, nil),
+		testMarket("2", 1, 10, "H2H", 1.9, nil),
+	}
+	records[1].LastUpdate = "2024-04-30 12:00:00"
+
+	issues := ValidateGeniusOddsPrices(records, 1, 1, DefaultGeniusOddsQualityConfig, qualityTestNow, time.UTC)
+	if len(qualityIssueCount(issues, QualityIssueInvalidPrice)) != 1 || len(qualityIssueCount(issues, QualityIssueStale)) != 1 {
+		t.Errorf("issues = %+v, want one invalid and one stale price", issues)
+	}
+}
+
+func TestValidateGeniusOddsPricesOutlier(t *testing.T) {
+
+	records := []GeniusOddsMarket{
+		testMarket("1", 1, 10, "H2H", 1.9, nil),
+		testMarket("2", 1, 10, "H2H", 1.95, nil),
+		testMarket("3", 1, 10, "H2H", 3.5, nil),
+	}
+
+	issues := qualityIssueCount(ValidateGeniusOddsPrices(records, 1, 1, DefaultGeniusOddsQualityConfig, qualityTestNow, time.UTC), QualityIssueOutlier)
+	if len(issues) != 1 || issues[GeniusOddsQualityKey(1, 1, 10, 0, "3")] != 1 {
+		t.Errorf("outliers = %v, want provider 3 only", issues)
+	}
+}
+
+func TestValidateGeniusOddsPricesLineMismatch(t *testing.T) {
+
+	records := []GeniusOddsMarket{
+		// provider 1 mirrors its line, provider 2 doesn't
+		testMarket("1", 2, 10, "Line", 1.9, testFloat(-6.5)),
+		testMarket("1", 2, 20, "Line", 1.9, testFloat(6.5)),
+		testMarket("2", 2, 10, "Line", 1.9, testFloat(-6.5)),
+		testMarket("2", 2, 20, "Line", 1.9, testFloat(7.5)),
+		// alternate lines of provider 3 are not paired
+		testMarket("3", 2, 10, "Line", 1.9, testFloat(-6.5)),
+		testMarket("3", 2, 10, "Line", 2.5, testFloat(-12.5)),
+		testMarket("3", 2, 20, "Line", 1.9, testFloat(6.5)),
+		// over / under share the total
+		testMarket("1", 3, 0, "Total", 1.9, testFloat(160.5)),
+		testMarket("1", 4, 0, "Total", 1.9, testFloat(160.5)),
+		testMarket("2", 3, 0, "Total", 1.9, testFloat(160.5)),
+		testMarket("2", 4, 0, "Total", 1.9, testFloat(162.5)),
+	}
+
+	issues := qualityIssueCount(ValidateGeniusOddsPrices(records, 1, 1, DefaultGeniusOddsQualityConfig, qualityTestNow, time.UTC), QualityIssueLineMismatch)
+
+	want := []string{
+		GeniusOddsQualityKey(1, 2, 10, 0, "2"),
+		GeniusOddsQualityKey(1, 2, 20, 0, "2"),
+		GeniusOddsQualityKey(1, 3, 0, 0, "2"),
+		GeniusOddsQualityKey(1, 4, 0, 0, "2"),
+	}
+	if len(issues) != len(want) {
+		t.Errorf("line mismatches = %v, want both sides of the provider 2 line and total", issues)
+	}
+	for _, key := range want {
+		if issues[key] != 1 {
+			t.Errorf("line mismatch %s flagged %d times, want 1", key, issues[key])
+		}
+	}
+}
+
+func TestExcludeGeniusOddsIssuesPlayer(t *testing.T) {
+
+	player1 := testMarket("1", 5, 10, "Player", 2, nil)
+	player1.PlayerID = 100
+	player2 := testMarket("1", 5, 10, "Player", 3, nil)
+	player2.PlayerID = 200
+	invalid := testMarket("2", 5, 10, "Player", 1, nil)
+
+	issues := map[string]bool{GeniusOddsQualityKey(1, 5, 10, 100, "1"): true}
+	valid := ExcludeGeniusOddsIssues([]GeniusOddsMarket{player1, player2, invalid}, issues)
+	if len(valid) != 1 || valid[0].PlayerID != 200 {
+		t.Errorf("valid = %+v, want the price of player 200 only", valid)
+	}
+}
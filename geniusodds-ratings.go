@@ -0,0 +1,101 @@
+package sports
+
+import (
+	"data"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/thegeniusgroup/isgdatalib"
+)
+
+// geniusOddsModelLookback : results used to build the model ratings
+const geniusOddsModelLookback = 3 * 365 * 24 * time.Hour
+
+// geniusOddsModels : model ratings per sport and league, rebuilt by StartGeniusOddsModel
+var geniusOddsModels = map[[2]int]*isg.GeniusOddsModel{}
+var geniusOddsModelsLock sync.RWMutex
+
+// StartGeniusOddsModel : rebuilds the Elo / Poisson ratings from the results and repeats after every interval
+func StartGeniusOddsModel(interval time.Duration) {
+	for {
+		buildGeniusOddsModels()
+		time.Sleep(interval)
+	}
+}
+
+// buildGeniusOddsModels : ratings of every league of the modelled sports
+func buildGeniusOddsModels() {
+
+	for _, objsport := range data.GeniusOddsSports() {
+
+		// tennis has no team scores to rate, the model only has the parameters of the team sports
+		if objsport.SportInternalID == 6 {
+			continue
+		}
+		if _, ok := isg.GeniusOddsModelSports[objsport.SportInternalID]; !ok {
+			continue
+		}
+
+		objLeagues := data.SportsLeagues[strconv.Itoa(objsport.SportInternalID)]
+
+		for _, objLeague := range objLeagues {
+
+			results, err := data.GetGeniusOddsModelResults(objsport, objLeague.LeagueInternalID, geniusOddsModelLookback)
+			if err != nil {
+				fmt.Println(err.Error())
+				continue
+			}
+			if len(results) == 0 {
+				continue
+			}
+
+			objModel := isg.BuildGeniusOddsModel(objsport.SportInternalID, results)
+
+			geniusOddsModelsLock.Lock()
+			geniusOddsModels[[2]int{objsport.SportInternalID, objLeague.LeagueInternalID}] = objModel
+			geniusOddsModelsLock.Unlock()
+		}
+	}
+}
+
+// setGeniusOddsModel : attaches the model expectation to each match, matches of leagues without ratings are left out
+func setGeniusOddsModel(objMatches []isg.GeniusSportsMatch) {
+
+	geniusOddsModelsLock.RLock()
+	defer geniusOddsModelsLock.RUnlock()
+
+	for i, objmatch := range objMatches {
+		objModel, ok := geniusOddsModels[[2]int{objmatch.SportInfo.SportInternalID, objmatch.LeagueInfo.LeagueInternalID}]
+		if !ok {
+			continue
+		}
+		objMatches[i].ModelInfo = objModel.MatchModel(objmatch.HomeTeamInternalID.Int64, objmatch.AwayTeamInternalID.Int64)
+	}
+}
+
+// setGeniusOddsMarketModel : model of the markets page match, priced against the same H2H, Line and Total odds as the listings
+func setGeniusOddsMarketModel(objMatches []isg.GeniusSportsMatch, objsport isg.Sport, objleague isg.League, matchID int) {
+
+	setGeniusOddsModel(objMatches)
+	if len(objMatches) == 0 || objMatches[0].ModelInfo == nil {
+		return
+	}
+
+	marketOddsFluc, err := data.GetMatchesProviderMarketFlucs(objsport, objleague.LeagueInternalID, matchID, "best", isg.GeniusOddsWindow{})
+	if err != nil {
+		fmt.Println(err.Error())
+	}
+
+	liveOdds, err := data.GetMatchesProviderMarketOdds(marketOddsFluc, objsport, objleague.LeagueInternalID, matchID, "best", isg.GeniusOddsWindow{})
+	if err != nil {
+		fmt.Println(err.Error())
+		return
+	}
+	liveOdds = excludeGeniusOddsIssues(liveOdds)
+
+	if len(liveOdds) > 0 {
+		objMatches[0].ModelMatchOdds = isg.MakingGeniusLiveOddsSort(liveOdds, objsport.SportInternalID, "best")
+	}
+}
@@ -0,0 +1,171 @@
+package isg
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Settlement outcomes of a single selection
+const (
+	SettleVoid = -1
+	SettleLose = 0
+	SettleWin  = 1
+)
+
+// GeniusOddsSettlement : a provider price for one selection with its settled outcome and accuracy scores
+type GeniusOddsSettlement struct {
+	MatchID      int64
+	SportID      int
+	LeagueID     int
+	SeasonID     int64
+	MatchStatus  string
+	ProviderID   string
+	MarketID     int64
+	MarketTeamID int64
+	HomeTeamID   int64
+	AwayTeamID   int64
+	ISGapiID     string
+	CategoryName string
+	MarketPrice  *float64
+	MarketVal    *float64
+	HomeScore    int
+	AwayScore    int
+	Outcome      int
+	ImpliedProb  float64
+	Brier        float64
+	LogLoss      float64
+}
+
+// GeniusOddsAccuracy : provider accuracy row for the leaderboard
+type GeniusOddsAccuracy struct {
+	Rank       int      `json:"rank"`
+	ProviderID string   `json:"-"`
+	Name       string   `json:"name"`
+	Icon       string   `json:"icon,omitempty"`
+	Season     string   `json:"season"`
+	Selections int      `json:"selections"`
+	Matches    int      `json:"matches"`
+	Brier      *float64 `json:"brier_score"`
+	LogLoss    *float64 `json:"log_loss"`
+	HitRate    *float64 `json:"hit_rate"`
+}
+
+// GeniusOddsLeaderboard :
+type GeniusOddsLeaderboard struct {
+	SportID   string               `json:"sport_id,omitempty"`
+	SportName string               `json:"sport_name,omitempty"`
+	SportURL  string               `json:"sport_url,omitempty"`
+	LeagueURL string               `json:"league_url,omitempty"`
+	Providers []GeniusOddsAccuracy `json:"providers,omitempty"`
+}
+
+// SettleGeniusOddsMarket : resolves one selection from the final score.
+// H2H (win/draw), Line (cover with the NewLine handicap), Total (over/under the closing total) and BTTS (btts_yes/btts_no).
+func SettleGeniusOddsMarket(objOdds GeniusOddsSettlement) int {
+
+	margin := objOdds.HomeScore - objOdds.AwayScore
+	if objOdds.MarketTeamID == objOdds.AwayTeamID {
+		margin = -margin
+	}
+	total := float64(objOdds.HomeScore + objOdds.AwayScore)
+
+	switch objOdds.ISGapiID {
+	case "win":
+		if margin > 0 {
+			return SettleWin
+		}
+		return SettleLose
+	case "draw":
+		if margin == 0 {
+			return SettleWin
+		}
+		return SettleLose
+	case "cover":
+		if objOdds.MarketVal == nil {
+			return SettleVoid
+		}
+		covered := float64(margin) + *objOdds.MarketVal
+		if covered > 0 {
+			return SettleWin
+		} else if covered == 0 {
+			return SettleVoid
+		}
+		return SettleLose
+	case "over", "under":
+		if objOdds.MarketVal == nil || total == *objOdds.MarketVal {
+			return SettleVoid
+		}
+		if (objOdds.ISGapiID == "over") == (total > *objOdds.MarketVal) {
+			return SettleWin
+		}
+		return SettleLose
+	case "btts_yes", "btts_no":
+		bothScored := objOdds.HomeScore > 0 && objOdds.AwayScore > 0
+		if (objOdds.ISGapiID == "btts_yes") == bothScored {
+			return SettleWin
+		}
+		return SettleLose
+	}
+
+	return SettleVoid
+}
+
+// ScoreGeniusOddsSettlements : settles every selection and computes the Brier score and log-loss of the provider's
+// implied probability. Implied probabilities are normalised within each provider market so the overround is removed.
+// Void and unpriced selections are returned as void with no scores so they are stored once and not settled again.
+// Selections of a match which isn't played (status N) are left out so a postponed match is settled once it is played.
+func ScoreGeniusOddsSettlements(records []GeniusOddsSettlement) []GeniusOddsSettlement {
+
+	var played []GeniusOddsSettlement
+	for _, objOdds := range records {
+		if objOdds.MatchStatus == "N" {
+			played = append(played, objOdds)
+		}
+	}
+	records = played
+
+	bookSum := map[string]float64{}
+	for i, objOdds := range records {
+		records[i].Outcome = SettleGeniusOddsMarket(objOdds)
+		if objOdds.MarketPrice != nil && *objOdds.MarketPrice > 1 {
+			bookSum[settlementBookKey(objOdds)] += 1 / *objOdds.MarketPrice
+		}
+	}
+
+	var scored []GeniusOddsSettlement
+	for _, objOdds := range records {
+
+		if objOdds.Outcome == SettleVoid || objOdds.MarketPrice == nil || *objOdds.MarketPrice <= 1 {
+			objOdds.Outcome = SettleVoid
+			scored = append(scored, objOdds)
+			continue
+		}
+
+		prob := 1 / *objOdds.MarketPrice
+		if sum := bookSum[settlementBookKey(objOdds)]; sum > 1 {
+			prob = prob / sum
+		}
+		// keep log-loss finite for near certain prices
+		prob = math.Min(math.Max(prob, 0.0001), 0.9999)
+
+		outcome := float64(objOdds.Outcome)
+		objOdds.ImpliedProb = Round(prob, .5, 4)
+		objOdds.Brier = Round(math.Pow(prob-outcome, 2), .5, 4)
+		objOdds.LogLoss = Round(-(outcome*math.Log(prob) + (1-outcome)*math.Log(1-prob)), .5, 4)
+		scored = append(scored, objOdds)
+	}
+
+	return scored
+}
+
+// settlementBookKey : groups the selections of one provider market, lines and totals are grouped per value
+func settlementBookKey(objOdds GeniusOddsSettlement) string {
+
+	key := strconv.Itoa(int(objOdds.MatchID)) + "-" + objOdds.ProviderID + "-" + strings.ToLower(objOdds.CategoryName)
+	if objOdds.MarketVal != nil {
+		val := math.Abs(*objOdds.MarketVal)
+		key += "-" + strconv.FormatFloat(val, 'f', 1, 64)
+	}
+	return key
+}
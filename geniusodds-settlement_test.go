@@ -0,0 +1,72 @@
+package isg
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSettleGeniusOddsMarket(t *testing.T) {
+
+	tests := []struct {
+		objOdds GeniusOddsSettlement
+		want    int
+	}{
+		{testSettlement("1", "win", 10, 1.8, nil, 90, 80), SettleWin},
+		{testSettlement("1", "win", 20, 2.1, nil, 90, 80), SettleLose},
+		{testSettlement("1", "draw", 10, 30, nil, 80, 80), SettleWin},
+		// the away team +10.5 covers a 10 point loss, -10 is a push
+		{testSettlement("1", "cover", 20, 1.9, testFloat(10.5), 90, 80), SettleWin},
+		{testSettlement("1", "cover", 10, 1.9, testFloat(-10), 90, 80), SettleVoid},
+		{testSettlement("1", "cover", 10, 1.9, nil, 90, 80), SettleVoid},
+		{testSettlement("1", "over", 0, 1.9, testFloat(169.5), 90, 80), SettleWin},
+		{testSettlement("1", "under", 0, 1.9, testFloat(170), 90, 80), SettleVoid},
+		{testSettlement("1", "btts_yes", 0, 1.9, nil, 1, 0), SettleLose},
+		{testSettlement("1", "first_try", 10, 5, nil, 1, 0), SettleVoid},
+	}
+
+	for _, tt := range tests {
+		if got := SettleGeniusOddsMarket(tt.objOdds); got != tt.want {
+			t.Errorf("%s team %d = %d, want %d", tt.objOdds.ISGapiID, tt.objOdds.MarketTeamID, got, tt.want)
+		}
+	}
+}
+
+func TestScoreGeniusOddsSettlements(t *testing.T) {
+
+	records := []GeniusOddsSettlement{
+		testSettlement("1", "win", 10, 1.8, nil, 90, 80),
+		testSettlement("1", "win", 20, 2.1, nil, 90, 80),
+		testSettlement("2", "win", 10, 1, nil, 90, 80),
+	}
+
+	scored := ScoreGeniusOddsSettlements(records)
+	if len(scored) != 3 {
+		t.Fatalf("got %d settled, want 3", len(scored))
+	}
+
+	// the overround of the provider 1 market is removed before scoring
+	prob := (1 / 1.8) / (1/1.8 + 1/2.1)
+	if scored[0].Outcome != SettleWin || math.Abs(scored[0].ImpliedProb-Round(prob, .5, 4)) > 0.0001 ||
+		math.Abs(scored[0].Brier-Round(math.Pow(prob-1, 2), .5, 4)) > 0.0001 {
+		t.Errorf("home win = %+v, want implied %v", scored[0], prob)
+	}
+	// a price of 1 can't be scored and is stored void
+	if scored[2].Outcome != SettleVoid || scored[2].Brier != 0 {
+		t.Errorf("unpriced = %+v, want void", scored[2])
+	}
+}
+
+func TestScoreGeniusOddsSettlementsUnplayed(t *testing.T) {
+
+	postponed := testSettlement("1", "win", 10, 1.8, nil, 0, 0)
+	postponed.MatchStatus = "P"
+	blank := testSettlement("1", "win", 10, 1.8, nil, 0, 0)
+	blank.MatchID, blank.MatchStatus = 2, ""
+	upcoming := testSettlement("1", "win", 10, 1.8, nil, 0, 0)
+	upcoming.MatchID, upcoming.MatchStatus = 3, "Y"
+
+	// nothing is stored, not even as void, so the matches settle once they are played
+	if scored := ScoreGeniusOddsSettlements([]GeniusOddsSettlement{postponed, blank, upcoming}); len(scored) != 0 {
+		t.Errorf("settled %+v, want none of the unplayed matches", scored)
+	}
+}
@@ -0,0 +1,109 @@
+package isg
+
+import (
+	"strconv"
+	"strings"
+)
+
+// GeniusOddsTennisMarketCategories : market categories of the set betting and game handicap markets. The feed gives
+// every set score and handicap market its own isg api id, so they are taken by their isg_market_category.
+var GeniusOddsTennisMarketCategories = []string{"Set Betting", "Game Handicap"}
+
+// GeniusOddsBestMarketSQL : condition on market and marketcategory for the markets of the best odds listing of the
+// sport. Tennis has no draw and adds its set betting and game handicap markets.
+func GeniusOddsBestMarketSQL(sportID int) string {
+	if sportID == 6 {
+		return "(market.isg_api_id IN ('win', 'over', 'under') OR marketcategory.category_name IN ('" +
+			strings.Join(GeniusOddsTennisMarketCategories, "', '") + "'))"
+	}
+	return "market.isg_api_id IN ('win', 'loss', 'draw', 'over', 'under', 'cover')"
+}
+
+// GeniusOddsTournament : tournament of a tennis match with its court surface
+type GeniusOddsTournament struct {
+	TournamentID string `json:"id,omitempty"`
+	Name         string `json:"name,omitempty"`
+	URL          string `json:"tournament_url,omitempty"`
+	Country      string `json:"country,omitempty"`
+	SurfaceName  string `json:"surface,omitempty"`
+	SurfaceURL   string `json:"surface_url,omitempty"`
+}
+
+// BindingGeniusOddsTournament : nil for the team sports
+func BindingGeniusOddsTournament(objmatch GeniusSportsMatch) *GeniusOddsTournament {
+
+	if objmatch.TennisInfo == nil {
+		return nil
+	}
+
+	var objTournament GeniusOddsTournament
+	objTournament.TournamentID = objmatch.TennisInfo.TournamentID
+	objTournament.Name = objmatch.TennisInfo.TournamentFilterName
+	objTournament.URL = objmatch.TennisInfo.TournamentURL
+	objTournament.Country = objmatch.TennisInfo.TournamentCountryName
+	objTournament.SurfaceName = objmatch.TennisInfo.SurfaceName
+	objTournament.SurfaceURL = objmatch.TennisInfo.SurfaceURL
+	return &objTournament
+}
+
+// geniusOddsTournamentKey : league and tournament of a tennis match, empty for the team sports
+func geniusOddsTournamentKey(objmatch GeniusSportsMatch) string {
+	if objmatch.TennisInfo == nil || objmatch.TennisInfo.TournamentID == "" {
+		return ""
+	}
+	return strconv.Itoa(objmatch.LeagueInfo.LeagueInternalID) + "-" + objmatch.TennisInfo.TournamentID
+}
+
+// GroupGeniusOddsTournaments : moves each tennis match up to the earlier matches of its tournament so a tournament is
+// listed as one group, the order is kept otherwise
+func GroupGeniusOddsTournaments(objMatches []GeniusSportsMatch) []GeniusSportsMatch {
+
+	var groups [][]GeniusSportsMatch
+	index := map[string]int{}
+	for _, objmatch := range objMatches {
+		key := geniusOddsTournamentKey(objmatch)
+		if i, ok := index[key]; ok && key != "" {
+			groups[i] = append(groups[i], objmatch)
+			continue
+		}
+		if key != "" {
+			index[key] = len(groups)
+		}
+		groups = append(groups, []GeniusSportsMatch{objmatch})
+	}
+
+	grouped := make([]GeniusSportsMatch, 0, len(objMatches))
+	for _, group := range groups {
+		grouped = append(grouped, group...)
+	}
+	return grouped
+}
+
+// geniusOddsNewGroup : whether the next match starts a new league group, tennis starts one for each tournament
+func geniusOddsNewGroup(objmatch, next GeniusSportsMatch) bool {
+	return objmatch.LeagueInfo.LeagueInternalID != next.LeagueInfo.LeagueInternalID ||
+		objmatch.SportInfo.SportInternalID != next.SportInfo.SportInternalID ||
+		geniusOddsTournamentKey(objmatch) != geniusOddsTournamentKey(next)
+}
+
+// bindingGeniusOddsLeagueGroup : name and url of the league group of the match, league names without the
+// "<sport> - " prefix are kept whole
+func bindingGeniusOddsLeagueGroup(objLeagueMatch *GeniusOddsMatchLeague, objmatch GeniusSportsMatch) {
+
+	objLeagueMatch.Leaguename = objmatch.LeagueInfo.LeagueName
+	if leagues := strings.SplitN(objmatch.LeagueInfo.LeagueName, " - ", 2); len(leagues) == 2 {
+		objLeagueMatch.Leaguename = leagues[1]
+	}
+	objLeagueMatch.LeagueURL = objmatch.LeagueInfo.LeagueEntityKey
+	objLeagueMatch.Tournament = BindingGeniusOddsTournament(objmatch)
+}
+
+// setGeniusOddsPlayerHands : players sit in the team slots, player1 home and player2 away
+func setGeniusOddsPlayerHands(matchesInfo *GeniusOddsMatchesInfo, objmatch GeniusSportsMatch) {
+
+	if objmatch.TennisInfo == nil {
+		return
+	}
+	matchesInfo.HomeTeamInfo.Hand = objmatch.TennisInfo.Player1HandPostion.String
+	matchesInfo.AwayTeamInfo.Hand = objmatch.TennisInfo.Player2HandPostion.String
+}
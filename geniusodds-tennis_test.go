@@ -0,0 +1,56 @@
+package isg
+
+import "testing"
+
+// tennisTestMatch : an ATP match of the tournament, a match without one is left ungrouped
+func tennisTestMatch(matchID int64, tournamentID string) GeniusSportsMatch {
+	objmatch := testMatch(matchID, 6)
+	objmatch.LeagueInfo.LeagueName = "Tennis - ATP"
+	if tournamentID != "" {
+		objmatch.TennisInfo = &MatchInfo{TournamentID: tournamentID, TournamentFilterName: "Tournament " + tournamentID}
+	}
+	return objmatch
+}
+
+func TestGroupGeniusOddsTournaments(t *testing.T) {
+
+	objMatches := []GeniusSportsMatch{
+		tennisTestMatch(1, "10"),
+		tennisTestMatch(2, "20"),
+		tennisTestMatch(3, ""),
+		tennisTestMatch(4, "10"),
+		tennisTestMatch(5, "20"),
+	}
+
+	var order []int64
+	for _, objmatch := range GroupGeniusOddsTournaments(objMatches) {
+		order = append(order, objmatch.MatchID.Int64)
+	}
+	want := []int64{1, 4, 2, 5, 3}
+	for i := range want {
+		if i >= len(order) || order[i] != want[i] {
+			t.Fatalf("order %v, want %v", order, want)
+		}
+	}
+}
+
+func TestBindingGeniusOddsMatchesTournamentGroups(t *testing.T) {
+
+	objMatches := []GeniusSportsMatch{tennisTestMatch(1, "10"), tennisTestMatch(2, "20"), tennisTestMatch(3, "10")}
+	objMatches[1].LeagueInfo.LeagueName = "WTA"
+	objMatches = GroupGeniusOddsTournaments(objMatches)
+
+	objSportMatch := BindingGeniusOddsMatches(objMatches, "upcoming", GeniusOddsOptions{})
+	if len(objSportMatch.Sport) != 1 || len(objSportMatch.Sport[0].Leagues) != 2 {
+		t.Fatalf("sports %+v, want one sport with two tournament groups", objSportMatch.Sport)
+	}
+
+	groups := objSportMatch.Sport[0].Leagues
+	if groups[0].Tournament == nil || groups[0].Tournament.TournamentID != "10" || len(groups[0].Matches) != 2 || groups[0].Leaguename != "ATP" {
+		t.Errorf("first group = %+v, want the 2 matches of tournament 10 in ATP", groups[0])
+	}
+	// a league name without the sport prefix is kept whole
+	if groups[1].Tournament == nil || groups[1].Tournament.TournamentID != "20" || groups[1].Leaguename != "WTA" {
+		t.Errorf("second group = %+v, want tournament 20 in WTA", groups[1])
+	}
+}
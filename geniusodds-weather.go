@@ -0,0 +1,221 @@
+package sports
+
+import (
+	"data"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/thegeniusgroup/isgdatalib"
+)
+
+// geniusOddsWeatherWindow : matches kicking off within it get a forecast
+const geniusOddsWeatherWindow = isg.GeniusOddsWindowDays * 24 * time.Hour
+
+// DefaultWeatherProvider : GENIUSODDS_WEATHER_PROVIDER setting of the open-meteo forecasts
+const DefaultWeatherProvider = "open-meteo"
+
+// WeatherForecastProvider : forecast at a match venue for the kick off
+type WeatherForecastProvider interface {
+	Forecast(objMatch isg.WeatherMatch) (isg.WeatherForecast, error)
+}
+
+// OpenMeteoWeatherProvider : hourly forecasts of the Open-Meteo api, no key needed
+type OpenMeteoWeatherProvider struct {
+	Client  *http.Client
+	BaseURL string
+}
+
+// openMeteoForecast : hourly values of the Open-Meteo response, times are UTC hours e.g. 2026-10-19T09:00
+type openMeteoForecast struct {
+	Hourly struct {
+		Time         []string   `json:"time"`
+		Temperature  []*float64 `json:"temperature_2m"`
+		Precip       []*float64 `json:"precipitation"`
+		PrecipChance []*float64 `json:"precipitation_probability"`
+		WindSpeed    []*float64 `json:"wind_speed_10m"`
+		WeatherCode  []*float64 `json:"weather_code"`
+	} `json:"hourly"`
+}
+
+// Forecast : the forecast hour nearest the kick off
+func (provider OpenMeteoWeatherProvider) Forecast(objMatch isg.WeatherMatch) (isg.WeatherForecast, error) {
+
+	var objForecast isg.WeatherForecast
+
+	kickOff := objMatch.KickOff.UTC()
+	params := url.Values{}
+	params.Set("latitude", strconv.FormatFloat(objMatch.Latitude, 'f', 4, 64))
+	params.Set("longitude", strconv.FormatFloat(objMatch.Longitude, 'f', 4, 64))
+	params.Set("hourly", "temperature_2m,precipitation,precipitation_probability,wind_speed_10m,weather_code")
+	params.Set("timezone", "UTC")
+	params.Set("start_date", kickOff.Format("2006-01-02"))
+	params.Set("end_date", kickOff.Format("2006-01-02"))
+
+	resp, err := provider.Client.Get(provider.BaseURL + "?" + params.Encode())
+	if err != nil {
+		return objForecast, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return objForecast, errors.New("open-meteo returned " + resp.Status)
+	}
+
+	var objResponse openMeteoForecast
+	err = json.NewDecoder(resp.Body).Decode(&objResponse)
+	if err != nil {
+		return objForecast, err
+	}
+
+	hour := -1
+	var nearest float64
+	for i, val := range objResponse.Hourly.Time {
+		t, err := time.Parse("2006-01-02T15:04", val)
+		if err != nil {
+			continue
+		}
+		diff := math.Abs(t.Sub(kickOff).Hours())
+		if hour == -1 || diff < nearest {
+			hour, nearest = i, diff
+		}
+	}
+	if hour == -1 {
+		return objForecast, errors.New("open-meteo has no forecast for " + kickOff.Format("2006-01-02 15:04"))
+	}
+
+	objForecast.Temperature = openMeteoValue(objResponse.Hourly.Temperature, hour)
+	objForecast.Precip = openMeteoValue(objResponse.Hourly.Precip, hour)
+	objForecast.PrecipChance = openMeteoValue(objResponse.Hourly.PrecipChance, hour)
+	objForecast.WindSpeed = openMeteoValue(objResponse.Hourly.WindSpeed, hour)
+	if code := openMeteoValue(objResponse.Hourly.WeatherCode, hour); code != nil {
+		objForecast.Summary = weatherCodeSummary(int(*code))
+	}
+
+	return objForecast, nil
+}
+
+// openMeteoValue :
+func openMeteoValue(values []*float64, i int) *float64 {
+	if i >= len(values) {
+		return nil
+	}
+	return values[i]
+}
+
+// weatherCodeSummary : summary of a WMO weather code
+func weatherCodeSummary(code int) string {
+	switch {
+	case code == 0:
+		return "Clear"
+	case code <= 2:
+		return "Partly Cloudy"
+	case code == 3:
+		return "Cloudy"
+	case code == 45 || code == 48:
+		return "Fog"
+	case code >= 51 && code <= 57:
+		return "Drizzle"
+	case code >= 61 && code <= 67:
+		return "Rain"
+	case code >= 71 && code <= 77:
+		return "Snow"
+	case code >= 80 && code <= 82:
+		return "Showers"
+	case code == 85 || code == 86:
+		return "Snow Showers"
+	case code >= 95:
+		return "Thunderstorm"
+	}
+	return ""
+}
+
+// NewWeatherForecastProvider : provider of the GENIUSODDS_WEATHER_PROVIDER setting, "open-meteo" or "file:<path>". Any other
+// setting is an error, there is no fallback provider.
+func NewWeatherForecastProvider(setting string) (WeatherForecastProvider, error) {
+
+	switch {
+	case setting == DefaultWeatherProvider:
+		return OpenMeteoWeatherProvider{Client: &http.Client{Timeout: 10 * time.Second}, BaseURL: "https://api.open-meteo.com/v1/forecast"}, nil
+	case strings.HasPrefix(setting, "file:"):
+		return isg.NewFileWeatherProvider(strings.TrimPrefix(setting, "file:"))
+	}
+	return nil, errors.New("invalid weather provider " + setting)
+}
+
+// StartGeniusOddsWeather : refreshes the forecasts of the upcoming matches and repeats after every interval
+func StartGeniusOddsWeather(interval time.Duration, provider WeatherForecastProvider) {
+	for {
+		refreshGeniusOddsWeather(provider)
+		time.Sleep(interval)
+	}
+}
+
+// refreshGeniusOddsWeather : forecasts of the genius odds matches within the upcoming window
+func refreshGeniusOddsWeather(provider WeatherForecastProvider) {
+
+	for _, objsport := range data.GeniusOddsSports() {
+
+		// tennis matches have no venue in the match table to forecast for
+		if objsport.SportInternalID == 6 {
+			continue
+		}
+
+		for _, objLeague := range data.SportsLeagues[strconv.Itoa(objsport.SportInternalID)] {
+
+			objMatches, err := data.GetWeatherForecastMatches(objsport, objLeague.LeagueInternalID, geniusOddsWeatherWindow)
+			if err != nil {
+				fmt.Println(err.Error())
+				continue
+			}
+
+			for _, objMatch := range objMatches {
+
+				objForecast, err := provider.Forecast(objMatch)
+				if err != nil {
+					fmt.Println(err.Error())
+					continue
+				}
+
+				err = data.UpdateMatchWeather(objsport, objMatch.MatchID, objForecast)
+				if err != nil {
+					fmt.Println(err.Error())
+				}
+			}
+		}
+	}
+}
+
+// setGeniusOddsWeather : attaches the forecast weather to each match, one query per sport
+func setGeniusOddsWeather(objMatches []isg.GeniusSportsMatch) {
+
+	sportMatches := map[int][]int64{}
+	sportInfo := map[int]isg.Sport{}
+	for _, objmatch := range objMatches {
+		sportMatches[objmatch.SportInfo.SportInternalID] = append(sportMatches[objmatch.SportInfo.SportInternalID], objmatch.MatchID.Int64)
+		sportInfo[objmatch.SportInfo.SportInternalID] = objmatch.SportInfo
+	}
+
+	weather := map[int]map[int64]isg.WeatherInfo{}
+	for sportID, matchIDs := range sportMatches {
+		sportWeather, err := data.GetGeniusOddsMatchWeather(sportInfo[sportID], matchIDs)
+		if err != nil {
+			fmt.Println(err.Error())
+			continue
+		}
+		weather[sportID] = sportWeather
+	}
+
+	for i, objmatch := range objMatches {
+		objWeather, ok := weather[objmatch.SportInfo.SportInternalID][objmatch.MatchID.Int64]
+		if ok {
+			objMatches[i].WeatherInfo = &objWeather
+		}
+	}
+}
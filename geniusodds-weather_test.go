@@ -0,0 +1,15 @@
+package sports
+
+import "testing"
+
+func TestNewWeatherForecastProvider(t *testing.T) {
+
+	if _, err := NewWeatherForecastProvider(DefaultWeatherProvider); err != nil {
+		t.Errorf("%s: %v", DefaultWeatherProvider, err)
+	}
+	for _, setting := range []string{"open-meteo.com", "met-office"} {
+		if objProvider, err := NewWeatherForecastProvider(setting); err == nil || objProvider != nil {
+			t.Errorf("%s = %v, %v, want an error and no provider", setting, objProvider, err)
+		}
+	}
+}
@@ -1,5 +1,7 @@
 package isg
 
+import "time"
+
 // IntMarketInfo :
 type IntMarketInfo struct {
 	MatchID        int64
@@ -10,9 +12,53 @@ type IntMarketInfo struct {
 	AnyMarketOdds  []OddsInfo
 }
 
+// GeniusOddsOptions : response options of the genius odds routes, read from the query string
+type GeniusOddsOptions struct {
+	OddsFormat string
+	TimeZone   *time.Location
+	Include    []string
+}
+
+// GeniusOddsFilter : subset of the markets page, provider ids, category names, group names (both lower case) and isg api market ids
+type GeniusOddsFilter struct {
+	Providers  []string
+	Categories []string
+	Groups     []string
+	Markets    []string
+}
+
+// Genius odds listing window defaults
+const (
+	GeniusOddsWindowDays   = 8
+	GeniusOddsPageLimit    = 10
+	GeniusOddsMaxPageLimit = 100
+)
+
+// GeniusOddsWindow : kick off window and page of the genius odds listings, zero From / To fall back to now and GeniusOddsWindowDays.
+// MatchIDs is the page picked by the page query, the odds and flucs queries then load those matches only.
+type GeniusOddsWindow struct {
+	From     time.Time
+	To       time.Time
+	Limit    int
+	Cursor   *GeniusOddsCursor
+	MatchIDs []int64
+}
+
+// GeniusOddsCursor : sort key of a listed match, as a cursor the last match of the previous page. Upcoming matches are in
+// kick off order (CounterDateTime, AEST counter_date / counter_time), best matches by the gap between the home and away
+// price and plunge matches largest plunge first (Rank). The sport sequence and match id break the ties.
+type GeniusOddsCursor struct {
+	Type            string
+	CounterDateTime string
+	Rank            float64
+	Sequence        int
+	MatchID         int64
+}
+
 // GeniusOddsSportMatch :
 type GeniusOddsSportMatch struct {
-	Sport []GeniusOddsMatch `json:"sport,omitempty"`
+	Sport      []GeniusOddsMatch `json:"sport,omitempty"`
+	NextCursor string            `json:"next_cursor,omitempty"`
 }
 
 // GeniusOddsMatch :
@@ -27,29 +73,38 @@ type GeniusOddsMatch struct {
 type GeniusOddsMatchLeague struct {
 	Leaguename string                  `json:"league_name,omitempty"`
 	LeagueURL  string                  `json:"league_url,omitempty"`
+	Tournament *GeniusOddsTournament   `json:"tournament,omitempty"`
 	Matches    []GeniusOddsMatchesInfo `json:"matches,omitempty"`
 }
 
 // GeniusOddsMatchesInfo :
 type GeniusOddsMatchesInfo struct {
-	MatchID         int64              `json:"match_id,omitempty"`
-	LocalDate       string             `json:"local_date,omitempty"`
-	LocalTime       string             `json:"local_time,omitempty"`
-	MatchDate       string             `json:"match_date"`
-	MatchTime       string             `json:"match_time"`
-	TimeZone        string             `json:"timezone"`
-	Weather         string             `json:"weather"`
-	DayNight        string             `json:"playing,omitempty"`
-	Status          string             `json:"match_status,omitempty"`
-	IsReschedule    int                `json:"is_reschedule"`
-	IsPlunge        string             `json:"plunge_team,omitempty"`
-	Round           SportRound         `json:"round_week,omitempty"`
-	HomeTeamInfo    Team               `json:"home,omitempty"`
-	AwayTeamInfo    Team               `json:"away,omitempty"`
-	VenueInfo       Venue              `json:"venue,omitempty"`
-	Groups          []GeniusGroups     `json:"groups,omitempty"`
-	Market          []GeniusMarketOdds `json:"markets,omitempty"`
-	GeniusOddsPlung *MarketOddsList    `json:"plunge_odds,omitempty"`
+	MatchID         int64                  `json:"match_id,omitempty"`
+	LocalDate       string                 `json:"local_date,omitempty"`
+	LocalTime       string                 `json:"local_time,omitempty"`
+	MatchDate       string                 `json:"match_date"`
+	MatchTime       string                 `json:"match_time"`
+	StartTime       string                 `json:"start_time,omitempty"`
+	StartTimeUTC    string                 `json:"start_time_utc,omitempty"`
+	StartTimeLocal  string                 `json:"start_time_local,omitempty"`
+	TimeZone        string                 `json:"timezone"`
+	Weather         string                 `json:"weather"`
+	WeatherDetail   *WeatherInfo           `json:"weather_detail,omitempty"`
+	DayNight        string                 `json:"playing,omitempty"`
+	Status          string                 `json:"match_status,omitempty"`
+	IsReschedule    int                    `json:"is_reschedule"`
+	IsPlunge        string                 `json:"plunge_team,omitempty"`
+	Round           SportRound             `json:"round_week,omitempty"`
+	Tournament      *GeniusOddsTournament  `json:"tournament,omitempty"`
+	HomeTeamInfo    Team                   `json:"home,omitempty"`
+	AwayTeamInfo    Team                   `json:"away,omitempty"`
+	VenueInfo       Venue                  `json:"venue,omitempty"`
+	Groups          []GeniusGroups         `json:"groups,omitempty"`
+	Market          []GeniusMarketOdds     `json:"markets,omitempty"`
+	GeniusOddsPlung *MarketOddsList        `json:"plunge_odds,omitempty"`
+	Model           *GeniusOddsModelPrices `json:"model,omitempty"`
+	Preview         *GeniusOddsPreview     `json:"preview,omitempty"`
+	Tips            []Tips                 `json:"tips,omitempty"`
 }
 
 // GeniusGroups :
@@ -75,10 +130,20 @@ type GeniusMarketOptions struct {
 
 // MarketOddsList :
 type MarketOddsList struct {
-	MarketType   string     `json:"display_name,omitempty"`
-	AbbrName     string     `json:"abbr_name,omitempty"`
-	TeamInfo     *Team      `json:"team,omitempty"`
-	ProviderList []OddsInfo `json:"providers,omitempty"`
+	MarketType   string            `json:"display_name,omitempty"`
+	AbbrName     string            `json:"abbr_name,omitempty"`
+	TeamInfo     *Team             `json:"team,omitempty"`
+	PlayerInfo   *GeniusOddsPlayer `json:"player,omitempty"`
+	ProviderList []OddsInfo        `json:"providers,omitempty"`
+}
+
+// GeniusOddsPlayer : player of a player market selection e.g. first goal scorer, anytime try scorer, disposals
+type GeniusOddsPlayer struct {
+	PlayerID         string `json:"id"`
+	PlayerInternalID int64  `json:"-"`
+	Name             string `json:"name"`
+	Position         string `json:"position,omitempty"`
+	TeamInfo         *Team  `json:"team,omitempty"`
 }
 
 // ExtProvider :
@@ -88,3 +153,49 @@ type ExtProvider struct {
 	Icon        *string   `json:"icon,omitempty"`
 	Fluctuation []float64 `json:"fluc,omitempty"`
 }
+
+// GeniusOddsSnapshotManifest : index of the published genius odds json snapshots
+type GeniusOddsSnapshotManifest struct {
+	GeneratedAt string                           `json:"generated_at"`
+	Snapshots   []GeniusOddsSnapshotManifestItem `json:"snapshots"`
+}
+
+// GeniusOddsSnapshotManifestItem :
+type GeniusOddsSnapshotManifestItem struct {
+	Key       string `json:"key"`
+	Type      string `json:"type"`
+	SportURL  string `json:"sport_url"`
+	LeagueURL string `json:"league_url"`
+	MatchID   int    `json:"match_id,omitempty"`
+	Hash      string `json:"hash"`
+	Size      int    `json:"size"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+// GeniusOddsClosingLineValue : open against close price for a provider and market category
+type GeniusOddsClosingLineValue struct {
+	ProviderID       string   `json:"-"`
+	Name             string   `json:"name"`
+	Icon             string   `json:"icon,omitempty"`
+	ProviderSequence int      `json:"-"`
+	SportID          int      `json:"-"`
+	LeagueID         int      `json:"-"`
+	LeagueURL        string   `json:"league_url,omitempty"`
+	SeasonID         int      `json:"-"`
+	Season           string   `json:"season"`
+	CategoryName     string   `json:"market_name"`
+	MarketCount      int      `json:"markets"`
+	AvgOpenOdds      *float64 `json:"avg_open_price"`
+	AvgCloseOdds     *float64 `json:"avg_close_price"`
+	CLVPer           *float64 `json:"clv_per"`
+	ShortenedCount   int      `json:"shortened"`
+	AvgLineMove      *float64 `json:"avg_line_move,omitempty"`
+}
+
+// GeniusOddsClosingLineReport :
+type GeniusOddsClosingLineReport struct {
+	SportID   string                       `json:"sport_id,omitempty"`
+	SportName string                       `json:"sport_name,omitempty"`
+	SportURL  string                       `json:"sport_url,omitempty"`
+	Providers []GeniusOddsClosingLineValue `json:"providers,omitempty"`
+}
@@ -0,0 +1,61 @@
+/*
+Package data - Handles functions related to data source access e.g. cache, databases
+*/
+package data
+
+import (
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/thegeniusgroup/isgdatalib"
+)
+
+// GeniusOddsSportIDs : internal ids of the sports listed, published and checked by genius odds.
+// AFL, tennis, NRL and sport 10 unless GENIUSODDS_SPORTS names others at startup.
+var GeniusOddsSportIDs = map[int]bool{1: true, 6: true, 7: true, 10: true}
+
+// ParseGeniusOddsSports : internal ids of the comma separated sport api ids e.g. "ar,rl", every id must be a preloaded sport
+func ParseGeniusOddsSports(sportIDs string, objSports map[string]isg.Sport) (map[int]bool, error) {
+
+	ids := map[int]bool{}
+	for _, sportID := range strings.Split(sportIDs, ",") {
+		sportID = strings.ToLower(strings.TrimSpace(sportID))
+		if sportID == "" {
+			continue
+		}
+		objSport, ok := objSports[sportID]
+		if !ok {
+			return nil, errors.New("genius odds sport " + sportID + " not found")
+		}
+		ids[objSport.SportInternalID] = true
+	}
+
+	if len(ids) == 0 {
+		return nil, errors.New("no genius odds sports")
+	}
+
+	return ids, nil
+}
+
+// IsGeniusOddsSport : true when the sport is served by genius odds
+func IsGeniusOddsSport(sportID int) bool {
+	return GeniusOddsSportIDs[sportID]
+}
+
+// GeniusOddsSports : the preloaded genius odds sports in sport id order
+func GeniusOddsSports() []isg.Sport {
+
+	var objSports []isg.Sport
+	for _, objSport := range SportObjects {
+		if IsGeniusOddsSport(objSport.SportInternalID) {
+			objSports = append(objSports, objSport)
+		}
+	}
+
+	sort.Slice(objSports, func(i, j int) bool {
+		return objSports[i].SportInternalID < objSports[j].SportInternalID
+	})
+
+	return objSports
+}
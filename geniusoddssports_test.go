@@ -0,0 +1,40 @@
+package data
+
+import (
+	"testing"
+
+	"github.com/thegeniusgroup/isgdatalib"
+)
+
+func TestParseGeniusOddsSports(t *testing.T) {
+
+	objSports := map[string]isg.Sport{"ar": {SportInternalID: 1}, "te": {SportInternalID: 6}, "rl": {SportInternalID: 7}}
+
+	ids, err := ParseGeniusOddsSports(" AR, te,", objSports)
+	if err != nil || len(ids) != 2 || !ids[1] || !ids[6] {
+		t.Errorf("ParseGeniusOddsSports = %v, %v, want AFL and tennis", ids, err)
+	}
+
+	for _, sportIDs := range []string{"ar,xx", "", " , "} {
+		if _, err := ParseGeniusOddsSports(sportIDs, objSports); err == nil {
+			t.Errorf("ParseGeniusOddsSports(%q) expected an error", sportIDs)
+		}
+	}
+}
+
+func TestGeniusOddsSports(t *testing.T) {
+
+	prevObjects, prevIDs := SportObjects, GeniusOddsSportIDs
+	defer func() { SportObjects, GeniusOddsSportIDs = prevObjects, prevIDs }()
+
+	SportObjects = map[string]isg.Sport{"ar": {SportInternalID: 1}, "bb": {SportInternalID: 2}, "te": {SportInternalID: 6}, "sp": {SportInternalID: 10}}
+	GeniusOddsSportIDs = map[int]bool{10: true, 6: true, 1: true}
+
+	var ids []int
+	for _, objSport := range GeniusOddsSports() {
+		ids = append(ids, objSport.SportInternalID)
+	}
+	if len(ids) != 3 || ids[0] != 1 || ids[1] != 6 || ids[2] != 10 {
+		t.Errorf("GeniusOddsSports = %v, want 1, 6 and 10", ids)
+	}
+}
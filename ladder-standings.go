@@ -0,0 +1,104 @@
+package sports
+
+import (
+	"data"
+	"fmt"
+	"net/http"
+	"util"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/thegeniusgroup/isgdatalib"
+)
+
+// Ladder : standings of a league season from the final scores with the points adjustments applied. ?conference= and
+// ?division= limit the ladder to the teams of a conference or division.
+// GET  /ladder/{:sport}/{:league}/{:season}?conference=&division=
+func Ladder(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+
+	sportname := util.CleanText(p.ByName("sport"), true, true)
+	leaguename := util.CleanText(p.ByName("league"), true, true)
+	seasonname := util.CleanText(p.ByName("season"), true, true)
+	conference := util.CleanText(r.URL.Query().Get("conference"), false, true)
+	division := util.CleanText(r.URL.Query().Get("division"), false, true)
+
+	objsport, err := data.GetSport(sportname)
+	if err != nil {
+		util.WebResponse(w, r, http.StatusNotFound, "sport not found")
+		return
+	}
+
+	objRules, ok := isg.LadderSports[objsport.SportInternalID]
+	if !ok {
+		util.WebResponse(w, r, http.StatusNotFound, "sport not supported")
+		return
+	}
+
+	objleague, err := data.GetLeagueID(objsport, leaguename)
+	if err != nil {
+		util.WebResponse(w, r, http.StatusNotFound, "league not found")
+		return
+	}
+
+	seasonID, err := data.GetSeasonID(objsport, seasonname)
+	if err != nil || seasonID == 0 {
+		util.WebResponse(w, r, http.StatusNotFound, "season not found")
+		return
+	}
+
+	if conference != "" {
+		count, err := data.ValidateConference(conference, objsport)
+		if err != nil || count == 0 {
+			util.WebResponse(w, r, http.StatusBadRequest, "invalid conference :"+conference)
+			return
+		}
+	}
+	if division != "" {
+		count, err := data.ValidateDivision(division, objsport)
+		if err != nil || count == 0 {
+			util.WebResponse(w, r, http.StatusBadRequest, "invalid division :"+division)
+			return
+		}
+	}
+
+	allTeams, err := data.GetAllTeams(objsport, objleague, seasonname, 0)
+	if err != nil {
+		fmt.Println(err.Error())
+		util.WebResponse(w, r, http.StatusNotFound, "record not found")
+		return
+	}
+
+	matches, err := data.GetLadderMatches(objsport, objleague, seasonID)
+	if err != nil {
+		fmt.Println(err.Error())
+		util.WebResponse(w, r, http.StatusNotFound, "record not found")
+		return
+	}
+
+	teams := isg.LadderTeams(allTeams, matches, conference, division)
+	if len(teams) == 0 {
+		util.WebResponse(w, r, http.StatusNotFound, "record not found")
+		return
+	}
+
+	var teamAdjustments []isg.PointsAdjustments
+	for _, objTeam := range teams {
+		objAdjustments, err := data.GetPointsAdjustments(objsport, objleague, objTeam)
+		if err != nil {
+			fmt.Println(err.Error())
+			continue
+		}
+		teamAdjustments = append(teamAdjustments, objAdjustments...)
+	}
+	adjustments := isg.LadderAdjustments(teamAdjustments, seasonID)
+
+	var t isg.Ladder
+	t.Season = seasonname
+	t.Conference = conference
+	t.Division = division
+	t.SortBy = objRules.SortBy
+	t.Rows = isg.BuildLadder(objRules, teams, matches, adjustments)
+
+	final := util.JSONMessageWrappedObj(http.StatusOK, t)
+	util.WebResponseJSONObjectNoCache(w, r, http.StatusOK, final)
+	return
+}
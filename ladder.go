@@ -0,0 +1,215 @@
+package isg
+
+import (
+	"math"
+	"sort"
+	"strconv"
+)
+
+// Ladder tie breakers after the points
+const (
+	LadderSortPercentage   = "percentage"
+	LadderSortDifferential = "differential"
+)
+
+// LadderRules : competition points of a result and the tie breaker of a sport, Bye is the points of a team without a
+// match in a round that has been played
+type LadderRules struct {
+	Win    int
+	Draw   int
+	Loss   int
+	Bye    int
+	SortBy string
+}
+
+// LadderSports : ladder rules of the sports with final scores
+var LadderSports = map[int]LadderRules{
+	1:  {Win: 4, Draw: 2, SortBy: LadderSortPercentage},           // AFL
+	4:  {Win: 3, Draw: 1, SortBy: LadderSortDifferential},         // Soccer
+	7:  {Win: 2, Draw: 1, Bye: 2, SortBy: LadderSortDifferential}, // NRL
+	10: {Win: 4, Draw: 2, SortBy: LadderSortDifferential},         // Super Rugby
+}
+
+// LadderMatch : a home and away match of the season, the scores are only set once it is Played
+type LadderMatch struct {
+	MatchID    int64
+	RoundID    int
+	HomeTeamID int64
+	AwayTeamID int64
+	HomeScore  int
+	AwayScore  int
+	Played     bool
+}
+
+// LadderRow : a team's standing, Points include the Adjustment
+type LadderRow struct {
+	Rank         int      `json:"rank"`
+	DisplayRank  string   `json:"display_rank"`
+	Team         Team     `json:"team"`
+	Played       int      `json:"played"`
+	Won          int      `json:"won"`
+	Lost         int      `json:"lost"`
+	Drawn        int      `json:"drawn"`
+	Byes         int      `json:"byes,omitempty"`
+	For          int      `json:"for"`
+	Against      int      `json:"against"`
+	Percentage   *float64 `json:"percentage,omitempty"`
+	Differential int      `json:"differential"`
+	Adjustment   int      `json:"adjustment,omitempty"`
+	Points       int      `json:"points"`
+}
+
+// Ladder : standings of a league season, limited to a conference or division when one is given
+type Ladder struct {
+	Season     string      `json:"season"`
+	Conference string      `json:"conference,omitempty"`
+	Division   string      `json:"division,omitempty"`
+	SortBy     string      `json:"sort_by"`
+	Rows       []LadderRow `json:"ladder"`
+}
+
+// BuildLadder : standings of the teams from the final scores, teams without a result are listed with no games played.
+// Results against teams outside the list still count for the listed team. A team has a bye in a round with a played
+// match when it has no match of its own in the round. adjustments are keyed by the team id.
+func BuildLadder(rules LadderRules, teams []Team, matches []LadderMatch, adjustments map[int64]int) []LadderRow {
+
+	rows := make([]LadderRow, len(teams))
+	index := map[int64]int{}
+	for i, objTeam := range teams {
+		teamID, _ := strconv.ParseInt(objTeam.TeamInternalID, 10, 64)
+		index[teamID] = i
+		rows[i].Team = objTeam
+		rows[i].Adjustment = adjustments[teamID]
+	}
+
+	playedRounds := map[int]bool{}
+	teamRounds := map[int64]map[int]bool{}
+	for _, match := range matches {
+		for _, teamID := range []int64{match.HomeTeamID, match.AwayTeamID} {
+			if teamRounds[teamID] == nil {
+				teamRounds[teamID] = map[int]bool{}
+			}
+			teamRounds[teamID][match.RoundID] = true
+		}
+		if !match.Played {
+			continue
+		}
+		playedRounds[match.RoundID] = true
+		if i, ok := index[match.HomeTeamID]; ok {
+			addLadderResult(&rows[i], rules, match.HomeScore, match.AwayScore)
+		}
+		if i, ok := index[match.AwayTeamID]; ok {
+			addLadderResult(&rows[i], rules, match.AwayScore, match.HomeScore)
+		}
+	}
+
+	if rules.Bye > 0 {
+		for teamID, i := range index {
+			for roundID := range playedRounds {
+				if !teamRounds[teamID][roundID] {
+					rows[i].Byes++
+					rows[i].Points += rules.Bye
+				}
+			}
+		}
+	}
+
+	for i := range rows {
+		rows[i].Differential = rows[i].For - rows[i].Against
+		rows[i].Points += rows[i].Adjustment
+		if rules.SortBy == LadderSortPercentage && rows[i].Against > 0 {
+			percentage := Round(float64(rows[i].For)/float64(rows[i].Against)*100, .5, 2)
+			rows[i].Percentage = &percentage
+		}
+	}
+
+	sort.SliceStable(rows, func(i, j int) bool {
+		if rows[i].Points != rows[j].Points {
+			return rows[i].Points > rows[j].Points
+		}
+		if rules.SortBy == LadderSortPercentage {
+			if ladderPercentage(rows[i]) != ladderPercentage(rows[j]) {
+				return ladderPercentage(rows[i]) > ladderPercentage(rows[j])
+			}
+		} else if rows[i].Differential != rows[j].Differential {
+			return rows[i].Differential > rows[j].Differential
+		}
+		if rows[i].For != rows[j].For {
+			return rows[i].For > rows[j].For
+		}
+		return rows[i].Team.TeamName < rows[j].Team.TeamName
+	})
+
+	for i := range rows {
+		rows[i].Rank = i + 1
+		rows[i].DisplayRank = GetDisplayRank(strconv.Itoa(i + 1))
+		rows[i].Team.Ranking = strconv.Itoa(i + 1)
+	}
+
+	return rows
+}
+
+// LadderTeams : teams with a match in the season, limited to a conference or division when one is given
+func LadderTeams(teams []Team, matches []LadderMatch, conference, division string) []Team {
+
+	seasonTeams := map[int64]bool{}
+	for _, match := range matches {
+		seasonTeams[match.HomeTeamID] = true
+		seasonTeams[match.AwayTeamID] = true
+	}
+
+	var ladderTeams []Team
+	for _, objTeam := range teams {
+		teamID, _ := strconv.ParseInt(objTeam.TeamInternalID, 10, 64)
+		if !seasonTeams[teamID] || (conference != "" && objTeam.Conference != conference) || (division != "" && objTeam.Division != division) {
+			continue
+		}
+		ladderTeams = append(ladderTeams, objTeam)
+	}
+	return ladderTeams
+}
+
+// LadderAdjustments : points adjustments of the season keyed by team id, from the adjustments of the teams
+func LadderAdjustments(teamAdjustments []PointsAdjustments, seasonID int) map[int64]int {
+
+	adjustments := map[int64]int{}
+	for _, objAdjustment := range teamAdjustments {
+		if objAdjustment.SeasonID != seasonID {
+			continue
+		}
+		teamID, _ := strconv.ParseInt(objAdjustment.TeamID, 10, 64)
+		adjustments[teamID] += objAdjustment.Points
+	}
+	return adjustments
+}
+
+// addLadderResult :
+func addLadderResult(row *LadderRow, rules LadderRules, scored, conceded int) {
+
+	row.Played++
+	row.For += scored
+	row.Against += conceded
+
+	switch {
+	case scored > conceded:
+		row.Won++
+		row.Points += rules.Win
+	case scored < conceded:
+		row.Lost++
+		row.Points += rules.Loss
+	default:
+		row.Drawn++
+		row.Points += rules.Draw
+	}
+}
+
+// ladderPercentage : teams yet to concede rank above the rest on percentage
+func ladderPercentage(row LadderRow) float64 {
+	if row.Percentage == nil {
+		if row.For > 0 {
+			return math.Inf(1)
+		}
+		return 0
+	}
+	return *row.Percentage
+}
@@ -0,0 +1,153 @@
+package isg
+
+import "testing"
+
+// ladderTestTeams : teams 1 to 4 in name order
+var ladderTestTeams = []string{"Adelaide", "Brisbane", "Carlton", "Collingwood"}
+
+func ladderTestMatch(roundID int, homeTeamID, awayTeamID int64, homeScore, awayScore int) LadderMatch {
+	return LadderMatch{RoundID: roundID, HomeTeamID: homeTeamID, AwayTeamID: awayTeamID, HomeScore: homeScore, AwayScore: awayScore, Played: true}
+}
+
+// ladderTestOrder : team ids of the rows in ladder order
+func ladderTestOrder(rows []LadderRow) []string {
+	var order []string
+	for _, row := range rows {
+		order = append(order, row.Team.TeamInternalID)
+	}
+	return order
+}
+
+func ladderTestCheckOrder(t *testing.T, rows []LadderRow, want ...string) {
+	t.Helper()
+	order := ladderTestOrder(rows)
+	if len(order) != len(want) {
+		t.Fatalf("ladder %v, want %v", order, want)
+	}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Fatalf("ladder %v, want %v", order, want)
+		}
+	}
+}
+
+func TestBuildLadderPercentage(t *testing.T) {
+
+	// 1 and 2 are level on points, 1 has the better percentage
+	matches := []LadderMatch{
+		ladderTestMatch(1, 1, 3, 100, 50),
+		ladderTestMatch(1, 2, 4, 80, 70),
+		ladderTestMatch(2, 1, 2, 60, 60),
+		ladderTestMatch(2, 3, 4, 90, 80),
+	}
+
+	rows := BuildLadder(LadderSports[1], testTeams(ladderTestTeams...), matches, nil)
+	ladderTestCheckOrder(t, rows, "1", "2", "3", "4")
+
+	if rows[0].Points != 6 || rows[0].Won != 1 || rows[0].Drawn != 1 || rows[0].Played != 2 {
+		t.Errorf("row 1 = %+v, want 6 points from a win and a draw", rows[0])
+	}
+	if rows[0].Percentage == nil || *rows[0].Percentage != 145.45 {
+		t.Errorf("percentage = %v, want 145.45", rows[0].Percentage)
+	}
+	if rows[3].DisplayRank != GetDisplayRank("4") || rows[3].Team.Ranking != "4" {
+		t.Errorf("row 4 = %+v, want rank 4", rows[3])
+	}
+}
+
+func TestBuildLadderTieBreakers(t *testing.T) {
+
+	// all level on points, differential and points for, so in name order
+	matches := []LadderMatch{
+		ladderTestMatch(1, 1, 2, 10, 20),
+		ladderTestMatch(1, 3, 4, 10, 20),
+		ladderTestMatch(2, 2, 1, 10, 20),
+		ladderTestMatch(2, 4, 3, 10, 20),
+	}
+
+	rows := BuildLadder(LadderRules{Win: 2, Draw: 1, SortBy: LadderSortDifferential}, testTeams(ladderTestTeams...), matches, nil)
+	ladderTestCheckOrder(t, rows, "1", "2", "3", "4")
+
+	// 4 wins again, 1 and 3 are level on everything but the name
+	matches = append(matches, ladderTestMatch(3, 4, 2, 30, 0))
+	rows = BuildLadder(LadderRules{Win: 2, Draw: 1, SortBy: LadderSortDifferential}, testTeams(ladderTestTeams...), matches, nil)
+	ladderTestCheckOrder(t, rows, "4", "1", "3", "2")
+
+	// percentage sorts a team yet to concede first
+	rows = BuildLadder(LadderSports[1], testTeams(ladderTestTeams...), []LadderMatch{ladderTestMatch(1, 3, 4, 0, 0), ladderTestMatch(1, 1, 2, 10, 10)}, nil)
+	ladderTestCheckOrder(t, rows, "1", "2", "3", "4")
+	rows = BuildLadder(LadderSports[1], testTeams(ladderTestTeams...), []LadderMatch{ladderTestMatch(1, 1, 2, 10, 10), ladderTestMatch(1, 3, 4, 5, 0)}, nil)
+	ladderTestCheckOrder(t, rows, "3", "1", "2", "4")
+}
+
+func TestBuildLadderAdjustments(t *testing.T) {
+
+	matches := []LadderMatch{ladderTestMatch(1, 1, 2, 20, 10), ladderTestMatch(1, 3, 4, 20, 10)}
+
+	rows := BuildLadder(LadderSports[4], testTeams(ladderTestTeams...), matches, map[int64]int{1: -4})
+	ladderTestCheckOrder(t, rows, "3", "2", "4", "1")
+	if rows[3].Points != -1 || rows[3].Adjustment != -4 {
+		t.Errorf("row 1 = %+v, want -1 points after the adjustment", rows[3])
+	}
+}
+
+func TestBuildLadderByes(t *testing.T) {
+
+	// 4 has the bye in round 1, nobody has played round 2 yet
+	matches := []LadderMatch{
+		ladderTestMatch(1, 1, 2, 20, 10),
+		{RoundID: 2, HomeTeamID: 4, AwayTeamID: 1},
+		{RoundID: 2, HomeTeamID: 2, AwayTeamID: 3},
+	}
+
+	rows := BuildLadder(LadderSports[7], testTeams(ladderTestTeams...), matches, nil)
+	byes := map[string]LadderRow{}
+	for _, row := range rows {
+		byes[row.Team.TeamInternalID] = row
+	}
+	// 3 has no match in round 1 either
+	if byes["4"].Byes != 1 || byes["4"].Points != 2 || byes["4"].Played != 0 || byes["3"].Byes != 1 {
+		t.Errorf("rows = %+v, want a bye for 3 and 4", rows)
+	}
+	if byes["1"].Byes != 0 || byes["1"].Points != 2 || byes["2"].Points != 0 {
+		t.Errorf("rows = %+v, want no byes for 1 and 2", rows)
+	}
+
+	// the AFL has no bye points
+	rows = BuildLadder(LadderSports[1], testTeams(ladderTestTeams...), matches, nil)
+	for _, row := range rows {
+		if row.Byes != 0 {
+			t.Errorf("afl row %+v has a bye", row)
+		}
+	}
+}
+
+func TestLadderTeams(t *testing.T) {
+
+	teams := testTeams(ladderTestTeams...)
+	teams[0].Conference, teams[1].Conference, teams[2].Conference = "East", "West", "East"
+	matches := []LadderMatch{ladderTestMatch(1, 1, 2, 80, 70), {RoundID: 2, HomeTeamID: 3, AwayTeamID: 1}}
+
+	// 4 has no match in the season
+	if got := LadderTeams(teams, matches, "", ""); len(got) != 3 || got[2].TeamInternalID != "3" {
+		t.Errorf("teams = %+v, want 1, 2 and 3", got)
+	}
+	if got := LadderTeams(teams, matches, "East", ""); len(got) != 2 || got[0].TeamInternalID != "1" || got[1].TeamInternalID != "3" {
+		t.Errorf("East teams = %+v, want 1 and 3", got)
+	}
+	if got := LadderTeams(teams, matches, "", "North"); len(got) != 0 {
+		t.Errorf("North teams = %+v, want none", got)
+	}
+}
+
+func TestLadderAdjustments(t *testing.T) {
+
+	adjustments := LadderAdjustments([]PointsAdjustments{
+		{SeasonID: 2024, TeamID: "1", Points: -4},
+		{SeasonID: 2024, TeamID: "1", Points: 2},
+		{SeasonID: 2023, TeamID: "2", Points: -8},
+	}, 2024)
+	if len(adjustments) != 1 || adjustments[1] != -2 {
+		t.Errorf("adjustments = %v, want team 1 on -2", adjustments)
+	}
+}
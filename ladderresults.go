@@ -0,0 +1,56 @@
+/*
+Package data - Handles functions related to data source access e.g. cache, databases
+*/
+package data
+
+import (
+	"github.com/thegeniusgroup/isgdatalib"
+)
+
+// ladderFinalsColumn : flag of the finals rounds in the round tables of the ladder sports
+const ladderFinalsColumn = "finals"
+
+// GetLadderMatches : home and away matches of a league season with the final scores of the played ones. Finals are
+// left out by the finals flag of their round.
+func GetLadderMatches(objSport isg.Sport, objLeague isg.League, seasonID int) ([]isg.LadderMatch, error) {
+
+	var records []isg.LadderMatch
+
+	objRound := GetRoundWeekTable(objSport, objLeague)
+	if objRound.Column == "" {
+		return records, nil
+	}
+
+	sqlstr := "SELECT matches.match_id, IFNULL(matches." + objRound.Column + ",0), matches.home_team_id, matches.away_team_id, " +
+		" IFNULL(scores.home_score,0), IFNULL(scores.away_score,0), IFNULL(matches.status = ? AND scores.match_id IS NOT NULL,0) " +
+		" FROM " + objSport.TableNameMatches + " AS matches " +
+		" LEFT JOIN " + objSport.TableNameMatches + "_scores AS scores ON scores.match_id = matches.match_id " +
+		" LEFT JOIN " + objRound.Table + " AS rounds ON rounds." + objRound.Column + " = matches." + objRound.Column + " AND rounds.season_id = matches.season_id " +
+		" WHERE matches.league_id = ? AND matches.season_id = ? AND IFNULL(rounds." + ladderFinalsColumn + ",0) = 0 " +
+		" ORDER BY matches.counter_date, matches.counter_time, matches.match_id "
+
+	rows, err := SportsDb.Query(sqlstr, "N", objLeague.LeagueInternalID, seasonID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var record isg.LadderMatch
+		err = rows.Scan(
+			&record.MatchID,
+			&record.RoundID,
+			&record.HomeTeamID,
+			&record.AwayTeamID,
+			&record.HomeScore,
+			&record.AwayScore,
+			&record.Played,
+		)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+
+	return records, nil
+}
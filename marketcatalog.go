@@ -0,0 +1,95 @@
+/*
+Package data - Handles functions related to data source access e.g. cache, databases
+*/
+package data
+
+import (
+	"strconv"
+
+	"github.com/thegeniusgroup/isgdatalib"
+)
+
+// GetGeniusOddsMarketCatalog : markets priced for the upcoming matches of a league with the providers pricing them, matchID narrows it to one match
+func GetGeniusOddsMarketCatalog(objSport isg.Sport, leagueID, matchID int) ([]isg.GeniusOddsCatalogMarket, error) {
+
+	var catalog []isg.GeniusOddsCatalogMarket
+	var sqlstr, searchStr, displayStr string
+
+	// display names hold the team / player of the selection, they only mean something for a single match
+	searchStr = geniusOddsWindowSQL(isg.GeniusOddsWindow{})
+	displayStr = "''"
+	if matchID != 0 {
+		searchStr = "matches.match_id = " + strconv.Itoa(matchID) + " AND "
+		displayStr = "IFNULL(marketodds.market_display_name,'')"
+	}
+
+	// tennis selections are the players of the match
+	sportID := objSport.SportInternalID
+	homeColumn, awayColumn, leagueColumn := geniusOddsMatchColumns(sportID)
+	teamTable, teamKey, teamName, teamAbbr := "isg_team", "team_id", "team_name", "abbreviation"
+	if sportID == 6 {
+		teamTable, teamKey, teamName, teamAbbr = "isg_tennis_players", "player_id", "full_name", "short_name"
+	}
+
+	switch {
+
+	case IsGeniusOddsSport(sportID):
+
+		// one row per market, selection and provider, the market and provider columns are the same on every row of a group
+		// and the team names only come from the single match of a match catalog
+		sqlstr = "SELECT market.market_id, MIN(market.isg_api_id), MIN(marketmap.market_name), MIN(IFNULL(map.market_name,'')), MIN(IFNULL(isg_market_category_group.group_name,'')), " +
+			displayStr + " AS display_name, MIN(IFNULL(home." + teamName + ",'')), MIN(IFNULL(home." + teamAbbr + ",'')), MIN(IFNULL(away." + teamName + ",'')), " +
+			" MIN(IFNULL(away." + teamAbbr + ",'')), " +
+			" marketodds.provider_id, MIN(IFNULL(provider.provider_name,'')), MIN(IFNULL(provider.provider_url,'')), MIN(IFNULL(provider.provider_icon,'')), COUNT(DISTINCT matches.match_id) " +
+			" FROM " + objSport.TableNameMatches + " AS matches " +
+			" INNER JOIN isg_geniusodds_marketodds marketodds ON marketodds.match_id = matches.match_id AND marketodds.sport_id = ? AND marketodds.league_level_id = ? " +
+			" AND marketodds.provider_id != ? " +
+			" INNER JOIN isg_market market ON market.market_id = marketodds.market_id " +
+			" INNER JOIN isg_geniusodds_markets_mapping AS marketmap ON marketmap.market_id = market.market_id " +
+			" LEFT JOIN isg_geniusodds_markets_mapping AS map ON map.mapping_id = marketmap.parent_id " +
+			" LEFT JOIN isg_market_category_group ON isg_market_category_group.group_id = map.group_id " +
+			" LEFT JOIN isg_providers provider ON marketodds.provider_id = provider.provider_id " +
+			" LEFT JOIN " + teamTable + " AS home ON home." + teamKey + " = " + homeColumn +
+			" LEFT JOIN " + teamTable + " AS away ON away." + teamKey + " = " + awayColumn +
+			" WHERE " + searchStr + " matches.status = ? AND " + leagueColumn + " = ? AND marketodds.`status`= ? " +
+			" GROUP BY market.market_id, display_name, marketodds.provider_id " +
+			" ORDER BY MIN(isg_market_category_group.group_id), MIN(map.sequence), MIN(marketmap.sequence), market.market_id, display_name, " +
+			" MIN(IFNULL(provider.genius_odds_sequence, 0)), marketodds.provider_id "
+	default:
+		return catalog, nil
+	}
+
+	rows, err := SportsDb.Query(sqlstr, sportID, leagueID, 4, "Y", leagueID, 1)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var record isg.GeniusOddsCatalogMarket
+		err = rows.Scan(
+			&record.MarketID,
+			&record.ISGapiID,
+			&record.MarketName,
+			&record.CategoryName,
+			&record.GroupName,
+			&record.DisplayName,
+			&record.HomeTeamName,
+			&record.HomeTeamAbbr,
+			&record.AwayTeamName,
+			&record.AwayTeamAbbr,
+			&record.ProviderInfo.ProviderID,
+			&record.ProviderInfo.Name,
+			&record.ProviderInfo.URL,
+			&record.ProviderInfo.Icon,
+			&record.Matches,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		catalog = append(catalog, record)
+	}
+
+	return catalog, nil
+}
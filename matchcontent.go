@@ -0,0 +1,105 @@
+/*
+Package data - Handles functions related to data source access e.g. cache, databases
+*/
+package data
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/thegeniusgroup/isgdatalib"
+)
+
+// matchIDList : comma separated match ids for an IN clause
+func matchIDList(matchIDs []int64) string {
+	ids := make([]string, len(matchIDs))
+	for i, matchID := range matchIDs {
+		ids[i] = strconv.FormatInt(matchID, 10)
+	}
+	return strings.Join(ids, ",")
+}
+
+// GetGeniusOddsMatchPreviews : written previews of the matches of a league, keyed by match id
+func GetGeniusOddsMatchPreviews(objSport isg.Sport, objLeague isg.League, matchIDs []int64) (map[int64]string, error) {
+
+	previews := map[int64]string{}
+
+	tableName, perLeague := matchPreviewTable(objSport.SportInternalID)
+	if tableName == "" || len(matchIDs) == 0 {
+		return previews, nil
+	}
+
+	sqlstr := "SELECT match_id, IFNULL(description,'') FROM " + tableName + " WHERE match_id IN (" + matchIDList(matchIDs) + ") AND content_id = ? "
+	if perLeague {
+		sqlstr += " AND league_id = " + strconv.Itoa(objLeague.LeagueInternalID)
+	}
+
+	rows, err := SportsDb.Query(sqlstr, 1)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var matchID int64
+		var preview string
+		err = rows.Scan(&matchID, &preview)
+		if err != nil {
+			return nil, err
+		}
+		previews[matchID] = preview
+	}
+
+	return previews, nil
+}
+
+// GetGeniusOddsMatchTips : provider tips of the matches of a league from the sport's tips table, keyed by match id.
+// The tips of the matches of a page come in one query, like GetSportsMatchTips for one match. A sport without a tips table has no tips.
+func GetGeniusOddsMatchTips(objSport isg.Sport, objLeague isg.League, matchIDs []int64) (map[int64][]isg.Tips, error) {
+
+	tips := map[int64][]isg.Tips{}
+
+	tableName := objSport.TableNameTips
+	if tableName == "" || len(matchIDs) == 0 {
+		return tips, nil
+	}
+
+	league := " AND tips.league_id = ? "
+	if objSport.SportInternalID == 6 {
+		league = " AND tips.level_id = ? "
+	}
+
+	sqlstr := "SELECT tips.match_id, tips.title, COALESCE(tip, ''), COALESCE(roughie, ''), COALESCE(options.option_1, ''), COALESCE(options.option_2, ''), " +
+		" COALESCE(provider.provider_name, '') " +
+		" FROM " + tableName + " AS tips " +
+		" LEFT JOIN isg_tips_options options ON options.sport_id = ? AND options.provider_id = tips.provider_id " +
+		" LEFT JOIN isg_providers provider ON provider.provider_id = tips.provider_id " +
+		" WHERE tips.match_id IN (" + matchIDList(matchIDs) + ") " + league +
+		" ORDER BY tips.match_id, provider.genius_odds_sequence, tips.provider_id "
+
+	rows, err := SportsDb.Query(sqlstr, objSport.SportInternalID, objLeague.LeagueInternalID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var matchID int64
+		var objtips isg.Tips
+		err = rows.Scan(
+			&matchID,
+			&objtips.TipsTitle,
+			&objtips.Tips,
+			&objtips.Roughie,
+			&objtips.Option1,
+			&objtips.Option2,
+			&objtips.ProviderName,
+		)
+		if err != nil {
+			return nil, err
+		}
+		tips[matchID] = append(tips[matchID], objtips)
+	}
+
+	return tips, nil
+}
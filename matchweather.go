@@ -0,0 +1,124 @@
+/*
+Package data - Handles functions related to data source access e.g. cache, databases
+*/
+package data
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/thegeniusgroup/isgdatalib"
+)
+
+// weatherCacheTable : cache table of the sport holding the forecast columns (weather_* in schema.sql), match_weather is
+// left to the feed
+func weatherCacheTable(sportID int) string {
+	switch sportID {
+	case 1:
+		return "isg_aussie_rules_cache"
+	case 7:
+		return "isg_rugby_league_cache"
+	case 10:
+		return "isg_rugby_union_cache"
+	}
+	return ""
+}
+
+// GetWeatherForecastMatches : upcoming matches of a league kicking off before the window ends, with their venue location
+func GetWeatherForecastMatches(objSport isg.Sport, leagueID int, window time.Duration) ([]isg.WeatherMatch, error) {
+
+	var records []isg.WeatherMatch
+
+	if weatherCacheTable(objSport.SportInternalID) == "" {
+		return records, nil
+	}
+
+	currentDateTime := time.Now().UTC()
+	searchStr := "concat(matches.counter_date, ' ', matches.counter_time) BETWEEN '" + CounterDateTimeString(currentDateTime) + "' AND " +
+		" '" + CounterDateTimeString(currentDateTime.Add(window)) + "' AND "
+
+	sqlstr := "SELECT matches.match_id, matches.venue_id, venue.latitude, venue.longitude, matches.counter_date, matches.counter_time " +
+		" FROM " + objSport.TableNameMatches + " AS matches " +
+		" INNER JOIN isg_venue venue ON venue.venue_id = matches.venue_id " +
+		" WHERE " + searchStr + " matches.status = ? AND matches.league_id = ? AND IFNULL(venue.latitude,'') != '' AND IFNULL(venue.longitude,'') != '' " +
+		" ORDER BY matches.counter_date, matches.counter_time "
+
+	rows, err := SportsDb.Query(sqlstr, "Y", leagueID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var record isg.WeatherMatch
+		var counterDate, counterTime string
+		err = rows.Scan(&record.MatchID, &record.VenueID, &record.Latitude, &record.Longitude, &counterDate, &counterTime)
+		if err != nil {
+			return nil, err
+		}
+		record.KickOff, err = GetCounterDateTime(counterDate, counterTime)
+		if err != nil {
+			fmt.Println(err.Error())
+			continue
+		}
+		record.SportID = objSport.SportInternalID
+		record.LeagueID = leagueID
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+// UpdateMatchWeather : stores the forecast in the weather columns of the sport's cache table
+func UpdateMatchWeather(objSport isg.Sport, matchID int64, objForecast isg.WeatherForecast) error {
+
+	tableName := weatherCacheTable(objSport.SportInternalID)
+	if tableName == "" {
+		return nil
+	}
+
+	stmt, err := SportsDb.Prepare("UPDATE " + tableName + " SET weather_summary = ?, weather_temperature = ?, weather_wind_speed = ?, weather_precip = ?, " +
+		" weather_precip_chance = ?, weather_updated = ? WHERE match_id = ?")
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	_, err = stmt.Exec(objForecast.Summary, objForecast.Temperature, objForecast.WindSpeed, objForecast.Precip, objForecast.PrecipChance,
+		CounterDateTimeString(time.Now()), matchID)
+	return err
+}
+
+// GetGeniusOddsMatchWeather : forecast weather of the matches of a sport from its cache table, keyed by match id
+func GetGeniusOddsMatchWeather(objSport isg.Sport, matchIDs []int64) (map[int64]isg.WeatherInfo, error) {
+
+	weather := map[int64]isg.WeatherInfo{}
+
+	tableName := weatherCacheTable(objSport.SportInternalID)
+	if tableName == "" || len(matchIDs) == 0 {
+		return weather, nil
+	}
+
+	sqlstr := "SELECT match_id, IFNULL(weather_summary,''), weather_temperature, weather_wind_speed, weather_precip, weather_precip_chance, " +
+		" IFNULL(weather_updated,'') FROM " + tableName + " WHERE match_id IN (" + matchIDList(matchIDs) + ") AND weather_updated IS NOT NULL "
+
+	rows, err := SportsDb.Query(sqlstr)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var matchID int64
+		var updated string
+		var objForecast isg.WeatherForecast
+		err = rows.Scan(&matchID, &objForecast.Summary, &objForecast.Temperature, &objForecast.WindSpeed, &objForecast.Precip,
+			&objForecast.PrecipChance, &updated)
+		if err != nil {
+			return nil, err
+		}
+		weather[matchID] = isg.BindingWeatherInfo(objForecast, updated)
+	}
+
+	return weather, nil
+}
@@ -0,0 +1,55 @@
+/*
+Package data - Handles functions related to data source access e.g. cache, databases
+*/
+package data
+
+import (
+	"time"
+
+	"github.com/thegeniusgroup/isgdatalib"
+)
+
+// GetGeniusOddsModelResults : final scores of a league within the lookback window in kick off order, used to build the genius odds model ratings
+func GetGeniusOddsModelResults(objSport isg.Sport, leagueID int, lookback time.Duration) ([]isg.GeniusOddsModelResult, error) {
+
+	var results []isg.GeniusOddsModelResult
+
+	if _, ok := isg.GeniusOddsModelSports[objSport.SportInternalID]; !ok {
+		return results, nil
+	}
+
+	currentDateTime := time.Now().UTC()
+	searchStr := "concat(matches.counter_date, ' ', matches.counter_time) BETWEEN '" + CounterDateTimeString(currentDateTime.Add(-lookback)) + "' AND " +
+		" '" + CounterDateTimeString(currentDateTime) + "' AND "
+
+	sqlstr := "SELECT matches.match_id, matches.season_id, matches.home_team_id, matches.away_team_id, scores.home_score, scores.away_score, matches.match_date " +
+		" FROM " + objSport.TableNameMatches + " AS matches " +
+		" INNER JOIN " + objSport.TableNameMatches + "_scores AS scores ON scores.match_id = matches.match_id " +
+		" WHERE " + searchStr + " matches.league_id = ? AND matches.status = ? " +
+		" ORDER BY matches.counter_date, matches.counter_time, matches.match_id "
+
+	rows, err := SportsDb.Query(sqlstr, leagueID, "N")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var result isg.GeniusOddsModelResult
+		err = rows.Scan(
+			&result.MatchID,
+			&result.SeasonID,
+			&result.HomeTeamID,
+			&result.AwayTeamID,
+			&result.HomeScore,
+			&result.AwayScore,
+			&result.MatchDate,
+		)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, result)
+	}
+
+	return results, nil
+}
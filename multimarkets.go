@@ -0,0 +1,69 @@
+/*
+Package data - Handles functions related to data source access e.g. cache, databases
+*/
+package data
+
+import (
+	"github.com/thegeniusgroup/isgdatalib"
+)
+
+// GetGeniusOddsMultiMarkets : live provider prices of the upcoming matches of a multi, team and match markets only
+func GetGeniusOddsMultiMarkets(objSport isg.Sport, matchIDs []int64) ([]isg.GeniusOddsMarket, error) {
+
+	var records []isg.GeniusOddsMarket
+	var sqlstr string
+	sportID := objSport.SportInternalID
+
+	if len(matchIDs) == 0 || !IsGeniusOddsSport(sportID) {
+		return records, nil
+	}
+
+	// tennis players are the home and away side
+	homeColumn, awayColumn, _ := geniusOddsMatchColumns(sportID)
+	sqlstr = "SELECT matches.match_id, " + homeColumn + ", " + awayColumn + ", market.market_id, market.market_name, IFNULL(marketcategory.category_id,0), " +
+		" IFNULL(marketcategory.category_name,''), market.isg_api_id, marketodds.team_id, marketodds.market_price, marketodds.market_val, marketodds.provider_market_id, " +
+		" marketodds.provider_id, IFNULL(provider.provider_name,''), IFNULL(provider.provider_url,''), IFNULL(provider.provider_icon,''), IFNULL(provider.genius_odds_sequence, 0) " +
+		" FROM " + objSport.TableNameMatches + " AS matches " +
+		" INNER JOIN isg_geniusodds_marketodds marketodds ON marketodds.match_id = matches.match_id AND marketodds.sport_id = ? " +
+		" AND marketodds.provider_id != ? " +
+		" INNER JOIN isg_market market ON market.market_id = marketodds.market_id " +
+		" LEFT JOIN isg_market_category marketcategory ON marketcategory.category_id = market.category_id " +
+		" LEFT JOIN isg_providers provider ON marketodds.provider_id = provider.provider_id " +
+		" WHERE matches.match_id IN (" + matchIDList(matchIDs) + ") AND matches.status = ? AND marketodds.`status` = ? AND IFNULL(marketodds.player_id, 0) = 0 " +
+		" ORDER BY matches.match_id, marketodds.provider_id, market.category_id, market.market_id "
+
+	rows, err := SportsDb.Query(sqlstr, sportID, 4, "Y", 1)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var record isg.GeniusOddsMarket
+		err = rows.Scan(
+			&record.MatchID,
+			&record.HomeTeamID,
+			&record.AwayTeamID,
+			&record.MarketID,
+			&record.MarketName,
+			&record.CategoryID,
+			&record.CategoryName,
+			&record.ISGapiID,
+			&record.MarketTeamID,
+			&record.MarketPrice,
+			&record.MarketVal,
+			&record.ProviderMarketID,
+			&record.ProviderInfo.ProviderId,
+			&record.ProviderInfo.Name,
+			&record.ProviderInfo.URL,
+			&record.ProviderInfo.Icon,
+			&record.ProviderInfo.GeniusOddsSequence,
+		)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+
+	return records, nil
+}
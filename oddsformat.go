@@ -0,0 +1,215 @@
+package isg
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Supported odds formats for the odds_format parameter
+const (
+	OddsFormatDecimal    = "decimal"
+	OddsFormatFractional = "fractional"
+	OddsFormatAmerican   = "american"
+	OddsFormatHongKong   = "hongkong"
+	OddsFormatMalay      = "malay"
+	OddsFormatIndonesian = "indonesian"
+)
+
+// oddsFormatNames : accepted names and short codes of each format
+var oddsFormatNames = map[string]string{
+	"":           OddsFormatDecimal,
+	"decimal":    OddsFormatDecimal,
+	"eu":         OddsFormatDecimal,
+	"fractional": OddsFormatFractional,
+	"fraction":   OddsFormatFractional,
+	"uk":         OddsFormatFractional,
+	"american":   OddsFormatAmerican,
+	"us":         OddsFormatAmerican,
+	"moneyline":  OddsFormatAmerican,
+	"hongkong":   OddsFormatHongKong,
+	"hk":         OddsFormatHongKong,
+	"malay":      OddsFormatMalay,
+	"my":         OddsFormatMalay,
+	"indonesian": OddsFormatIndonesian,
+	"indo":       OddsFormatIndonesian,
+	"id":         OddsFormatIndonesian,
+}
+
+// fractionalOdds : traditional fractional prices, the nearest one is used when it is within a cent of the decimal price
+var fractionalOdds = [][2]int{
+	{1, 100}, {1, 50}, {1, 33}, {1, 25}, {1, 20}, {1, 16}, {1, 14}, {1, 12}, {1, 11}, {1, 10}, {1, 9}, {1, 8}, {2, 15}, {1, 7}, {2, 13}, {1, 6},
+	{2, 11}, {1, 5}, {2, 9}, {1, 4}, {2, 7}, {3, 10}, {1, 3}, {4, 11}, {2, 5}, {4, 9}, {1, 2}, {8, 15}, {4, 7}, {8, 13}, {4, 6}, {8, 11}, {4, 5},
+	{5, 6}, {10, 11}, {1, 1}, {21, 20}, {11, 10}, {6, 5}, {5, 4}, {11, 8}, {6, 4}, {8, 5}, {13, 8}, {7, 4}, {9, 5}, {15, 8}, {2, 1}, {85, 40},
+	{9, 4}, {5, 2}, {11, 4}, {3, 1}, {10, 3}, {7, 2}, {4, 1}, {9, 2}, {5, 1}, {11, 2}, {6, 1}, {13, 2}, {7, 1}, {15, 2}, {8, 1}, {17, 2}, {9, 1},
+	{10, 1}, {11, 1}, {12, 1}, {14, 1}, {16, 1}, {18, 1}, {20, 1}, {25, 1}, {33, 1}, {40, 1}, {50, 1}, {66, 1}, {80, 1}, {100, 1},
+}
+
+// GetOddsFormat : validates the odds_format value and returns the format name
+func GetOddsFormat(format string) (string, error) {
+	oddsFormat, ok := oddsFormatNames[strings.ToLower(strings.TrimSpace(format))]
+	if !ok {
+		return "", errors.New(ISGErrBadInputPrefix + "invalid odds format " + format)
+	}
+	return oddsFormat, nil
+}
+
+// ConvertOdds : converts a decimal price into the requested format, e.g. 2.5 is "6/4", "+150", "1.50", "-0.67" or "+1.50"
+func ConvertOdds(price *float64, oddsFormat string) string {
+
+	if price == nil {
+		return ""
+	}
+	odds := *price
+	if odds <= 1 {
+		return strconv.FormatFloat(odds, 'f', 2, 64)
+	}
+
+	switch oddsFormat {
+	case OddsFormatFractional:
+		return decimalToFractional(odds)
+	case OddsFormatAmerican:
+		if odds >= 2 {
+			return "+" + strconv.Itoa(int(Round((odds-1)*100, .5, 0)))
+		}
+		return strconv.Itoa(int(Round(-100/(odds-1), .5, 0)))
+	case OddsFormatHongKong:
+		return strconv.FormatFloat(Round(odds-1, .5, 2), 'f', 2, 64)
+	case OddsFormatMalay:
+		if odds > 2 {
+			return strconv.FormatFloat(Round(-1/(odds-1), .5, 2), 'f', 2, 64)
+		}
+		return strconv.FormatFloat(Round(odds-1, .5, 2), 'f', 2, 64)
+	case OddsFormatIndonesian:
+		if odds >= 2 {
+			return "+" + strconv.FormatFloat(Round(odds-1, .5, 2), 'f', 2, 64)
+		}
+		return strconv.FormatFloat(Round(-1/(odds-1), .5, 2), 'f', 2, 64)
+	}
+
+	return strconv.FormatFloat(Round(odds, .5, 2), 'f', 2, 64)
+}
+
+// decimalToFractional : table lookup first, otherwise the reduced fraction of the price rounded to cents
+func decimalToFractional(odds float64) string {
+
+	profit := odds - 1
+	best := -1
+	bestDiff := math.MaxFloat64
+	for i, frac := range fractionalOdds {
+		diff := math.Abs(profit - float64(frac[0])/float64(frac[1]))
+		if diff < bestDiff {
+			best = i
+			bestDiff = diff
+		}
+	}
+	if best >= 0 && bestDiff < 0.01 {
+		return strconv.Itoa(fractionalOdds[best][0]) + "/" + strconv.Itoa(fractionalOdds[best][1])
+	}
+
+	numerator := int(Round(profit*100, .5, 0))
+	denominator := 100
+	divisor := gcd(numerator, denominator)
+	return strconv.Itoa(numerator/divisor) + "/" + strconv.Itoa(denominator/divisor)
+}
+
+// gcd : greatest common divisor
+func gcd(a, b int) int {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+// FormatGeniusOddsPrices : adds the formatted prices and flucs of the requested format to a provider price.
+// Flucs of line and total markets hold the line moves, so they are left as they are.
+func FormatGeniusOddsPrices(odds *OddsInfo, oddsFormat string) {
+
+	if oddsFormat == "" || oddsFormat == OddsFormatDecimal {
+		return
+	}
+
+	odds.OddsFormat = oddsFormat
+	odds.OpenOddsFmt = ConvertOdds(odds.OpenOdds, oddsFormat)
+	odds.NewOddsFmt = ConvertOdds(odds.NewOdds, oddsFormat)
+
+	if odds.NewLine != nil || odds.NewTotal != nil {
+		return
+	}
+
+	odds.FlucsFmt = []string{}
+	for _, fluc := range odds.Flucs {
+		odds.FlucsFmt = append(odds.FlucsFmt, ConvertOdds(fluc, oddsFormat))
+	}
+}
+
+// formatMarketOddsList : formats every provider price of a market option
+func formatMarketOddsList(objList *MarketOddsList, oddsFormat string) {
+	if objList == nil {
+		return
+	}
+	for i := range objList.ProviderList {
+		FormatGeniusOddsPrices(&objList.ProviderList[i], oddsFormat)
+	}
+}
+
+// FormatGeniusOddsMatchesInfo : applies the odds format to all markets, groups and plunge odds of a match
+func FormatGeniusOddsMatchesInfo(matchesInfo *GeniusOddsMatchesInfo, oddsFormat string) {
+
+	if oddsFormat == "" || oddsFormat == OddsFormatDecimal {
+		return
+	}
+
+	for i := range matchesInfo.Market {
+		for j := range matchesInfo.Market[i].MarketOption {
+			formatMarketOddsList(matchesInfo.Market[i].MarketOption[j].GeniusHomeMarketOdds, oddsFormat)
+			formatMarketOddsList(matchesInfo.Market[i].MarketOption[j].GeniusAwayMarketOdds, oddsFormat)
+			formatMarketOddsList(matchesInfo.Market[i].MarketOption[j].GeniusAnyMarketOdds, oddsFormat)
+		}
+	}
+
+	for i := range matchesInfo.Groups {
+		for j := range matchesInfo.Groups[i].Market {
+			for k := range matchesInfo.Groups[i].Market[j].MatchMarketOption {
+				formatMarketOddsList(&matchesInfo.Groups[i].Market[j].MatchMarketOption[k], oddsFormat)
+			}
+		}
+	}
+
+	formatMarketOddsList(matchesInfo.GeniusOddsPlung, oddsFormat)
+}
+
+// FormatCorrectScoreGrids : applies the odds format to the grid prices and the fair prices of the derived markets
+func FormatCorrectScoreGrids(grids []CorrectScoreGrid, oddsFormat string) {
+
+	if oddsFormat == "" || oddsFormat == OddsFormatDecimal {
+		return
+	}
+
+	for i := range grids {
+		for _, row := range grids[i].Grid {
+			for _, cell := range row {
+				if cell == nil {
+					continue
+				}
+				cell.OddsFormat = oddsFormat
+				cell.OddsFmt = ConvertOdds(cell.Odds, oddsFormat)
+			}
+		}
+
+		derived := &grids[i].Derived
+		probs := []*CorrectScoreProb{&derived.HomeWin, &derived.Draw, &derived.AwayWin, derived.BTTSYes, derived.BTTSNo}
+		for j := range derived.Totals {
+			probs = append(probs, &derived.Totals[j].Over, &derived.Totals[j].Under)
+		}
+		for j := range derived.Margins {
+			probs = append(probs, &derived.Margins[j].Prob)
+		}
+		for _, prob := range probs {
+			if prob != nil {
+				prob.FairPriceFmt = ConvertOdds(prob.FairPrice, oddsFormat)
+			}
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package isg
+
+import "testing"
+
+func TestGetOddsFormat(t *testing.T) {
+
+	tests := []struct {
+		format string
+		want   string
+		err    bool
+	}{
+		{"", OddsFormatDecimal, false},
+		{"UK", OddsFormatFractional, false},
+		{" us ", OddsFormatAmerican, false},
+		{"hk", OddsFormatHongKong, false},
+		{"my", OddsFormatMalay, false},
+		{"indo", OddsFormatIndonesian, false},
+		{"roman", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := GetOddsFormat(tt.format)
+		if (err != nil) != tt.err || got != tt.want {
+			t.Errorf("GetOddsFormat(%q) = %q, %v, want %q", tt.format, got, err, tt.want)
+		}
+	}
+}
+
+func TestConvertOddsFractional(t *testing.T) {
+
+	tests := []struct {
+		price float64
+		want  string
+	}{
+		{4.33, "10/3"},
+		{4.34, "10/3"},
+		{2.5, "6/4"},
+		{1.5, "1/2"},
+		{2, "1/1"},
+		{1.91, "10/11"},
+		{3.125, "85/40"},
+		{101, "100/1"},
+		// no traditional price within a cent, reduced fraction of the price
+		{3.14, "107/50"},
+		{1.01, "1/100"},
+		{1, "1.00"},
+	}
+
+	for _, tt := range tests {
+		price := tt.price
+		if got := ConvertOdds(&price, OddsFormatFractional); got != tt.want {
+			t.Errorf("ConvertOdds(%v, fractional) = %q, want %q", tt.price, got, tt.want)
+		}
+	}
+}
+
+func TestConvertOddsAmerican(t *testing.T) {
+
+	tests := []struct {
+		price float64
+		want  string
+	}{
+		{2.5, "+150"},
+		{2, "+100"},
+		{1.5, "-200"},
+		{1.91, "-110"},
+		{1.333, "-300"},
+		{4.335, "+334"},
+		{1.995, "-101"},
+		{11, "+1000"},
+	}
+
+	for _, tt := range tests {
+		price := tt.price
+		if got := ConvertOdds(&price, OddsFormatAmerican); got != tt.want {
+			t.Errorf("ConvertOdds(%v, american) = %q, want %q", tt.price, got, tt.want)
+		}
+	}
+}
+
+func TestConvertOddsAsian(t *testing.T) {
+
+	tests := []struct {
+		price  float64
+		format string
+		want   string
+	}{
+		{2.5, OddsFormatHongKong, "1.50"},
+		{2.5, OddsFormatMalay, "-0.67"},
+		{1.8, OddsFormatMalay, "0.80"},
+		{2.5, OddsFormatIndonesian, "+1.50"},
+		{1.5, OddsFormatIndonesian, "-2.00"},
+		{2.505, OddsFormatDecimal, "2.51"},
+	}
+
+	for _, tt := range tests {
+		price := tt.price
+		if got := ConvertOdds(&price, tt.format); got != tt.want {
+			t.Errorf("ConvertOdds(%v, %s) = %q, want %q", tt.price, tt.format, got, tt.want)
+		}
+	}
+
+	if got := ConvertOdds(nil, OddsFormatAmerican); got != "" {
+		t.Errorf("ConvertOdds(nil) = %q, want empty", got)
+	}
+}
@@ -0,0 +1,131 @@
+/*
+Package data - Handles functions related to data source access e.g. cache, databases
+*/
+package data
+
+import (
+	"strconv"
+
+	"github.com/thegeniusgroup/isgdatalib"
+)
+
+// GetMarketMatchesPlayerOdds : player market prices of a match (first goal scorer, anytime try scorer, disposals) joined to the
+// sport's players table. The selections make up the "Player" group of the markets page.
+func GetMarketMatchesPlayerOdds(marketFlucs []isg.GeniusOddsMarket, objSport isg.Sport, leagueID, matchID int, objFilter isg.GeniusOddsFilter) ([]isg.GeniusOddsMarket, error) {
+	var liveOdds []isg.GeniusOddsMarket
+	var sqlstr string
+
+	// tennis players are the selections of the match markets
+	if matchID == 0 || objSport.TableNamePlayers == "" || objSport.SportInternalID == 6 {
+		return liveOdds, nil
+	}
+
+	// the player markets are grouped as "Player" whatever their mapped group is
+	if len(objFilter.Groups) > 0 && !isg.CheckValueInArray(objFilter.Groups, "player") {
+		return liveOdds, nil
+	}
+	objFilter.Groups = nil
+	filterStr, filterArgs := geniusOddsFilterSQL(objFilter)
+
+	sportID := objSport.SportInternalID
+	switch sportID {
+
+	case 1, 7, 10:
+		sqlstr = " SELECT matches.match_id, matches.home_team_id, matches.away_team_id, market.market_id, marketodds.team_id, marketodds.market_price, marketodds.market_val, " +
+			" marketodds.provider_market_id, IFNULL(provider.provider_name,'') AS provider_name, IFNULL(provider.provider_icon,'') AS provider_icon, provider.provider_id, " +
+			" IFNULL(provider.genius_odds_sequence, 0), marketmap.market_name, IFNULL(map.market_name, marketmap.market_name) AS category_name, " +
+			" player.player_id, IFNULL(player.isg_api_id,''), IFNULL(player.full_name,''), IFNULL(player.position,''), " +
+			" IFNULL(team.isg_api_id,''), IFNULL(team.team_name,''), IFNULL(team.abbreviation,''), IFNULL(team.icon,'') " +
+			" FROM " + objSport.TableNameMatches + " AS matches " +
+			" INNER JOIN isg_geniusodds_marketodds marketodds ON marketodds.match_id = matches.match_id AND marketodds.sport_id = ? AND marketodds.league_level_id = ? " +
+			" AND marketodds.provider_id != ? " +
+			" INNER JOIN isg_market market ON market.market_id = marketodds.market_id " +
+			" INNER JOIN isg_geniusodds_markets_mapping AS marketmap ON marketmap.market_id = market.market_id " +
+			" LEFT JOIN isg_geniusodds_markets_mapping AS map ON map.mapping_id = marketmap.parent_id " +
+			" LEFT JOIN isg_market_category_group ON isg_market_category_group.group_id = map.group_id " +
+			" LEFT JOIN isg_providers provider ON marketodds.provider_id= provider.provider_id " +
+			" INNER JOIN " + objSport.TableNamePlayers + " AS player ON player.player_id = marketodds.player_id " +
+			" LEFT JOIN isg_team AS team ON team.team_id = marketodds.team_id " +
+			" WHERE matches.match_id = ? AND matches.status = ? AND matches.league_id = ? AND marketodds.`status`= ? " + filterStr +
+			" ORDER BY map.sequence, marketmap.sequence, market.market_id, player.full_name, marketodds.provider_id "
+	default:
+		return liveOdds, nil
+	}
+
+	args := append([]interface{}{sportID, leagueID, 4, matchID, "Y", leagueID, 1}, filterArgs...)
+	rows, err := SportsDb.Query(sqlstr, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+	for rows.Next() {
+		var odds isg.GeniusOddsMarket
+		var objPlayer isg.GeniusOddsPlayer
+		var objTeam isg.Team
+		err = rows.Scan(
+			&odds.MatchID,
+			&odds.HomeTeamID,
+			&odds.AwayTeamID,
+			&odds.MarketID,
+			&odds.MarketTeamID,
+			&odds.MarketPrice,
+			&odds.MarketVal,
+			&odds.ProviderMarketID,
+			&odds.ProviderInfo.Name,
+			&odds.ProviderInfo.Icon,
+			&odds.ProviderInfo.ProviderId,
+			&odds.ProviderInfo.GeniusOddsSequence,
+			&odds.MarketName,
+			&odds.CategoryName,
+			&objPlayer.PlayerInternalID,
+			&objPlayer.PlayerID,
+			&objPlayer.Name,
+			&objPlayer.Position,
+			&objTeam.TeamID,
+			&objTeam.TeamName,
+			&objTeam.Abbreviation,
+			&objTeam.TeamFlag,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		objTeam.TeamInternalID = strconv.Itoa(int(odds.MarketTeamID))
+		if objTeam.TeamID != "" {
+			objPlayer.TeamInfo = &objTeam
+		}
+		odds.PlayerID = objPlayer.PlayerInternalID
+		odds.PlayerInfo = &objPlayer
+		odds.GroupName = "Player"
+		odds.DisplayName = objPlayer.Name
+
+		var prevLine *float64
+		objFlucs := []*float64{}
+		for _, flucs := range marketFlucs {
+			if flucs.MatchID != odds.MatchID || flucs.MarketID != odds.MarketID || flucs.MarketTeamID != odds.MarketTeamID ||
+				flucs.PlayerID != odds.PlayerID || flucs.ProviderInfo.ProviderId != odds.ProviderInfo.ProviderId {
+				continue
+			}
+
+			// the first fluc is the open price
+			if len(objFlucs) == 0 {
+				odds.MarketFlucPrice = flucs.MarketPrice
+				odds.MarketFlucVal = flucs.MarketVal
+			}
+
+			if flucs.MarketVal != nil {
+				if (prevLine == nil) || (*prevLine != *flucs.MarketVal) {
+					objFlucs = append(objFlucs, flucs.MarketVal)
+					prevLine = flucs.MarketVal
+				}
+			} else {
+				objFlucs = append(objFlucs, flucs.MarketPrice)
+			}
+		}
+		odds.Flucs = objFlucs
+		liveOdds = append(liveOdds, odds)
+	}
+
+	return liveOdds, nil
+}
@@ -0,0 +1,71 @@
+/*
+Package data - Handles functions related to data source access e.g. cache, databases
+*/
+package data
+
+import (
+	"github.com/thegeniusgroup/isgdatalib"
+)
+
+// GetGeniusOddsQualityPrices : live provider prices of the upcoming matches of a league with their last update, checked by the price validator
+func GetGeniusOddsQualityPrices(objSport isg.Sport, leagueID int) ([]isg.GeniusOddsMarket, error) {
+
+	var records []isg.GeniusOddsMarket
+	var sqlstr string
+	sportID := objSport.SportInternalID
+
+	searchStr := geniusOddsWindowSQL(isg.GeniusOddsWindow{})
+	homeColumn, awayColumn, leagueColumn := geniusOddsMatchColumns(sportID)
+
+	switch {
+
+	case IsGeniusOddsSport(sportID):
+
+		sqlstr = "SELECT matches.match_id, " + homeColumn + ", " + awayColumn + ", market.market_id, market.market_name, IFNULL(marketcategory.category_id,0), " +
+			" IFNULL(marketcategory.category_name,''), market.isg_api_id, marketodds.team_id, IFNULL(marketodds.player_id, 0), marketodds.market_price, marketodds.market_val, " +
+			" marketodds.provider_id, IFNULL(provider.provider_name,''), IFNULL(marketodds.last_update,'') " +
+			" FROM " + objSport.TableNameMatches + " AS matches " +
+			" INNER JOIN isg_geniusodds_marketodds marketodds ON marketodds.match_id = matches.match_id AND marketodds.sport_id = ? AND marketodds.league_level_id = ? " +
+			" AND marketodds.provider_id != ? " +
+			" INNER JOIN isg_market market ON market.market_id = marketodds.market_id " +
+			" LEFT JOIN isg_market_category marketcategory ON marketcategory.category_id = market.category_id " +
+			" LEFT JOIN isg_providers provider ON marketodds.provider_id = provider.provider_id " +
+			" WHERE " + searchStr + " matches.status = ? AND " + leagueColumn + " = ? AND marketodds.`status` = ? " +
+			" ORDER BY matches.match_id, market.category_id, market.market_id, marketodds.provider_id "
+	default:
+		return records, nil
+	}
+
+	rows, err := SportsDb.Query(sqlstr, sportID, leagueID, 4, "Y", leagueID, 1)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var record isg.GeniusOddsMarket
+		err = rows.Scan(
+			&record.MatchID,
+			&record.HomeTeamID,
+			&record.AwayTeamID,
+			&record.MarketID,
+			&record.MarketName,
+			&record.CategoryID,
+			&record.CategoryName,
+			&record.ISGapiID,
+			&record.MarketTeamID,
+			&record.PlayerID,
+			&record.MarketPrice,
+			&record.MarketVal,
+			&record.ProviderInfo.ProviderId,
+			&record.ProviderInfo.Name,
+			&record.LastUpdate,
+		)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+
+	return records, nil
+}
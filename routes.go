@@ -25,5 +25,28 @@ import (
 	router.GET("/geniusodds/matches/:type/:sport/:league", sports.GeniusOddsFixtureList)
 	router.GET("/geniusodds/matches/:type/:sport/:league/:matchid", sports.GeniusOddsFixtureList)
 	router.GET("/geniusodds/markets/:sport/:league/:season/:round/:team1/:team2", sports.GeniusOddsMarketFixtureList)
-
-	
\ No newline at end of file
+	router.GET("/geniusodds/catalog/:sport/:league", sports.GeniusOddsMarketCatalog)
+	router.GET("/geniusodds/catalog/:sport/:league/:matchid", sports.GeniusOddsMarketCatalog)
+	router.GET("/geniusodds/match/:matchid", sports.GeniusOddsMarketByID)
+	router.GET("/geniusodds/event/:provider/:eventid", sports.GeniusOddsMarketByEvent)
+	router.GET("/geniusodds/clv/:sport", sports.GeniusOddsClosingLineValue)
+	router.GET("/geniusodds/clv/:sport/:league", sports.GeniusOddsClosingLineValue)
+	router.GET("/geniusodds/clv/:sport/:league/:season", sports.GeniusOddsClosingLineValue)
+	router.GET("/geniusodds/leaderboard/:sport", sports.GeniusOddsAccuracyLeaderboard)
+	router.GET("/geniusodds/leaderboard/:sport/:league", sports.GeniusOddsAccuracyLeaderboard)
+	router.GET("/geniusodds/leaderboard/:sport/:league/:season", sports.GeniusOddsAccuracyLeaderboard)
+	router.GET("/geniusodds/correctscore/:sport/:matchid", sports.GeniusOddsCorrectScore)
+	router.POST("/geniusodds/multi", sports.GeniusOddsMulti)
+	router.GET("/geniusodds/alerts", sports.GeniusOddsAlertRuleList)
+	router.POST("/geniusodds/alerts", sports.GeniusOddsAlertRuleCreate)
+	router.DELETE("/geniusodds/alerts/:ruleid", sports.GeniusOddsAlertRuleDelete)
+	router.GET("/geniusodds/admin/quality", sports.GeniusOddsQualityIssues)
+	router.GET("/geniusodds/admin/quality/:sport", sports.GeniusOddsQualityIssues)
+	router.GET("/resolve/team", sports.ResolveTeam)
+	router.GET("/form/:sport/:league/:team", sports.TeamForm)
+	router.GET("/venues/:sport", sports.Venues)
+	router.GET("/venues/:sport/:venue", sports.Venue)
+	router.GET("/ladder/:sport/:league/:season", sports.Ladder)
+	router.GET("/calendar/:sport/:league", sports.Calendar)
+	router.GET("/onthisday/:month/:day", sports.OnThisDay)
+	router.GET("/funfacts/:sport/:league/:matchid", sports.MatchFunFacts)
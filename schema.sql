@@ -0,0 +1,197 @@
+-- Tables and columns added for the genius odds jobs and routes.
+-- Each table is written in its current shape, apply the statements in order on a new environment.
+-- The error log tables of the crons keep the shape of the existing ones (see InsertErrorLog).
+
+-- ---------------------------------------------------------------------------
+-- Sports db
+-- ---------------------------------------------------------------------------
+
+-- Closing lines, one row per provider selection of a started match, written by ArchiveGeniusOddsClosingLines.
+-- The open price is the first fluc before the start and the close price the last one, re-runs update the row.
+CREATE TABLE isg_geniusodds_marketodds_closing (
+	match_id INT UNSIGNED NOT NULL,
+	sport_id INT UNSIGNED NOT NULL,
+	league_level_id INT UNSIGNED NOT NULL,
+	season_id INT UNSIGNED NOT NULL,
+	provider_id INT UNSIGNED NOT NULL,
+	market_id INT UNSIGNED NOT NULL,
+	team_id INT UNSIGNED NOT NULL DEFAULT 0,
+	category_name VARCHAR(100) NOT NULL DEFAULT '',
+	open_price DECIMAL(8,3) NULL,
+	open_val DECIMAL(6,1) NULL,
+	close_price DECIMAL(8,3) NULL,
+	close_val DECIMAL(6,1) NULL,
+	match_start DATETIME NOT NULL,      -- counter date and time (AEST)
+	dateadded DATETIME NOT NULL,
+	UNIQUE KEY selection (match_id, market_id, team_id, provider_id),
+	KEY sport_season (sport_id, league_level_id, season_id)
+);
+
+-- Settled selections of the played matches, written by InsertGeniusOddsSettlements and read by the accuracy leaderboard.
+-- outcome is -1 void, 0 lose, 1 win. Void and unpriced selections are stored with no scores so they are not settled again.
+CREATE TABLE isg_geniusodds_settlement (
+	match_id INT UNSIGNED NOT NULL,
+	sport_id INT UNSIGNED NOT NULL,
+	league_level_id INT UNSIGNED NOT NULL,
+	season_id INT UNSIGNED NOT NULL,
+	provider_id INT UNSIGNED NOT NULL,
+	market_id INT UNSIGNED NOT NULL,
+	team_id INT UNSIGNED NOT NULL DEFAULT 0,
+	category_name VARCHAR(100) NOT NULL DEFAULT '',
+	market_price DECIMAL(8,3) NULL,
+	market_val DECIMAL(6,1) NULL,
+	outcome TINYINT NOT NULL,
+	implied_prob DECIMAL(6,4) NOT NULL DEFAULT 0,
+	brier DECIMAL(6,4) NOT NULL DEFAULT 0,
+	log_loss DECIMAL(6,4) NOT NULL DEFAULT 0,
+	dateadded DATETIME NOT NULL,
+	UNIQUE KEY selection (match_id, market_id, team_id, provider_id),
+	KEY sport_season (sport_id, league_level_id, season_id)
+);
+
+-- Extra names of the teams for the /resolve/team name resolvers, entity_type is team.
+CREATE TABLE isg_entity_alias (
+	alias_id INT UNSIGNED NOT NULL AUTO_INCREMENT PRIMARY KEY,
+	entity_type VARCHAR(10) NOT NULL,
+	sport_id INT UNSIGNED NOT NULL,
+	entity_id INT UNSIGNED NOT NULL,
+	alias VARCHAR(100) NOT NULL,
+	status TINYINT NOT NULL DEFAULT 1,
+	UNIQUE KEY entity_alias (entity_type, sport_id, alias)
+);
+
+-- Historical facts of a calendar day, written by the content editors, the api only reads them (GetOnThisDayFacts).
+CREATE TABLE isg_onthisday (
+	id INT UNSIGNED NOT NULL AUTO_INCREMENT PRIMARY KEY,
+	`day` TINYINT UNSIGNED NOT NULL,   -- 1-31
+	`month` TINYINT UNSIGNED NOT NULL, -- 1-12
+	`year` SMALLINT UNSIGNED NOT NULL,
+	`date` DATE NULL,                  -- orders the facts of a year
+	sport VARCHAR(5) NULL,             -- sport code (Sport.SportID) e.g. ar, rl, sc
+	onthisday TEXT NULL,               -- the fact as shown
+	KEY month_day (`month`, `day`)
+);
+
+-- Season ladder table of each sport, one row per team and season with its form, streak and matches played (e.g.
+-- isg_aussie_rules_ladder). Read at preload into Sport.TableNameLadder, NULL for a sport without a ladder so it has no team form.
+ALTER TABLE isg_sports
+	ADD COLUMN sport_ladder_tablename VARCHAR(100) NULL;
+
+-- Provider tips table of each sport (e.g. isg_aussie_rules_matches_tips), read at preload into Sport.TableNameTips.
+-- NULL for a sport without tips so its genius odds matches are listed without them.
+ALTER TABLE isg_sports
+	ADD COLUMN sport_tips_tablename VARCHAR(100) NULL;
+
+-- Finals flag of the rounds of the ladder sports, the ladder leaves the matches of the finals rounds out (GetLadderMatches).
+ALTER TABLE isg_aussie_rules_round ADD COLUMN finals TINYINT NOT NULL DEFAULT 0;
+ALTER TABLE isg_rugby_league_round ADD COLUMN finals TINYINT NOT NULL DEFAULT 0;
+ALTER TABLE isg_rugby_union_round ADD COLUMN finals TINYINT NOT NULL DEFAULT 0;
+ALTER TABLE isg_soccer_week ADD COLUMN finals TINYINT NOT NULL DEFAULT 0;
+ALTER TABLE isg_soccer_worldcup_week ADD COLUMN finals TINYINT NOT NULL DEFAULT 0;
+ALTER TABLE isg_soccer_match_days ADD COLUMN finals TINYINT NOT NULL DEFAULT 0;
+
+-- Forecast columns of the weather job on the cache tables of the sports it forecasts. match_weather is left to the feed.
+ALTER TABLE isg_aussie_rules_cache
+	ADD COLUMN weather_summary VARCHAR(50) NULL,
+	ADD COLUMN weather_temperature DECIMAL(5,1) NULL,
+	ADD COLUMN weather_wind_speed DECIMAL(5,1) NULL,
+	ADD COLUMN weather_precip DECIMAL(5,1) NULL,
+	ADD COLUMN weather_precip_chance DECIMAL(5,1) NULL,
+	ADD COLUMN weather_updated DATETIME NULL;
+
+ALTER TABLE isg_rugby_league_cache
+	ADD COLUMN weather_summary VARCHAR(50) NULL,
+	ADD COLUMN weather_temperature DECIMAL(5,1) NULL,
+	ADD COLUMN weather_wind_speed DECIMAL(5,1) NULL,
+	ADD COLUMN weather_precip DECIMAL(5,1) NULL,
+	ADD COLUMN weather_precip_chance DECIMAL(5,1) NULL,
+	ADD COLUMN weather_updated DATETIME NULL;
+
+ALTER TABLE isg_rugby_union_cache
+	ADD COLUMN weather_summary VARCHAR(50) NULL,
+	ADD COLUMN weather_temperature DECIMAL(5,1) NULL,
+	ADD COLUMN weather_wind_speed DECIMAL(5,1) NULL,
+	ADD COLUMN weather_precip DECIMAL(5,1) NULL,
+	ADD COLUMN weather_precip_chance DECIMAL(5,1) NULL,
+	ADD COLUMN weather_updated DATETIME NULL;
+
+-- ---------------------------------------------------------------------------
+-- User db
+-- ---------------------------------------------------------------------------
+
+-- Alert rules of the users, status 0 once the user deletes the rule.
+CREATE TABLE isg_geniusodds_alert_rules (
+	rule_id INT UNSIGNED NOT NULL AUTO_INCREMENT PRIMARY KEY,
+	user_id VARCHAR(64) NOT NULL,
+	rule_type VARCHAR(20) NOT NULL,    -- price_cross, plunge, line_move, new_provider
+	sport_id INT UNSIGNED NOT NULL,
+	league_id INT UNSIGNED NOT NULL DEFAULT 0,
+	match_id INT UNSIGNED NOT NULL DEFAULT 0,
+	market VARCHAR(50) NULL,
+	team_id INT UNSIGNED NOT NULL DEFAULT 0,
+	provider_id VARCHAR(10) NULL,
+	threshold DECIMAL(8,3) NOT NULL DEFAULT 0,
+	direction VARCHAR(10) NULL,        -- above or below
+	notifier VARCHAR(10) NOT NULL,     -- webhook, email or log
+	target VARCHAR(255) NULL,
+	cooldown_mins INT UNSIGNED NOT NULL DEFAULT 0,
+	status TINYINT NOT NULL DEFAULT 1,
+	dateadded DATETIME NOT NULL,
+	KEY user_status (user_id, status)
+);
+
+-- Sent alerts, seeds the cooldowns after a restart.
+CREATE TABLE isg_geniusodds_alert_sent (
+	dedup_key VARCHAR(100) NOT NULL PRIMARY KEY,
+	rule_id INT UNSIGNED NOT NULL,
+	user_id VARCHAR(64) NOT NULL,
+	match_id INT UNSIGNED NOT NULL,
+	message VARCHAR(500) NOT NULL,
+	sent_at DATETIME NOT NULL,
+	KEY sent_at (sent_at)
+);
+
+-- Alerts given up on after their failed send attempts, kept for a manual resend.
+CREATE TABLE isg_geniusodds_alert_dead (
+	id INT UNSIGNED NOT NULL AUTO_INCREMENT PRIMARY KEY,
+	dedup_key VARCHAR(100) NOT NULL,
+	rule_id INT UNSIGNED NOT NULL,
+	user_id VARCHAR(64) NOT NULL,
+	match_id INT UNSIGNED NOT NULL,
+	notifier VARCHAR(10) NOT NULL,
+	target VARCHAR(255) NULL,
+	message VARCHAR(500) NOT NULL,
+	last_update DATETIME NOT NULL,     -- the fluc of the alert, counter time (AEST)
+	attempts TINYINT UNSIGNED NOT NULL,
+	last_error VARCHAR(500) NOT NULL,
+	dead_at DATETIME NOT NULL,
+	KEY rule_id (rule_id)
+);
+
+-- ---------------------------------------------------------------------------
+-- Log db, the cron error log shape written by InsertErrorLog
+-- ---------------------------------------------------------------------------
+
+-- isg_geniusodds_quality_log : price issues of the quality job, alert_id is the issue type
+-- isg_geniusodds_alert_log   : alerts of the log notifier, alert_id is the rule id
+CREATE TABLE isg_geniusodds_quality_log (
+	id INT UNSIGNED NOT NULL AUTO_INCREMENT PRIMARY KEY,
+	alert_id INT UNSIGNED NOT NULL,
+	alert_group_id INT UNSIGNED NOT NULL DEFAULT 0,
+	sport_id INT UNSIGNED NOT NULL,
+	match_id INT UNSIGNED NOT NULL DEFAULT 0,
+	season_id INT UNSIGNED NOT NULL DEFAULT 0,
+	round_week VARCHAR(50) NOT NULL DEFAULT '',
+	league_id INT UNSIGNED NOT NULL DEFAULT 0,
+	home_team VARCHAR(100) NOT NULL DEFAULT '',
+	away_team VARCHAR(100) NOT NULL DEFAULT '',
+	match_date DATE NULL,
+	match_time TIME NULL,
+	script_name VARCHAR(100) NOT NULL DEFAULT '',
+	error_msg VARCHAR(500) NOT NULL,
+	dateadded DATETIME NOT NULL,
+	provider_name VARCHAR(100) NOT NULL DEFAULT '',
+	UNIQUE KEY alert (alert_id, sport_id, match_id, provider_name)
+);
+
+CREATE TABLE isg_geniusodds_alert_log LIKE isg_geniusodds_quality_log;
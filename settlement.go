@@ -0,0 +1,163 @@
+/*
+Package data - Handles functions related to data source access e.g. cache, databases
+*/
+package data
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/thegeniusgroup/isgdatalib"
+)
+
+// GetGeniusOddsUnsettledMarkets : provider prices of the played matches (status N) with both scores which are not settled yet.
+// Postponed, abandoned and matches without a status are left for a later run.
+func GetGeniusOddsUnsettledMarkets(objSport isg.Sport, leagueID int, lookback time.Duration) ([]isg.GeniusOddsSettlement, error) {
+
+	var records []isg.GeniusOddsSettlement
+	var sqlstr string
+	sportID := objSport.SportInternalID
+
+	// allow a few hours after the start for the final score to land
+	currentDateTime := time.Now().UTC()
+	searchStr := "concat(matches.counter_date, ' ', matches.counter_time) BETWEEN '" + CounterDateTimeString(currentDateTime.Add(-lookback)) + "' AND " +
+		" '" + CounterDateTimeString(currentDateTime.Add(-4*time.Hour)) + "' AND "
+
+	switch sportID {
+
+	case 1, 7, 10:
+
+		sqlstr = "SELECT matches.match_id, matches.season_id, matches.status, matches.home_team_id, matches.away_team_id, scores.home_score, scores.away_score, " +
+			" marketodds.provider_id, marketodds.market_id, marketodds.team_id, marketodds.market_price, marketodds.market_val, " +
+			" IFNULL(marketcategory.category_name,''), market.isg_api_id " +
+			" FROM " + objSport.TableNameMatches + " AS matches " +
+			" INNER JOIN " + objSport.TableNameMatches + "_scores AS scores ON scores.match_id = matches.match_id " +
+			" INNER JOIN isg_geniusodds_marketodds marketodds ON marketodds.match_id = matches.match_id AND marketodds.sport_id = ? AND marketodds.league_level_id = ? " +
+			" AND marketodds.provider_id != ? " +
+			" INNER JOIN isg_market market ON market.market_id = marketodds.market_id " +
+			" LEFT JOIN isg_market_category marketcategory ON marketcategory.category_id = market.category_id " +
+			" LEFT JOIN isg_geniusodds_settlement settled ON settled.match_id = marketodds.match_id AND settled.market_id = marketodds.market_id " +
+			" AND settled.team_id = marketodds.team_id AND settled.provider_id = marketodds.provider_id " +
+			" WHERE " + searchStr + " matches.league_id = ? AND matches.status = ? AND scores.home_score IS NOT NULL AND scores.away_score IS NOT NULL " +
+			" AND settled.match_id IS NULL " +
+			" AND market.isg_api_id IN ('win', 'draw', 'cover', 'over', 'under', 'btts_yes', 'btts_no') " +
+			" ORDER BY matches.match_id, marketodds.provider_id, market.category_id, market.market_id "
+	default:
+		return records, nil
+	}
+
+	rows, err := SportsDb.Query(sqlstr, sportID, leagueID, 4, leagueID, "N")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var record isg.GeniusOddsSettlement
+		err = rows.Scan(
+			&record.MatchID,
+			&record.SeasonID,
+			&record.MatchStatus,
+			&record.HomeTeamID,
+			&record.AwayTeamID,
+			&record.HomeScore,
+			&record.AwayScore,
+			&record.ProviderID,
+			&record.MarketID,
+			&record.MarketTeamID,
+			&record.MarketPrice,
+			&record.MarketVal,
+			&record.CategoryName,
+			&record.ISGapiID,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		record.SportID = sportID
+		record.LeagueID = leagueID
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+// InsertGeniusOddsSettlements : stores the settled selections with their accuracy scores, void selections are stored as a terminal state
+func InsertGeniusOddsSettlements(records []isg.GeniusOddsSettlement) error {
+
+	stmt, err := SportsDb.Prepare("INSERT INTO isg_geniusodds_settlement SET match_id = ?, sport_id = ?, league_level_id = ?, season_id = ?, provider_id = ?, " +
+		" market_id = ?, team_id = ?, category_name = ?, market_price = ?, market_val = ?, outcome = ?, implied_prob = ?, brier = ?, log_loss = ?, dateadded = ? " +
+		" ON DUPLICATE KEY UPDATE market_price = ?, market_val = ?, outcome = ?, implied_prob = ?, brier = ?, log_loss = ?, dateadded = ? ")
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	dateadded := time.Now().In(AEST).Format("2006-01-02 15:04:05")
+	for _, record := range records {
+		_, err = stmt.Exec(record.MatchID, record.SportID, record.LeagueID, record.SeasonID, record.ProviderID, record.MarketID, record.MarketTeamID,
+			record.CategoryName, record.MarketPrice, record.MarketVal, record.Outcome, record.ImpliedProb, record.Brier, record.LogLoss, dateadded,
+			record.MarketPrice, record.MarketVal, record.Outcome, record.ImpliedProb, record.Brier, record.LogLoss, dateadded)
+		if err != nil {
+			fmt.Println(err.Error())
+		}
+	}
+
+	return nil
+}
+
+// GetGeniusOddsAccuracyLeaderboard : provider Brier score and log-loss per season, lower is better.
+// leagueID and seasonID are optional filters, 0 means all.
+func GetGeniusOddsAccuracyLeaderboard(objSport isg.Sport, leagueID, seasonID int) ([]isg.GeniusOddsAccuracy, error) {
+
+	var leaderboard []isg.GeniusOddsAccuracy
+
+	searchStr, args := geniusOddsReportFilter("settled", objSport.SportInternalID, leagueID, seasonID, "")
+	args = append(args, isg.SettleVoid)
+
+	sqlstr := "SELECT settled.provider_id, IFNULL(provider.provider_name,''), IFNULL(provider.provider_icon,''), IFNULL(season.season,''), " +
+		" COUNT(1), COUNT(DISTINCT settled.match_id), ROUND(AVG(settled.brier), 4), ROUND(AVG(settled.log_loss), 4), " +
+		" ROUND(SUM(IF(settled.outcome = 1 AND settled.implied_prob >= 0.5, 1, IF(settled.outcome = 0 AND settled.implied_prob < 0.5, 1, 0))) / COUNT(1) * 100, 2) " +
+		" FROM isg_geniusodds_settlement AS settled " +
+		" LEFT JOIN isg_providers provider ON provider.provider_id = settled.provider_id " +
+		" LEFT JOIN " + objSport.TableNameSeasons + " AS season ON season.season_id = settled.season_id " +
+		" WHERE " + searchStr + " AND settled.outcome != ? " +
+		" GROUP BY settled.season_id, settled.provider_id " +
+		" ORDER BY settled.season_id DESC, AVG(settled.brier) ASC, AVG(settled.log_loss) ASC "
+
+	rows, err := SportsDb.Query(sqlstr, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var rank int
+	var prevSeason string
+	for rows.Next() {
+		var accuracy isg.GeniusOddsAccuracy
+		err = rows.Scan(
+			&accuracy.ProviderID,
+			&accuracy.Name,
+			&accuracy.Icon,
+			&accuracy.Season,
+			&accuracy.Selections,
+			&accuracy.Matches,
+			&accuracy.Brier,
+			&accuracy.LogLoss,
+			&accuracy.HitRate,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		if accuracy.Season != prevSeason {
+			rank = 0
+			prevSeason = accuracy.Season
+		}
+		rank++
+		accuracy.Rank = rank
+		leaderboard = append(leaderboard, accuracy)
+	}
+
+	return leaderboard, nil
+}
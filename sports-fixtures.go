@@ -19,7 +19,7 @@ import (
 
 
 // GeniusOddsFixtureList : Gets list of fixtures matching the parameters only for upcoming.
-// GET  /{:sport}/{:league}
+// GET  /{:sport}/{:league}?from=&to=&limit=&cursor=&include=preview,tips
 func GeniusOddsFixtureList(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
 
 	//customer := util.CustomerFromScope(p)
@@ -38,6 +38,24 @@ func GeniusOddsFixtureList(w http.ResponseWriter, r *http.Request, p httprouter.
 	var objleague isg.League
 	var err error
 
+	objOptions, err := getGeniusOddsOptions(r)
+	if err != nil {
+		util.WebResponse(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	objWindow, err := getGeniusOddsWindow(r, objOptions.TimeZone)
+	if err != nil {
+		util.WebResponse(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// a cursor only continues the listing it was made for
+	if objWindow.Cursor != nil && objWindow.Cursor.Type != typeVal {
+		util.WebResponse(w, r, http.StatusBadRequest, "cursor is not of the "+typeVal+" listing")
+		return
+	}
+
 	if sportname == "all" || sportname == "" {
 		for _, objsport := range data.SportObjects {
 			objsports = append(objsports, objsport)
@@ -68,26 +86,62 @@ func GeniusOddsFixtureList(w http.ResponseWriter, r *http.Request, p httprouter.
 		}
 	}
 
+	objMatch, err := getGeniusOddsMatches(objsports, objsport, objleague, leaguename, typeVal, matchID, objWindow)
+	if err != nil {
+		util.WebResponse(w, r, http.StatusNotFound, "record not found")
+		return
+	}
+
+	if len(objMatch) == 0 {
+		util.WebResponse(w, r, http.StatusNotFound, "record not found")
+		return
+	}
+
+	// keep one page, the cursor picks up after its last match. Only the page is enriched.
+	objMatch, nextCursor := isg.PageGeniusOddsMatches(objMatch, typeVal, objWindow.Limit, objWindow.Cursor)
+	setGeniusOddsDetails(objMatch)
+
+	// Sort the matches according to listing parameter
+	objMatch[0].TypeVal = typeVal
+	sort.Sort(isg.GeniusSortMatchesISG(objMatch))
+	objMatch = isg.GroupGeniusOddsTournaments(objMatch)
+
+	setGeniusOddsContent(objMatch, objOptions.Include)
+
+	// if typeVal == "best" && len(objMatch) > 1 {
+	// 	bestMatch := objMatch[0]
+	// 	objMatch = []isg.GeniusSportsMatch{}
+	// 	objMatch = append(objMatch, bestMatch)
+	// }
+
+	// Binding the matches into json
+	t := isg.BindingGeniusOddsMatches(objMatch, typeVal, objOptions)
+	t.NextCursor = nextCursor
+	final := util.JSONMessageWrappedObj(http.StatusOK, t)
+	util.WebResponseJSONObjectNoCache(w, r, http.StatusOK, final)
+	return
+}
+
+// getGeniusOddsMatches : loads the genius odds matches for the listing type across the requested sports and leagues
+func getGeniusOddsMatches(objsports []isg.Sport, objsport isg.Sport, objleague isg.League, leaguename, typeVal, matchID string, objWindow isg.GeniusOddsWindow) ([]isg.GeniusSportsMatch, error) {
+
+	var err error
 	var bestMatches []isg.OddsInfo
 	var plungeMatches []isg.GeniusOddsPlunge
 	if typeVal == "best" {
 		bestMatches, err = data.GetGeniusOddBestMatch(objsport.SportInternalID, objleague.LeagueInternalID, typeVal)
 		if err != nil {
-			util.WebResponse(w, r, http.StatusNotFound, "record not found")
-			return
+			return nil, errors.New("record not found")
 		} else if len(bestMatches) == 0 {
-			util.WebResponse(w, r, http.StatusNotFound, "record not found")
-			return
+			return nil, errors.New("record not found")
 		}
 
 	} else if typeVal == "plunge" && matchID == "" {
 		plungeMatches, err = data.GetGeniusOddPlungeMatch(objsport.SportInternalID, objleague.LeagueInternalID, typeVal, matchID)
 		if err != nil {
-			util.WebResponse(w, r, http.StatusNotFound, "record not found")
-			return
+			return nil, errors.New("record not found")
 		} else if len(plungeMatches) == 0 {
-			util.WebResponse(w, r, http.StatusNotFound, "record not found")
-			return
+			return nil, errors.New("record not found")
 		}
 	}
 
@@ -95,7 +149,12 @@ func GeniusOddsFixtureList(w http.ResponseWriter, r *http.Request, p httprouter.
 	var objMatch []isg.GeniusSportsMatch
 	for _, objsport := range objsports {
 
-		if objsport.SportInternalID != 1 && objsport.SportInternalID != 7 {
+		if !data.IsGeniusOddsSport(objsport.SportInternalID) {
+			continue
+		}
+
+		// tennis has no plunge odds
+		if objsport.SportInternalID == 6 && typeVal == "plunge" {
 			continue
 		}
 
@@ -120,7 +179,7 @@ func GeniusOddsFixtureList(w http.ResponseWriter, r *http.Request, p httprouter.
 
 					matchid, _ := strconv.Atoi(matchID)
 
-					plungeOddsFluc, err := data.GetMatchesProviderMarketFlucs(objsport, objLeague.LeagueInternalID, matchid, typeVal)
+					plungeOddsFluc, err := data.GetMatchesProviderMarketFlucs(objsport, objLeague.LeagueInternalID, matchid, typeVal, objWindow)
 					if err != nil {
 						fmt.Println(err.Error())
 					}
@@ -138,7 +197,7 @@ func GeniusOddsFixtureList(w http.ResponseWriter, r *http.Request, p httprouter.
 					objmatchID = append(objmatchID, matchID)
 				}
 
-				objMatch, err = data.GetPlungeMatchesForGeniusOdds(objMatch, livePlungeOdds, objsport, objLeague, typeVal, objmatchID)
+				objMatch, err = data.GetPlungeMatchesForGeniusOdds(objMatch, livePlungeOdds, objsport, objLeague, typeVal, objmatchID, objWindow)
 				if err != nil {
 					continue
 				}
@@ -148,22 +207,23 @@ func GeniusOddsFixtureList(w http.ResponseWriter, r *http.Request, p httprouter.
 
 				var liveOdd []isg.IntMarketInfo
 
-				marketOddsFluc, err := data.GetMatchesProviderMarketFlucs(objsport, objLeague.LeagueInternalID, bestMatchID, typeVal)
+				marketOddsFluc, err := data.GetMatchesProviderMarketFlucs(objsport, objLeague.LeagueInternalID, bestMatchID, typeVal, objWindow)
 				if err != nil {
 					fmt.Println(err.Error())
 				}
 
-				liveOdds, err := data.GetMatchesProviderMarketOdds(marketOddsFluc, objsport, objLeague.LeagueInternalID, bestMatchID, typeVal)
+				liveOdds, err := data.GetMatchesProviderMarketOdds(marketOddsFluc, objsport, objLeague.LeagueInternalID, bestMatchID, typeVal, objWindow)
 				if err != nil {
 					fmt.Println(err.Error())
 					continue
 				}
+				liveOdds = excludeGeniusOddsIssues(liveOdds)
 
 				if len(liveOdds) > 0 {
 					liveOdd = isg.MakingGeniusLiveOddsSort(liveOdds, objsport.SportInternalID, typeVal)
 				}
 
-				_sqlstr := data.GenerateSQLQueryForGeniusOdds(objsport, objLeague, bestMatchID) // third parameter id optional parameter as matchID
+				_sqlstr := data.GenerateSQLQueryForGeniusOdds(objsport, objLeague, bestMatchID, objWindow) // third parameter id optional parameter as matchID
 				objMatch, err = data.GetMatchesForGeniusOdds(_sqlstr, objMatch, liveOdd, objsport, objLeague, typeVal)
 				if err != nil {
 					fmt.Println(err.Error())
@@ -173,22 +233,33 @@ func GeniusOddsFixtureList(w http.ResponseWriter, r *http.Request, p httprouter.
 
 				var liveOdd []isg.IntMarketInfo
 
-				marketOddsFluc, err := data.GetMatchesProviderMarketFlucs(objsport, objLeague.LeagueInternalID, 0, typeVal)
+				// the page of the league is picked first, the odds and flucs are loaded for its matches only
+				objPageWindow := objWindow
+				objPageWindow.MatchIDs, err = data.GetGeniusOddsPageMatchIDs(objsport, objLeague, objWindow)
 				if err != nil {
 					fmt.Println(err.Error())
+					continue
+				} else if len(objPageWindow.MatchIDs) == 0 {
+					continue
 				}
 
-				liveOdds, err := data.GetMatchesProviderMarketOdds(marketOddsFluc, objsport, objLeague.LeagueInternalID, 0, typeVal)
+				marketOddsFluc, err := data.GetMatchesProviderMarketFlucs(objsport, objLeague.LeagueInternalID, 0, typeVal, objPageWindow)
+				if err != nil {
+					fmt.Println(err.Error())
+				}
+
+				liveOdds, err := data.GetMatchesProviderMarketOdds(marketOddsFluc, objsport, objLeague.LeagueInternalID, 0, typeVal, objPageWindow)
 				if err != nil {
 					fmt.Println(err.Error())
 					continue
 				}
+				liveOdds = excludeGeniusOddsIssues(liveOdds)
 
 				if len(liveOdds) > 0 {
 					liveOdd = isg.MakingGeniusLiveOddsSort(liveOdds, objsport.SportInternalID, typeVal)
 				}
 
-				_sqlstr := data.GenerateSQLQueryForGeniusOdds(objsport, objLeague, 0) // third parameter id optional parameter as matchID
+				_sqlstr := data.GenerateSQLQueryForGeniusOdds(objsport, objLeague, 0, objPageWindow) // third parameter id optional parameter as matchID
 				objMatch, err = data.GetMatchesForGeniusOdds(_sqlstr, objMatch, liveOdd, objsport, objLeague, typeVal)
 				if err != nil {
 					fmt.Println(err.Error())
@@ -199,29 +270,21 @@ func GeniusOddsFixtureList(w http.ResponseWriter, r *http.Request, p httprouter.
 		}
 	}
 
-	if len(objMatch) == 0 {
-		util.WebResponse(w, r, http.StatusNotFound, "record not found")
-		return
-	}
-
-	// Sort the matches according to listing parameter
-	objMatch[0].TypeVal = typeVal
-	sort.Sort(isg.GeniusSortMatchesISG(objMatch))
-
-	// if typeVal == "best" && len(objMatch) > 1 {
-	// 	bestMatch := objMatch[0]
-	// 	objMatch = []isg.GeniusSportsMatch{}
-	// 	objMatch = append(objMatch, bestMatch)
-	// }
+	return objMatch, nil
+}
 
-	// Binding the matches into json
-	t := isg.BindingGeniusOddsMatches(objMatch, typeVal)
-	final := util.JSONMessageWrappedObj(http.StatusOK, t)
-	util.WebResponseJSONObjectNoCache(w, r, http.StatusOK, final)
-	return
+// setGeniusOddsDetails : model fair prices, form, weather, travel and tennis details of a page of matches
+func setGeniusOddsDetails(objMatch []isg.GeniusSportsMatch) {
+	setGeniusOddsModel(objMatch)
+	setGeniusOddsForm(objMatch)
+	setGeniusOddsWeather(objMatch)
+	setGeniusOddsTravel(objMatch)
+	setGeniusOddsTennis(objMatch)
 }
 
-// GeniusOddsMarketFixtureList :
+// GeniusOddsMarketFixtureList : ?providers=&categories=&groups=&markets=&include= take comma separated lists
+// Tennis matches are keyed by tournament and round, the players take the team segments.
+// GET  /geniusodds/markets/te/{:level}/{:tournament}/{:round}/{:player1}/{:player2}
 func GeniusOddsMarketFixtureList(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
 	var err error
 	sportname := util.CleanText(p.ByName("sport"), true, true)
@@ -231,7 +294,17 @@ func GeniusOddsMarketFixtureList(w http.ResponseWriter, r *http.Request, p httpr
 	team1 := util.CleanText(p.ByName("team1"), true, true)
 	team2 := util.CleanText(p.ByName("team2"), true, true)
 
-	typeVal := "market"
+	objOptions, err := getGeniusOddsOptions(r)
+	if err != nil {
+		util.WebResponse(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	objFilter, err := getGeniusOddsFilter(r)
+	if err != nil {
+		util.WebResponse(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
 
 	if sportname == "" {
 		util.WebResponse(w, r, http.StatusNotFound, "sport not found")
@@ -260,6 +333,18 @@ func GeniusOddsMarketFixtureList(w http.ResponseWriter, r *http.Request, p httpr
 		return
 	}
 
+	// tennis markets are keyed by tournament, the season segment is the tournament
+	if objsport.SportInternalID == 6 {
+		matchID, err := resolveGeniusOddsTennisSlug(objsport, objleague, season, round, team1, team2)
+		if err != nil {
+			util.WebResponse(w, r, http.StatusNotFound, strings.TrimPrefix(err.Error(), isg.ISGErrBadInputPrefix))
+			return
+		}
+
+		writeGeniusOddsMarketMatch(w, r, objsport, objleague, matchID, objOptions, objFilter)
+		return
+	}
+
 	// change season table for cricket
 	if objsport.SportInternalID == 5 && objleague.LeagueInternalID == 2 {
 		objsport.TableNameSeasons = "isg_cricket_single_season"
@@ -281,84 +366,87 @@ func GeniusOddsMarketFixtureList(w http.ResponseWriter, r *http.Request, p httpr
 		return
 	}
 
-	if round == "" {
-		util.WebResponse(w, r, http.StatusNotFound, "date/round/week not found")
+	matchID, err := resolveGeniusOddsMatchSlug(objsport, objleague, seasonID, round, team1, team2)
+	if err != nil {
+		util.WebResponse(w, r, http.StatusNotFound, strings.TrimPrefix(err.Error(), isg.ISGErrBadInputPrefix))
 		return
 	}
-	var roundWeekDate string
-
-	roundArr := strings.Split(round, "-")
-	if len(roundArr) == 2 {
-		checkCount, err := time.Parse("Jan", roundArr[0])
-		if err == nil {
-			month := checkCount.Format("01")
-			roundWeekDate = "-" + month + "-" + roundArr[1]
-		} else {
-			objRound, _, err := data.GetRoundWeekDetails(objsport, objleague, round)
-			if err != nil {
-				util.WebResponse(w, r, http.StatusNotFound, "date/round/week not found")
-				return
-			}
-			if len(objRound) > 0 {
-				roundWeekDate = strconv.Itoa(objRound[0].RoundID)
-			}
-		}
-	} else {
-		objRound, _, err := data.GetRoundWeekDetails(objsport, objleague, round)
-		if err != nil {
-			util.WebResponse(w, r, http.StatusNotFound, "date/round/week not found")
-			return
-		}
-		if len(objRound) > 0 {
-			roundWeekDate = strconv.Itoa(objRound[0].RoundID)
-		}
+
+	writeGeniusOddsMarketMatch(w, r, objsport, objleague, matchID, objOptions, objFilter)
+	return
+}
+
+// resolveGeniusOddsMatchSlug : match id of the round and team slugs. The second game of a same day doubleheader is
+// team2-g2, without a game suffix the first game is taken.
+func resolveGeniusOddsMatchSlug(objsport isg.Sport, objleague isg.League, seasonID int, round, team1, team2 string) (int, error) {
+
+	if round == "" {
+		return 0, errors.New("date/round/week not found")
 	}
 
-	if team1 == "" || team2 == "" {
-		util.WebResponse(w, r, http.StatusNotFound, "teams not found")
-		return
+	objSlug, err := isg.ParseGeniusOddsMatchSlug(objsport.SportInternalID, objleague.LeagueInternalID, round, team1, team2)
+	if err != nil {
+		return 0, err
 	}
 
-	sortOder := "ASC"
-	if strings.HasSuffix(team2, "-2") {
-		sortOder = "DESC"
-		team2 = strings.Replace(team2, "-2", "", -1)
+	roundWeekDate := objSlug.Date
+	if roundWeekDate == "" {
+		objRound, _, err := data.GetRoundWeekDetails(objsport, objleague, objSlug.Round)
+		if err != nil {
+			return 0, errors.New("date/round/week not found")
+		}
+		if len(objRound) == 0 {
+			return 0, errors.New("date/round/week not found")
+		}
+		roundWeekDate = strconv.Itoa(objRound[0].RoundID)
 	}
 
-	var homeTeamName, awayTeamName string
-	if objsport.SportInternalID == 2 || (objsport.SportInternalID == 3 && objleague.LeagueInternalID == 1) || objsport.SportInternalID == 8 ||
-		objsport.SportInternalID == 9 {
-		homeTeamName = team2
-		awayTeamName = team1
-	} else {
-		homeTeamName = team1
-		awayTeamName = team2
+	homeTeamID, err := data.GetTeam(objsport.SportInternalID, objSlug.HomeTeam)
+	if err != nil {
+		return 0, errors.New("home team not found")
 	}
 
-	homeTeamID, err := data.GetTeam(objsport.SportInternalID, homeTeamName)
+	awayTeamID, err := data.GetTeam(objsport.SportInternalID, objSlug.AwayTeam)
 	if err != nil {
-		util.WebResponse(w, r, http.StatusNotFound, "home team not found")
-		return
+		return 0, errors.New("away team not found")
 	}
 
-	awayTeamID, err := data.GetTeam(objsport.SportInternalID, awayTeamName)
+	matchIDs, err := data.GetGeniusMarketMatchIDs(objsport, objleague.LeagueInternalID, seasonID, roundWeekDate, homeTeamID, awayTeamID)
 	if err != nil {
-		util.WebResponse(w, r, http.StatusNotFound, "away team not found")
-		return
+		fmt.Println(err.Error())
+		return 0, errors.New("unable to get match record")
 	}
 
-	matchID, err := data.GetGeniusMarketMatchID(objsport, objleague.LeagueInternalID, seasonID, roundWeekDate, homeTeamID, awayTeamID, sortOder)
+	return isg.SelectGeniusOddsMatchGame(matchIDs, objSlug.Game)
+}
+
+// writeGeniusOddsMarketMatch : markets page response of a resolved match
+func writeGeniusOddsMarketMatch(w http.ResponseWriter, r *http.Request, objsport isg.Sport, objleague isg.League, matchID int, objOptions isg.GeniusOddsOptions,
+	objFilter isg.GeniusOddsFilter) {
+
+	typeVal := "market"
 
+	objMatch, plungeMatches, err := getGeniusOddsMarketMatch(objsport, objleague, matchID, typeVal, objFilter)
 	if err != nil {
-		util.WebResponse(w, r, http.StatusNotFound, "unable to get match record")
+		util.WebResponse(w, r, http.StatusNotFound, "unable to get the match record.")
 		return
 	}
-
-	if matchID == 0 {
-		util.WebResponse(w, r, http.StatusNotFound, "match not found")
+	if len(objMatch) == 0 {
+		util.WebResponse(w, r, http.StatusNotFound, "record not found")
 		return
 	}
 
+	setGeniusOddsContent(objMatch, objOptions.Include)
+
+	t := isg.BindingGeniusOddsMarketMatches(objMatch, typeVal, plungeMatches, objOptions)
+	final := util.JSONMessageWrappedObj(http.StatusOK, t)
+	util.WebResponseJSONObjectNoCache(w, r, http.StatusOK, final)
+	return
+}
+
+// getGeniusOddsMarketMatch : loads a single match with all of its provider markets for the markets page
+func getGeniusOddsMarketMatch(objsport isg.Sport, objleague isg.League, matchID int, typeVal string, objFilter isg.GeniusOddsFilter) ([]isg.GeniusSportsMatch, []isg.GeniusOddsPlunge, error) {
+
 	var objMatch []isg.GeniusSportsMatch
 
 	// get plunge match
@@ -371,28 +459,173 @@ func GeniusOddsMarketFixtureList(w http.ResponseWriter, r *http.Request, p httpr
 		fmt.Println(err.Error())
 	}
 
-	liveOdds, err := data.GetMarketMatchesProviderOdds(marketOddsFluc, objsport, objleague.LeagueInternalID, matchID)
+	liveOdds, err := data.GetMarketMatchesProviderOdds(marketOddsFluc, objsport, objleague.LeagueInternalID, matchID, objFilter)
 	if err != nil {
 		fmt.Println(err.Error())
 	}
+	liveOdds = excludeGeniusOddsIssues(liveOdds)
+
+	// exotic team markets are bound from the Odds exotic fields into home, away and any sides
+	liveOdds, exoticOdds := isg.SplitGeniusOddsExoticMarkets(liveOdds)
 
 	if len(liveOdds) > 0 {
 		liveOdd = isg.MakingGeniusLiveMarketOddsSort(liveOdds, objsport.SportInternalID, "market")
 	}
 
-	_sqlstr := data.GenerateSQLQueryForGeniusOdds(objsport, objleague, matchID)
+	// player markets come last as the "Player" group
+	playerOdds, err := data.GetMarketMatchesPlayerOdds(marketOddsFluc, objsport, objleague.LeagueInternalID, matchID, objFilter)
+	if err != nil {
+		fmt.Println(err.Error())
+	}
+	playerOdds = excludeGeniusOddsIssues(playerOdds)
+
+	if len(playerOdds) > 0 {
+		liveOdd = append(liveOdd, isg.MakingGeniusLiveMarketOddsSort(playerOdds, objsport.SportInternalID, "market")...)
+	}
+
+	_sqlstr := data.GenerateSQLQueryForGeniusOdds(objsport, objleague, matchID, isg.GeniusOddsWindow{})
 	objMatch, err = data.GetMatchesForGeniusOdds(_sqlstr, objMatch, liveOdd, objsport, objleague, "market")
 	if err != nil {
-		util.WebResponse(w, r, http.StatusNotFound, "unable to get the match record.")
-		return
+		return nil, nil, err
 	}
-	if len(objMatch) == 0 {
-		util.WebResponse(w, r, http.StatusNotFound, "record not found")
-		return
+
+	if len(objMatch) > 0 {
+		objMatch[0].ExoticMatchOdds = exoticOdds
+		setGeniusOddsMarketModel(objMatch, objsport, objleague, matchID)
 	}
+	setGeniusOddsForm(objMatch)
+	setGeniusOddsWeather(objMatch)
+	setGeniusOddsTravel(objMatch)
+	setGeniusOddsTennis(objMatch)
 
-	t := isg.BindingGeniusOddsMarketMatches(objMatch, typeVal, plungeMatches)
-	final := util.JSONMessageWrappedObj(http.StatusOK, t)
-	util.WebResponseJSONObjectNoCache(w, r, http.StatusOK, final)
-	return
+	return objMatch, plungeMatches, nil
+}
+
+// getGeniusOddsOptions : reads the response options shared by the genius odds routes
+func getGeniusOddsOptions(r *http.Request) (isg.GeniusOddsOptions, error) {
+	var objOptions isg.GeniusOddsOptions
+	var err error
+
+	objOptions.OddsFormat, err = isg.GetOddsFormat(util.CleanText(r.URL.Query().Get("odds_format"), true, true))
+	if err != nil {
+		return objOptions, err
+	}
+
+	// IANA names are passed as is e.g. America/Argentina/Buenos_Aires or Etc/GMT+10, LoadLocation rejects anything else
+	tz := r.URL.Query().Get("tz")
+	if tz != "" {
+		objOptions.TimeZone, err = time.LoadLocation(tz)
+		if err != nil {
+			return objOptions, errors.New("invalid tz value :" + tz)
+		}
+	}
+
+	// extra content of each match e.g. include=preview,tips
+	for _, include := range splitGeniusOddsFilter(r.URL.Query().Get("include")) {
+		if !isg.CheckValueInArray(isg.GeniusOddsIncludes, include) {
+			return objOptions, errors.New("invalid include value :" + include)
+		}
+		objOptions.Include = append(objOptions.Include, include)
+	}
+
+	return objOptions, nil
+}
+
+// getGeniusOddsWindow : reads the from / to kick off window, page limit and cursor of the genius odds listings.
+// from / to take RFC 3339 times or dates, dates are read in the requested time zone (AEST by default) and to covers the whole day.
+func getGeniusOddsWindow(r *http.Request, loc *time.Location) (isg.GeniusOddsWindow, error) {
+	var objWindow isg.GeniusOddsWindow
+	var err error
+
+	if loc == nil {
+		loc = data.AEST
+	}
+
+	objWindow.From, err = parseGeniusOddsWindowTime(r.URL.Query().Get("from"), loc, false)
+	if err != nil {
+		return objWindow, errors.New("invalid from value :" + r.URL.Query().Get("from"))
+	}
+
+	objWindow.To, err = parseGeniusOddsWindowTime(r.URL.Query().Get("to"), loc, true)
+	if err != nil {
+		return objWindow, errors.New("invalid to value :" + r.URL.Query().Get("to"))
+	}
+
+	if !objWindow.From.IsZero() && !objWindow.To.IsZero() && objWindow.To.Before(objWindow.From) {
+		return objWindow, errors.New("to must be after from")
+	}
+
+	objWindow.Limit = isg.GeniusOddsPageLimit
+	limit := util.CleanText(r.URL.Query().Get("limit"), true, true)
+	if limit != "" {
+		objWindow.Limit, err = strconv.Atoi(limit)
+		if err != nil || objWindow.Limit < 1 || objWindow.Limit > isg.GeniusOddsMaxPageLimit {
+			return objWindow, errors.New("invalid limit value :" + limit)
+		}
+	}
+
+	cursor := util.CleanText(r.URL.Query().Get("cursor"), false, true)
+	if cursor != "" {
+		objWindow.Cursor, err = isg.DecodeGeniusOddsCursor(cursor)
+		if err != nil {
+			return objWindow, errors.New("invalid cursor value :" + cursor)
+		}
+	}
+
+	return objWindow, nil
+}
+
+// parseGeniusOddsWindowTime : RFC 3339 time or date of the from / to parameters, endOfDay moves a date to the next midnight
+func parseGeniusOddsWindowTime(value string, loc *time.Location, endOfDay bool) (time.Time, error) {
+
+	// an unescaped + of the offset arrives as a space
+	value = strings.Replace(util.CleanText(value, false, true), " ", "+", -1)
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	windowTime, err := time.Parse(time.RFC3339, value)
+	if err == nil {
+		return windowTime, nil
+	}
+
+	windowTime, err = time.ParseInLocation("2006-01-02", value, loc)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if endOfDay {
+		windowTime = windowTime.AddDate(0, 0, 1)
+	}
+	return windowTime, nil
+}
+
+// getGeniusOddsFilter : reads the providers, categories, groups and markets filters of the markets page, providers are given by their url
+func getGeniusOddsFilter(r *http.Request) (isg.GeniusOddsFilter, error) {
+	var objFilter isg.GeniusOddsFilter
+
+	for _, providername := range splitGeniusOddsFilter(r.URL.Query().Get("providers")) {
+		objprovider, ok := data.Providers[providername]
+		if !ok {
+			return objFilter, errors.New("invalid providers value :" + providername)
+		}
+		objFilter.Providers = append(objFilter.Providers, objprovider.ProviderId)
+	}
+
+	objFilter.Categories = splitGeniusOddsFilter(r.URL.Query().Get("categories"))
+	objFilter.Groups = splitGeniusOddsFilter(r.URL.Query().Get("groups"))
+	objFilter.Markets = splitGeniusOddsFilter(r.URL.Query().Get("markets"))
+
+	return objFilter, nil
+}
+
+// splitGeniusOddsFilter : lower case values of a comma separated filter
+func splitGeniusOddsFilter(value string) []string {
+	var values []string
+	for _, val := range strings.Split(util.CleanText(value, true, true), ",") {
+		val = strings.TrimSpace(val)
+		if val != "" {
+			values = append(values, val)
+		}
+	}
+	return values
 }
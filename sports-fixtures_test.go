@@ -0,0 +1,46 @@
+package sports
+
+import (
+	"data"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/thegeniusgroup/isgdatalib"
+)
+
+func TestGetGeniusOddsOptionsTimeZone(t *testing.T) {
+
+	for _, tz := range []string{"America/Argentina/Buenos_Aires", "Etc/GMT+10", "Europe/London"} {
+		r := httptest.NewRequest("GET", "/geniusodds/upcoming?tz="+url.QueryEscape(tz), nil)
+		objOptions, err := getGeniusOddsOptions(r)
+		if err != nil || objOptions.TimeZone == nil || objOptions.TimeZone.String() != tz {
+			t.Errorf("tz %s = %v, %v", tz, objOptions.TimeZone, err)
+		}
+	}
+
+	for _, tz := range []string{"Mars/Olympus_Mons", "../../etc/passwd"} {
+		r := httptest.NewRequest("GET", "/geniusodds/upcoming?tz="+url.QueryEscape(tz), nil)
+		if _, err := getGeniusOddsOptions(r); err == nil {
+			t.Errorf("tz %s accepted", tz)
+		}
+	}
+}
+
+func TestGetGeniusOddsFilter(t *testing.T) {
+
+	data.Providers["sportsbet"] = isg.Provider{ProviderId: "3"}
+	defer delete(data.Providers, "sportsbet")
+
+	r := httptest.NewRequest("GET", "/geniusodds/markets/ar/afl/2024/1/a/b?providers=sportsbet&categories=line,%20total,&markets=win", nil)
+	objFilter, err := getGeniusOddsFilter(r)
+	if err != nil || len(objFilter.Providers) != 1 || objFilter.Providers[0] != "3" || len(objFilter.Categories) != 2 ||
+		objFilter.Categories[1] != "total" || len(objFilter.Groups) != 0 || len(objFilter.Markets) != 1 {
+		t.Errorf("filter = %+v, %v", objFilter, err)
+	}
+
+	r = httptest.NewRequest("GET", "/geniusodds/markets/ar/afl/2024/1/a/b?providers=nobody", nil)
+	if _, err = getGeniusOddsFilter(r); err == nil {
+		t.Error("unknown provider accepted")
+	}
+}
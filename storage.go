@@ -5,6 +5,7 @@ package data
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"os"
 	"strings"
@@ -96,3 +97,38 @@ func S3GetItem(bucket, folder, key string) ([]byte, error) {
 	return body, nil
 
 }
+
+// S3PutJSONItem : writes a json document to the bucket, same folder convention as S3PutItem
+func S3PutJSONItem(bucket, folder, key string, obj []byte) error {
+	svc := InitS3()
+	if svc == nil {
+		return errors.New("unable to create s3 session")
+	}
+
+	if ENV != "production" {
+		folder = "_dev/" + folder
+	}
+
+	payload := bytes.NewReader(obj)
+	path := key
+	if folder != "" {
+		path = folder + "/" + key
+	}
+
+	params := &s3.PutObjectInput{
+		Bucket:               aws.String(bucket), // Required
+		Key:                  aws.String(path),   // Required
+		Body:                 payload,
+		ContentLength:        aws.Int64(payload.Size()),
+		ContentType:          aws.String("application/json"),
+		CacheControl:         aws.String("max-age=60"),
+		ServerSideEncryption: aws.String("AES256"),
+	}
+	_, err := svc.PutObject(params)
+	if err != nil {
+		fmt.Println(err.Error())
+		return err
+	}
+
+	return nil
+}
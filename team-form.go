@@ -0,0 +1,129 @@
+package sports
+
+import (
+	"data"
+	"fmt"
+	"net/http"
+	"strconv"
+	"util"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/thegeniusgroup/isgdatalib"
+)
+
+// Team form route defaults
+const (
+	teamFormDefaultSeasons = 3
+	teamFormMaxSeasons     = 10
+	teamFormMaxLast        = 20
+)
+
+// setGeniusOddsForm : attaches the ladder form and streak of the home and away teams to each match of the page
+func setGeniusOddsForm(objMatches []isg.GeniusSportsMatch) {
+
+	forms := map[string]*isg.TeamForm{}
+	teamForm := func(objmatch isg.GeniusSportsMatch, teamID int64) *isg.TeamForm {
+
+		key := strconv.Itoa(objmatch.SportInfo.SportInternalID) + "-" + strconv.Itoa(objmatch.LeagueInfo.LeagueInternalID) + "-" +
+			strconv.FormatInt(objmatch.SeasonID.Int64, 10) + "-" + strconv.FormatInt(teamID, 10)
+		if objForm, ok := forms[key]; ok {
+			return objForm
+		}
+
+		var objForm *isg.TeamForm
+		form, streak, err := data.GetTeamFormStreak(objmatch.SportInfo, objmatch.LeagueInfo.LeagueInternalID, teamID, int(objmatch.SeasonID.Int64))
+		if err != nil {
+			fmt.Println(err.Error())
+		} else if form != "" {
+			objForm = &isg.TeamForm{TeamID: teamID, Form: form, Streak: streak}
+		}
+		forms[key] = objForm
+		return objForm
+	}
+
+	for i, objmatch := range objMatches {
+		// tennis players have no team ladder
+		if objmatch.SportInfo.SportInternalID == 6 || !objmatch.HomeTeamInternalID.Valid || !objmatch.AwayTeamInternalID.Valid {
+			continue
+		}
+		objMatches[i].HomeFormInfo = teamForm(objmatch, objmatch.HomeTeamInternalID.Int64)
+		objMatches[i].AwayFormInfo = teamForm(objmatch, objmatch.AwayTeamInternalID.Int64)
+	}
+}
+
+// TeamForm : ladder form and current streak, last results, home / away splits and form per season of a team. ?last= is the
+// number of last results (default 5) and ?seasons= the seasons looked back on (default 3).
+// GET  /form/{:sport}/{:league}/{:team}?last=&seasons=
+func TeamForm(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+
+	sportname := util.CleanText(p.ByName("sport"), true, true)
+	leaguename := util.CleanText(p.ByName("league"), true, true)
+	teamname := util.CleanText(p.ByName("team"), true, true)
+
+	objsport, err := data.GetSport(sportname)
+	if err != nil {
+		util.WebResponse(w, r, http.StatusNotFound, "sport not found")
+		return
+	}
+
+	if objsport.SportInternalID == 6 {
+		util.WebResponse(w, r, http.StatusNotFound, "sport not supported")
+		return
+	}
+
+	objleague, err := data.GetLeagueID(objsport, leaguename)
+	if err != nil {
+		util.WebResponse(w, r, http.StatusNotFound, "league not found")
+		return
+	}
+
+	teamID, err := data.GetTeam(objsport.SportInternalID, teamname)
+	if err != nil {
+		util.WebResponse(w, r, http.StatusNotFound, "team not found")
+		return
+	}
+
+	last := isg.TeamFormDefaultLast
+	if val := r.URL.Query().Get("last"); val != "" {
+		last, err = strconv.Atoi(val)
+		if err != nil || last <= 0 || last > teamFormMaxLast {
+			util.WebResponse(w, r, http.StatusBadRequest, "invalid last value :"+val)
+			return
+		}
+	}
+
+	seasons := teamFormDefaultSeasons
+	if val := r.URL.Query().Get("seasons"); val != "" {
+		seasons, err = strconv.Atoi(val)
+		if err != nil || seasons <= 0 || seasons > teamFormMaxSeasons {
+			util.WebResponse(w, r, http.StatusBadRequest, "invalid seasons value :"+val)
+			return
+		}
+	}
+
+	results, err := data.GetTeamFormResults(objsport, objleague.LeagueInternalID, int64(teamID), seasons)
+	if err != nil {
+		fmt.Println(err.Error())
+		util.WebResponse(w, r, http.StatusNotFound, "record not found")
+		return
+	}
+
+	var form, streak string
+	seasonID, err := data.GetCurrentSeasonid(objsport, objleague.LeagueInternalID)
+	if err == nil {
+		form, streak, err = data.GetTeamFormStreak(objsport, objleague.LeagueInternalID, int64(teamID), seasonID)
+	}
+	if err != nil {
+		fmt.Println(err.Error())
+	}
+
+	t := isg.BuildTeamForm(int64(teamID), results, last, form, streak)
+	if t == nil {
+		util.WebResponse(w, r, http.StatusNotFound, "record not found")
+		return
+	}
+
+	final := util.JSONMessageWrappedObj(http.StatusOK, t)
+	util.WebResponseJSONObjectNoCache(w, r, http.StatusOK, final)
+	return
+}
@@ -0,0 +1,171 @@
+package isg
+
+import (
+	"sort"
+	"strings"
+)
+
+// TeamFormDefaultLast : last results listed by default, the form strings e.g. "WWLWL" are the last 5 results like MakeForm
+const TeamFormDefaultLast = 5
+
+// TeamFormResult : a finished match from the team's side, Result is W / L / D on the final scores
+type TeamFormResult struct {
+	MatchID       int64  `json:"match_id"`
+	SeasonID      int64  `json:"season_id"`
+	MatchDate     string `json:"match_date"`
+	Home          bool   `json:"home"`
+	OpponentID    int64  `json:"opponent_id"`
+	Score         int    `json:"score"`
+	OpponentScore int    `json:"opponent_score"`
+	Result        string `json:"result"`
+}
+
+// TeamFormSplit : record, form and streak of a set of results
+type TeamFormSplit struct {
+	Played int    `json:"played"`
+	Won    int    `json:"won"`
+	Lost   int    `json:"lost"`
+	Drawn  int    `json:"drawn"`
+	Form   string `json:"form"`
+	Streak string `json:"streak"`
+}
+
+// TeamFormSeason :
+type TeamFormSeason struct {
+	SeasonID int64 `json:"season_id"`
+	TeamFormSplit
+}
+
+// TeamForm : form and current streak of a team across the seasons with its home / away splits, oldest result first in the forms.
+// Form and Streak come from the season ladders so they keep the hockey overtime losses and cricket no results, the splits
+// are built from the final scores.
+type TeamForm struct {
+	TeamID  int64            `json:"-"`
+	Form    string           `json:"form"`
+	Streak  string           `json:"streak"`
+	Home    *TeamFormSplit   `json:"home,omitempty"`
+	Away    *TeamFormSplit   `json:"away,omitempty"`
+	Seasons []TeamFormSeason `json:"seasons,omitempty"`
+	Last    []TeamFormResult `json:"last,omitempty"`
+}
+
+// BuildTeamForm : form of one team with its last results, nil when the team has no results. form / streak are the ladder
+// form and streak of the team, the splits stand in for them when the ladders have none.
+func BuildTeamForm(teamID int64, results []GeniusOddsModelResult, last int, form, streak string) *TeamForm {
+
+	var records []TeamFormResult
+	for _, result := range results {
+		if result.HomeTeamID == teamID {
+			records = append(records, teamFormResult(result, true))
+		} else if result.AwayTeamID == teamID {
+			records = append(records, teamFormResult(result, false))
+		}
+	}
+	if len(records) == 0 {
+		return nil
+	}
+
+	if last <= 0 {
+		last = TeamFormDefaultLast
+	}
+
+	objForm := bindingTeamForm(teamID, records)
+	if form != "" && streak != "" {
+		objForm.Form, objForm.Streak = form, streak
+	}
+
+	// latest result first
+	for i := len(records) - 1; i >= 0 && len(objForm.Last) < last; i-- {
+		objForm.Last = append(objForm.Last, records[i])
+	}
+	return objForm
+}
+
+// bindingTeamForm :
+func bindingTeamForm(teamID int64, records []TeamFormResult) *TeamForm {
+
+	var objForm TeamForm
+	objForm.TeamID = teamID
+
+	var all, home, away []TeamFormResult
+	seasons := map[int64][]TeamFormResult{}
+	for _, record := range records {
+		all = append(all, record)
+		if record.Home {
+			home = append(home, record)
+		} else {
+			away = append(away, record)
+		}
+		seasons[record.SeasonID] = append(seasons[record.SeasonID], record)
+	}
+
+	objAll := bindingTeamFormSplit(all)
+	objForm.Form = objAll.Form
+	objForm.Streak = objAll.Streak
+	objHome, objAway := bindingTeamFormSplit(home), bindingTeamFormSplit(away)
+	objForm.Home, objForm.Away = &objHome, &objAway
+
+	for seasonID, seasonRecords := range seasons {
+		objForm.Seasons = append(objForm.Seasons, TeamFormSeason{SeasonID: seasonID, TeamFormSplit: bindingTeamFormSplit(seasonRecords)})
+	}
+	sort.Slice(objForm.Seasons, func(i, j int) bool {
+		return objForm.Seasons[i].SeasonID > objForm.Seasons[j].SeasonID
+	})
+
+	return &objForm
+}
+
+// bindingTeamFormSplit : the streak runs back over every result of the split, the form over the last 5 results
+func bindingTeamFormSplit(records []TeamFormResult) TeamFormSplit {
+
+	var objSplit TeamFormSplit
+	var forms, latest []string
+	for _, record := range records {
+		objSplit.Played++
+		switch record.Result {
+		case "W":
+			objSplit.Won++
+		case "L":
+			objSplit.Lost++
+		case "D":
+			objSplit.Drawn++
+		}
+		forms = append(forms, record.Result)
+		latest = append([]string{record.Result}, latest...)
+	}
+
+	if len(forms) == 0 {
+		return objSplit
+	}
+
+	objSplit.Streak = MakeCurrentStreak(strings.Join(forms, ""))
+	objSplit.Form = MakeForm(latest)
+	return objSplit
+}
+
+// teamFormResult :
+func teamFormResult(result GeniusOddsModelResult, home bool) TeamFormResult {
+
+	var record TeamFormResult
+	record.MatchID = result.MatchID
+	record.SeasonID = result.SeasonID
+	record.MatchDate = result.MatchDate
+	record.Home = home
+	if home {
+		record.OpponentID = result.AwayTeamID
+		record.Score, record.OpponentScore = result.HomeScore, result.AwayScore
+	} else {
+		record.OpponentID = result.HomeTeamID
+		record.Score, record.OpponentScore = result.AwayScore, result.HomeScore
+	}
+
+	switch {
+	case record.Score > record.OpponentScore:
+		record.Result = "W"
+	case record.Score < record.OpponentScore:
+		record.Result = "L"
+	default:
+		record.Result = "D"
+	}
+	return record
+}
@@ -0,0 +1,45 @@
+package isg
+
+import "testing"
+
+// teamFormResults : team 1 at home in odd matches, W W L D W L from its side
+var teamFormResults = []GeniusOddsModelResult{
+	testResult(1, 1, 2, 3, 1),
+	testResult(2, 2, 1, 0, 2),
+	testResult(3, 1, 2, 1, 2),
+	testResult(4, 2, 1, 1, 1),
+	testResult(5, 1, 2, 2, 0),
+	testResult(6, 2, 1, 2, 0),
+}
+
+func TestBuildTeamForm(t *testing.T) {
+
+	objForm := BuildTeamForm(1, teamFormResults, 3, "", "")
+	if objForm == nil {
+		t.Fatal("no form for team 1")
+	}
+
+	// the last 5 results oldest first, the streak of the latest result
+	if objForm.Form != "WLDWL" || objForm.Streak != "L1" {
+		t.Errorf("form %q streak %q, want WLDWL L1", objForm.Form, objForm.Streak)
+	}
+	if objForm.Home.Form != "WLW" || objForm.Away.Form != "WDL" {
+		t.Errorf("home form %q away form %q, want WLW and WDL", objForm.Home.Form, objForm.Away.Form)
+	}
+	if len(objForm.Last) != 3 || objForm.Last[0].MatchID != 6 {
+		t.Errorf("last results %+v, want matches 6, 5 and 4", objForm.Last)
+	}
+
+	if BuildTeamForm(3, teamFormResults, 3, "", "") != nil {
+		t.Error("form built for a team without results")
+	}
+}
+
+func TestBuildTeamFormLadder(t *testing.T) {
+
+	// the ladder form keeps the overtime loss of hockey
+	objForm := BuildTeamForm(1, teamFormResults, 5, "WWlWL", "L1")
+	if objForm.Form != "WWlWL" || objForm.Streak != "L1" {
+		t.Errorf("form %q streak %q, want the ladder WWlWL L1", objForm.Form, objForm.Streak)
+	}
+}
@@ -0,0 +1,74 @@
+/*
+Package data - Handles functions related to data source access e.g. cache, databases
+*/
+package data
+
+import (
+	"strconv"
+
+	"github.com/thegeniusgroup/isgdatalib"
+)
+
+// GetTeamFormStreak : form and current streak of a team up to the season across the season ladders of the sport's
+// ladder table, like the team pages. A sport without a ladder table has no form.
+func GetTeamFormStreak(objSport isg.Sport, leagueID int, teamID int64, seasonID int) (string, string, error) {
+
+	if objSport.TableNameLadder == "" {
+		return "", "", nil
+	}
+
+	records, err := GetSportsFormStreakData(objSport.SportID, leagueID, strconv.FormatInt(teamID, 10), strconv.Itoa(seasonID), objSport.TableNameLadder)
+	if err != nil {
+		return "", "", err
+	}
+
+	// seasons without a result yet have no streak
+	var played []isg.SportsFormStreak
+	for _, record := range records {
+		if len(record.Streak) > 1 && record.Form != "" {
+			played = append(played, record)
+		}
+	}
+
+	form, streak := CalculationBTKSFormStreak(objSport, played)
+	return form, streak, nil
+}
+
+// GetTeamFormResults : final scores of a team in the last seasons of a league in kick off order
+func GetTeamFormResults(objSport isg.Sport, leagueID int, teamID int64, seasons int) ([]isg.GeniusOddsModelResult, error) {
+
+	var results []isg.GeniusOddsModelResult
+
+	sqlstr := "SELECT matches.match_id, matches.season_id, matches.home_team_id, matches.away_team_id, scores.home_score, scores.away_score, matches.match_date " +
+		" FROM " + objSport.TableNameMatches + " AS matches " +
+		" INNER JOIN " + objSport.TableNameMatches + "_scores AS scores ON scores.match_id = matches.match_id " +
+		" WHERE matches.league_id = ? AND matches.status = ? AND (matches.home_team_id = ? OR matches.away_team_id = ?) " +
+		" AND matches.season_id >= (SELECT IFNULL(MIN(season_id),0) FROM (SELECT DISTINCT season_id FROM " + objSport.TableNameMatches +
+		" WHERE league_id = ? AND (home_team_id = ? OR away_team_id = ?) AND status = ? ORDER BY season_id DESC LIMIT " + strconv.Itoa(seasons) + ") AS form_seasons) " +
+		" ORDER BY matches.counter_date, matches.counter_time, matches.match_id "
+
+	rows, err := SportsDb.Query(sqlstr, leagueID, "N", teamID, teamID, leagueID, teamID, teamID, "N")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var result isg.GeniusOddsModelResult
+		err = rows.Scan(
+			&result.MatchID,
+			&result.SeasonID,
+			&result.HomeTeamID,
+			&result.AwayTeamID,
+			&result.HomeScore,
+			&result.AwayScore,
+			&result.MatchDate,
+		)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, result)
+	}
+
+	return results, nil
+}
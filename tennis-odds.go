@@ -0,0 +1,77 @@
+package sports
+
+import (
+	"data"
+	"errors"
+	"fmt"
+
+	"github.com/thegeniusgroup/isgdatalib"
+)
+
+// setGeniusOddsTennis : tournament, surface and player hands of the tennis matches
+func setGeniusOddsTennis(objMatches []isg.GeniusSportsMatch) {
+
+	var matchIDs []int64
+	for _, objmatch := range objMatches {
+		if objmatch.SportInfo.SportInternalID == 6 {
+			matchIDs = append(matchIDs, objmatch.MatchID.Int64)
+		}
+	}
+	if len(matchIDs) == 0 {
+		return
+	}
+
+	tennisMatches, err := data.GetGeniusOddsTennisMatches(matchIDs)
+	if err != nil {
+		fmt.Println(err.Error())
+		return
+	}
+
+	for i, objmatch := range objMatches {
+		if objmatch.SportInfo.SportInternalID != 6 {
+			continue
+		}
+		if matchinfo, ok := tennisMatches[objmatch.MatchID.Int64]; ok {
+			objMatches[i].TennisInfo = &matchinfo
+		}
+	}
+}
+
+// resolveGeniusOddsTennisSlug : match id of the tournament, round and player slugs, the players can be in either order
+func resolveGeniusOddsTennisSlug(objsport isg.Sport, objleague isg.League, tournament, round, player1, player2 string) (int, error) {
+
+	if round == "" {
+		return 0, errors.New("round not found")
+	}
+	if player1 == "" || player2 == "" {
+		return 0, errors.New("players not found")
+	}
+
+	tournamentID, err := data.GetTennisTournament(objleague.LeagueInternalID, tournament)
+	if err != nil {
+		return 0, errors.New("tournament not found")
+	}
+
+	objRound, _, err := data.GetRoundWeekDetails(objsport, objleague, round)
+	if err != nil || len(objRound) == 0 {
+		return 0, errors.New("round not found")
+	}
+
+	player1ID, err := data.GetTennisPlayer(objleague.LeagueInternalID, player1)
+	if err != nil {
+		return 0, errors.New("player1 not found")
+	}
+
+	player2ID, err := data.GetTennisPlayer(objleague.LeagueInternalID, player2)
+	if err != nil {
+		return 0, errors.New("player2 not found")
+	}
+
+	matchIDs, err := data.GetGeniusMarketTennisMatchIDs(objleague.LeagueInternalID, tournamentID, objRound[0].RoundID, player1ID, player2ID)
+	if err != nil {
+		fmt.Println(err.Error())
+		return 0, errors.New("unable to get match record")
+	}
+
+	return isg.SelectGeniusOddsMatchGame(matchIDs, 1)
+}
@@ -0,0 +1,90 @@
+/*
+Package data - Handles functions related to data source access e.g. cache, databases
+*/
+package data
+
+import (
+	"github.com/thegeniusgroup/isgdatalib"
+)
+
+// geniusOddsMatchColumns : home, away and league columns of the genius odds match queries, tennis matches are
+// player1 v player2 of a level
+func geniusOddsMatchColumns(sportID int) (string, string, string) {
+	if sportID == 6 {
+		return "matches.player1_id", "matches.player2_id", "matches.level_id"
+	}
+	return "matches.home_team_id", "matches.away_team_id", "matches.league_id"
+}
+
+// GetGeniusOddsTennisMatches : tournament, surface and player hands of tennis matches, keyed by match id
+func GetGeniusOddsTennisMatches(matchIDs []int64) (map[int64]isg.MatchInfo, error) {
+
+	records := map[int64]isg.MatchInfo{}
+
+	if len(matchIDs) == 0 {
+		return records, nil
+	}
+
+	sqlstr := "SELECT matches.match_id, IFNULL(tournament.isg_api_id,''), IFNULL(tournament.filter_name,''), IFNULL(tournament.tournament_url,''), " +
+		" IFNULL(country.country,''), IFNULL(surface.surface_name,''), IFNULL(surface.surface_url,''), player1.hand_position, player2.hand_position " +
+		" FROM isg_tennis_matches AS matches " +
+		" LEFT JOIN isg_tennis_tournament tournament ON tournament.tournament_id = matches.tournament_id " +
+		" LEFT JOIN isg_tennis_surface surface ON surface.surface_id = tournament.surface_id " +
+		" LEFT JOIN isg_tennis_players player1 ON player1.player_id = matches.player1_id " +
+		" LEFT JOIN isg_tennis_players player2 ON player2.player_id = matches.player2_id " +
+		" LEFT JOIN isg_tennis_tournament_season_venue season_venue ON season_venue.tournament_id = matches.tournament_id AND season_venue.level_id = matches.level_id " +
+		" AND season_venue.season_id = matches.season_id " +
+		" LEFT JOIN isg_venue ON isg_venue.venue_id = season_venue.venue_id " +
+		" LEFT JOIN isg_country country ON country.country_id = isg_venue.country " +
+		" WHERE matches.match_id IN (" + matchIDList(matchIDs) + ") "
+
+	rows, err := SportsDb.Query(sqlstr)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var matchID int64
+		var matchinfo isg.MatchInfo
+		err = rows.Scan(&matchID, &matchinfo.TournamentID, &matchinfo.TournamentFilterName, &matchinfo.TournamentURL, &matchinfo.TournamentCountryName,
+			&matchinfo.SurfaceName, &matchinfo.SurfaceURL, &matchinfo.Player1HandPostion, &matchinfo.Player2HandPostion)
+		if err != nil {
+			return nil, err
+		}
+		matchinfo.MatchID = int(matchID)
+		records[matchID] = matchinfo
+	}
+
+	return records, nil
+}
+
+// GetGeniusMarketTennisMatchIDs : upcoming matches of the two players in the tournament round, either player may be player1.
+// The latest season comes first.
+func GetGeniusMarketTennisMatchIDs(levelID, tournamentID, roundID, player1ID, player2ID int) ([]int, error) {
+
+	var matchIDs []int
+
+	sqlstr := "SELECT matches.match_id FROM isg_tennis_matches AS matches " +
+		" INNER JOIN isg_tennis_tournament_season_round AS seasonround ON seasonround.match_id = matches.match_id " +
+		" WHERE matches.level_id = ? AND matches.tournament_id = ? AND seasonround.round_id = ? " +
+		" AND ((matches.player1_id = ? AND matches.player2_id = ?) OR (matches.player1_id = ? AND matches.player2_id = ?)) AND matches.status = ? " +
+		" ORDER BY matches.season_id DESC, matches.match_date, matches.match_time, matches.match_id"
+
+	rows, err := SportsDb.Query(sqlstr, levelID, tournamentID, roundID, player1ID, player2ID, player2ID, player1ID, "Y")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var matchID int
+		err = rows.Scan(&matchID)
+		if err != nil {
+			return nil, err
+		}
+		matchIDs = append(matchIDs, matchID)
+	}
+
+	return matchIDs, nil
+}
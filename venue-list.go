@@ -0,0 +1,143 @@
+package sports
+
+import (
+	"data"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+	"util"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/thegeniusgroup/isgdatalib"
+)
+
+// geniusOddsTravelTTL : how long the venues of a sport are kept for the travel
+const geniusOddsTravelTTL = 6 * time.Hour
+
+// geniusOddsTravel : venues of a sport by isg_venue id, loaded on first use and after the ttl
+type geniusOddsTravel struct {
+	venues map[int]isg.Venue
+	list   []isg.Venue
+	loaded time.Time
+}
+
+var geniusOddsTravels = map[int]*geniusOddsTravel{}
+var geniusOddsTravelsLock sync.RWMutex
+
+// Venues : venues of a sport with their location
+// GET  /venues/{:sport}
+func Venues(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+
+	sportname := util.CleanText(p.ByName("sport"), true, true)
+
+	objsport, err := data.GetSport(sportname)
+	if err != nil {
+		util.WebResponse(w, r, http.StatusNotFound, "sport not found")
+		return
+	}
+
+	t, err := data.GetVenues(objsport)
+	if err != nil {
+		fmt.Println(err.Error())
+		util.WebResponse(w, r, http.StatusNotFound, "record not found")
+		return
+	}
+	if len(t) == 0 {
+		util.WebResponse(w, r, http.StatusNotFound, "record not found")
+		return
+	}
+
+	final := util.JSONMessageWrappedObj(http.StatusOK, t)
+	util.WebResponseJSONObjectNoCache(w, r, http.StatusOK, final)
+	return
+}
+
+// Venue : a venue of a sport by id or name
+// GET  /venues/{:sport}/{:venue}
+func Venue(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+
+	sportname := util.CleanText(p.ByName("sport"), true, true)
+	venuename := util.CleanText(p.ByName("venue"), true, true)
+
+	objsport, err := data.GetSport(sportname)
+	if err != nil {
+		util.WebResponse(w, r, http.StatusNotFound, "sport not found")
+		return
+	}
+
+	t, err := data.GetVenue(objsport, venuename)
+	if err != nil {
+		util.WebResponse(w, r, http.StatusNotFound, "venue not found")
+		return
+	}
+
+	final := util.JSONMessageWrappedObj(http.StatusOK, t)
+	util.WebResponseJSONObjectNoCache(w, r, http.StatusOK, final)
+	return
+}
+
+// setGeniusOddsTravel : attaches the travel of the home and away team to each match, one location query per sport
+func setGeniusOddsTravel(objMatches []isg.GeniusSportsMatch) {
+
+	sportMatches := map[int][]int64{}
+	sportInfo := map[int]isg.Sport{}
+	for _, objmatch := range objMatches {
+		if objmatch.SportInfo.SportID == "te" || !objmatch.VenueInternalID.Valid {
+			continue
+		}
+		sportMatches[objmatch.SportInfo.SportInternalID] = append(sportMatches[objmatch.SportInfo.SportInternalID], objmatch.MatchID.Int64)
+		sportInfo[objmatch.SportInfo.SportInternalID] = objmatch.SportInfo
+	}
+
+	locations := map[int]map[int64]isg.MatchInfo{}
+	for sportID, matchIDs := range sportMatches {
+		sportLocations, err := data.GetGeniusOddsMatchLocations(sportInfo[sportID], matchIDs)
+		if err != nil {
+			fmt.Println(err.Error())
+			continue
+		}
+		locations[sportID] = sportLocations
+	}
+
+	for i, objmatch := range objMatches {
+
+		objLocation, ok := locations[objmatch.SportInfo.SportInternalID][objmatch.MatchID.Int64]
+		if !ok {
+			continue
+		}
+
+		objTravel := getGeniusOddsTravel(objmatch.SportInfo)
+		objMatches[i].HomeTravelInfo, objMatches[i].AwayTravelInfo = isg.BuildMatchTravel(objLocation,
+			objTravel.venues[int(objmatch.VenueInternalID.Int64)], objTravel.list)
+	}
+}
+
+// getGeniusOddsTravel : venues of the sport, reloaded once the ttl has passed
+func getGeniusOddsTravel(objsport isg.Sport) *geniusOddsTravel {
+
+	geniusOddsTravelsLock.RLock()
+	objTravel, ok := geniusOddsTravels[objsport.SportInternalID]
+	geniusOddsTravelsLock.RUnlock()
+	if ok && time.Since(objTravel.loaded) < geniusOddsTravelTTL {
+		return objTravel
+	}
+
+	// failed loads are kept until the ttl as well, so a missing table is not queried on every request
+	objTravel = &geniusOddsTravel{venues: map[int]isg.Venue{}, loaded: time.Now()}
+
+	venues, err := data.GetVenues(objsport)
+	if err != nil {
+		fmt.Println(err.Error())
+	}
+	objTravel.list = venues
+	for _, objVenue := range venues {
+		objTravel.venues[objVenue.VenueInternalID] = objVenue
+	}
+
+	geniusOddsTravelsLock.Lock()
+	geniusOddsTravels[objsport.SportInternalID] = objTravel
+	geniusOddsTravelsLock.Unlock()
+
+	return objTravel
+}
@@ -0,0 +1,117 @@
+/*
+Package data - Handles functions related to data source access e.g. cache, databases
+*/
+package data
+
+import (
+	"github.com/thegeniusgroup/isgdatalib"
+)
+
+// venueColumns : venue details with the location, VenueInternalID is the isg_venue id
+const venueColumns = "SELECT venue.venue_id, IFNULL(venue.isg_api_id,''), IFNULL(venue.venue,''), IFNULL(venue.filtername,''), IFNULL(venue.city,''), " +
+	" IFNULL(venue.state,''), IFNULL(country.country,''), IFNULL(country.continent,''), IFNULL(venue.latitude,''), IFNULL(venue.longitude,''), " +
+	" IFNULL(venue.timezone,'') " +
+	" FROM isg_venue venue " +
+	" LEFT JOIN isg_country country ON country.country_id = venue.country "
+
+// GetVenues : venues of a sport with their location
+func GetVenues(objSport isg.Sport) ([]isg.Venue, error) {
+
+	var records []isg.Venue
+
+	rows, err := SportsDb.Query(venueColumns+" WHERE venue.sports = ? ORDER BY venue.filtername ", objSport.SportInternalID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		objVenue, err := scanVenue(rows)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, objVenue)
+	}
+
+	return records, nil
+}
+
+// GetVenue : venue of a sport by id, isg api id or name
+func GetVenue(objSport isg.Sport, venue string) (isg.Venue, error) {
+
+	objVenue, err := GetVenueDetails(venue, objSport)
+	if err != nil {
+		return objVenue, err
+	}
+
+	row := SportsDb.QueryRow(venueColumns+" WHERE venue.venue_id = ? ", objVenue.VenueID)
+	return scanVenue(row)
+}
+
+// scanVenue :
+func scanVenue(row interface{ Scan(...interface{}) error }) (isg.Venue, error) {
+
+	var objVenue isg.Venue
+	err := row.Scan(
+		&objVenue.VenueInternalID,
+		&objVenue.VenueID,
+		&objVenue.VenueName,
+		&objVenue.VenueFilterName,
+		&objVenue.VenueCity,
+		&objVenue.VenueState,
+		&objVenue.VenueCountry,
+		&objVenue.Continent,
+		&objVenue.Latitude,
+		&objVenue.Longitude,
+		&objVenue.Timezone,
+	)
+	return objVenue, err
+}
+
+// GetGeniusOddsMatchLocations : state, country and continent of the teams and venue of each match, keyed by match id.
+// Teams are placed by the state and country columns of isg_team, the same as isg_venue. The home venue of a team is the
+// venue of most of its played home matches in the league, NULL when it has played none.
+func GetGeniusOddsMatchLocations(objSport isg.Sport, matchIDs []int64) (map[int64]isg.MatchInfo, error) {
+
+	locations := map[int64]isg.MatchInfo{}
+
+	if len(matchIDs) == 0 {
+		return locations, nil
+	}
+
+	homeVenueSQL := func(teamColumn string) string {
+		return "(SELECT ground.venue_id FROM " + objSport.TableNameMatches + " AS ground " +
+			" WHERE ground.home_team_id = matches." + teamColumn + " AND ground.league_id = matches.league_id AND ground.status = 'N' AND ground.venue_id > 0 " +
+			" GROUP BY ground.venue_id ORDER BY COUNT(*) DESC, ground.venue_id LIMIT 1)"
+	}
+
+	sqlstr := "SELECT matches.match_id, h_team.state, h_country.country, h_country.continent, a_team.state, a_country.country, a_country.continent, " +
+		" venue.state, v_country.country, v_country.continent, " + homeVenueSQL("home_team_id") + ", " + homeVenueSQL("away_team_id") +
+		" FROM " + objSport.TableNameMatches + " AS matches " +
+		" LEFT JOIN isg_team AS h_team ON h_team.team_id = matches.home_team_id " +
+		" LEFT JOIN isg_country AS h_country ON h_country.country_id = h_team.country " +
+		" LEFT JOIN isg_team AS a_team ON a_team.team_id = matches.away_team_id " +
+		" LEFT JOIN isg_country AS a_country ON a_country.country_id = a_team.country " +
+		" LEFT JOIN isg_venue AS venue ON venue.venue_id = matches.venue_id " +
+		" LEFT JOIN isg_country AS v_country ON v_country.country_id = venue.country " +
+		" WHERE matches.match_id IN (" + matchIDList(matchIDs) + ") "
+
+	rows, err := SportsDb.Query(sqlstr)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var matchinfo isg.MatchInfo
+		err = rows.Scan(&matchinfo.MatchID, &matchinfo.HomeTeamState, &matchinfo.HomeCountry, &matchinfo.HomeContinent,
+			&matchinfo.AwayTeamState, &matchinfo.AwayCountry, &matchinfo.AwayContinent,
+			&matchinfo.VenueState, &matchinfo.VenueCountry, &matchinfo.VenueContinent, &matchinfo.HomeVenueInternalID, &matchinfo.AwayVenueInternalID)
+		if err != nil {
+			return nil, err
+		}
+		locations[int64(matchinfo.MatchID)] = matchinfo
+	}
+
+	return locations, nil
+}
@@ -0,0 +1,124 @@
+package isg
+
+import (
+	"database/sql"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// earthRadiusKm : mean radius of the earth used by the travel distances
+const earthRadiusKm = 6371.0
+
+// TeamTravel : travel of a team from where it is based to the match venue. Interstate is a different state of the same
+// country, Overseas a different country, or a different continent when a country is unknown. Distance is measured from
+// the team's home venue and left out when the team has none.
+type TeamTravel struct {
+	HomeState   string   `json:"home_state,omitempty"`
+	HomeCountry string   `json:"home_country,omitempty"`
+	Distance    *float64 `json:"distance_km,omitempty"`
+	Interstate  bool     `json:"interstate"`
+	Overseas    bool     `json:"overseas"`
+}
+
+// VenueDistance : great circle distance between two venues in km, false when a venue has no coordinates
+func VenueDistance(from, to Venue) (float64, bool) {
+
+	lat1, lon1, ok := venueCoordinates(from)
+	if !ok {
+		return 0, false
+	}
+	lat2, lon2, ok := venueCoordinates(to)
+	if !ok {
+		return 0, false
+	}
+	return coordinateDistance(lat1, lon1, lat2, lon2), true
+}
+
+// BuildMatchTravel : travel of the home and away team from the state, country and continent of the teams and venue in
+// the match info. The distance is taken from the team's home venue, found in the venues, to the match venue.
+// A team without a state or country gets no travel.
+func BuildMatchTravel(objMatchInfo MatchInfo, venue Venue, venues []Venue) (*TeamTravel, *TeamTravel) {
+
+	home := buildTeamTravel(objMatchInfo.HomeTeamState, objMatchInfo.HomeCountry, objMatchInfo.HomeContinent, objMatchInfo.HomeVenueInternalID, objMatchInfo, venue, venues)
+	away := buildTeamTravel(objMatchInfo.AwayTeamState, objMatchInfo.AwayCountry, objMatchInfo.AwayContinent, objMatchInfo.AwayVenueInternalID, objMatchInfo, venue, venues)
+	return home, away
+}
+
+// buildTeamTravel :
+func buildTeamTravel(state, country, continent sql.NullString, homeVenueID sql.NullInt64, objMatchInfo MatchInfo, venue Venue, venues []Venue) *TeamTravel {
+
+	teamState := strings.TrimSpace(state.String)
+	teamCountry := strings.TrimSpace(country.String)
+	if teamState == "" && teamCountry == "" {
+		return nil
+	}
+
+	objTravel := &TeamTravel{HomeState: teamState, HomeCountry: teamCountry}
+
+	venueState := strings.TrimSpace(objMatchInfo.VenueState.String)
+	venueCountry := strings.TrimSpace(objMatchInfo.VenueCountry.String)
+	teamContinent := strings.TrimSpace(continent.String)
+	venueContinent := strings.TrimSpace(objMatchInfo.VenueContinent.String)
+
+	switch {
+	case teamCountry != "" && venueCountry != "":
+		objTravel.Overseas = !strings.EqualFold(teamCountry, venueCountry)
+		if !objTravel.Overseas && teamState != "" && venueState != "" {
+			objTravel.Interstate = !strings.EqualFold(teamState, venueState)
+		}
+	case teamContinent != "" && venueContinent != "":
+		objTravel.Overseas = !strings.EqualFold(teamContinent, venueContinent)
+	}
+
+	lat1, lon1, ok := homeCoordinates(homeVenueID, venues)
+	if !ok {
+		return objTravel
+	}
+	lat2, lon2, ok := venueCoordinates(venue)
+	if !ok {
+		return objTravel
+	}
+	distance := Round(coordinateDistance(lat1, lon1, lat2, lon2), .5, 0)
+	objTravel.Distance = &distance
+
+	return objTravel
+}
+
+// homeCoordinates : coordinates of the team's home venue, false when the team has no home venue or it has no coordinates
+func homeCoordinates(homeVenueID sql.NullInt64, venues []Venue) (float64, float64, bool) {
+
+	if !homeVenueID.Valid {
+		return 0, 0, false
+	}
+
+	for _, objVenue := range venues {
+		if int64(objVenue.VenueInternalID) == homeVenueID.Int64 {
+			return venueCoordinates(objVenue)
+		}
+	}
+	return 0, 0, false
+}
+
+// coordinateDistance : great circle distance in km
+func coordinateDistance(lat1, lon1, lat2, lon2 float64) float64 {
+
+	dLat := (lat2 - lat1) * math.Pi / 180
+	dLon := (lon2 - lon1) * math.Pi / 180
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1*math.Pi/180)*math.Cos(lat2*math.Pi/180)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return earthRadiusKm * 2 * math.Atan2(math.Sqrt(a), math.Sqrt(1-a))
+}
+
+// venueCoordinates :
+func venueCoordinates(objVenue Venue) (float64, float64, bool) {
+
+	lat, err := strconv.ParseFloat(strings.TrimSpace(objVenue.Latitude), 64)
+	if err != nil || lat < -90 || lat > 90 {
+		return 0, 0, false
+	}
+	lon, err := strconv.ParseFloat(strings.TrimSpace(objVenue.Longitude), 64)
+	if err != nil || lon < -180 || lon > 180 {
+		return 0, 0, false
+	}
+	return lat, lon, true
+}
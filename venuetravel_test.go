@@ -0,0 +1,69 @@
+package isg
+
+import "testing"
+
+// travelTestVenues : MCG and Marvel in Victoria, the SCG in New South Wales and Eden Park in New Zealand
+func travelTestVenues() []Venue {
+	return []Venue{
+		{VenueInternalID: 1, VenueState: "VIC", VenueCountry: "Australia", Continent: "Oceania", Latitude: "-37.8200", Longitude: "144.9834"},
+		{VenueInternalID: 2, VenueState: "VIC", VenueCountry: "Australia", Continent: "Oceania", Latitude: "-37.8166", Longitude: "144.9475"},
+		{VenueInternalID: 3, VenueState: "NSW", VenueCountry: "Australia", Continent: "Oceania", Latitude: "-33.8917", Longitude: "151.2247"},
+		{VenueInternalID: 4, VenueState: "Auckland", VenueCountry: "New Zealand", Continent: "Oceania", Latitude: "-36.8750", Longitude: "174.7446"},
+	}
+}
+
+func TestBuildMatchTravelInterstate(t *testing.T) {
+
+	venues := travelTestVenues()
+	objMatchInfo := MatchInfo{
+		HomeTeamState: testNullString("NSW"), HomeCountry: testNullString("Australia"), HomeVenueInternalID: testNullInt(3),
+		AwayTeamState: testNullString("VIC"), AwayCountry: testNullString("Australia"), AwayVenueInternalID: testNullInt(2),
+		VenueState: testNullString("NSW"), VenueCountry: testNullString("Australia"),
+	}
+
+	home, away := BuildMatchTravel(objMatchInfo, venues[2], venues)
+	if home == nil || home.Interstate || home.Overseas || home.Distance == nil || *home.Distance != 0 {
+		t.Errorf("home travel = %+v, want a local match", home)
+	}
+	// from Marvel to the SCG
+	if away == nil || !away.Interstate || away.Overseas || away.Distance == nil || *away.Distance < 705 || *away.Distance > 720 {
+		t.Errorf("away travel = %+v, want about 712 km interstate", away)
+	}
+
+	// a home ground off the list or without coordinates gives no distance
+	venues[1].Latitude = ""
+	objMatchInfo.HomeVenueInternalID = testNullInt(9)
+	home, away = BuildMatchTravel(objMatchInfo, venues[2], venues)
+	if home == nil || home.Distance != nil || away == nil || !away.Interstate || away.Distance != nil {
+		t.Errorf("travel = %+v, %+v, want no distances", home, away)
+	}
+}
+
+func TestBuildMatchTravelOverseas(t *testing.T) {
+
+	venues := travelTestVenues()
+	objMatchInfo := MatchInfo{
+		HomeTeamState: testNullString("Auckland"), HomeCountry: testNullString("New Zealand"),
+		AwayContinent: testNullString("Oceania"), AwayTeamState: testNullString("VIC"),
+		VenueState: testNullString("Auckland"), VenueCountry: testNullString("New Zealand"), VenueContinent: testNullString("Oceania"),
+	}
+
+	home, away := BuildMatchTravel(objMatchInfo, venues[3], venues)
+	if home == nil || home.Interstate || home.Overseas {
+		t.Errorf("home travel = %+v, want a local match", home)
+	}
+	// the away country is unknown, the continent is the same and the team has no home venue to measure from
+	if away == nil || away.Overseas || away.Interstate || away.Distance != nil {
+		t.Errorf("away travel = %+v, want no flags and no distance", away)
+	}
+
+	objMatchInfo.AwayCountry = testNullString("Australia")
+	if _, away = BuildMatchTravel(objMatchInfo, venues[3], venues); away == nil || !away.Overseas || away.Interstate {
+		t.Errorf("away travel = %+v, want overseas", away)
+	}
+
+	objMatchInfo.AwayTeamState, objMatchInfo.AwayCountry = testNullString(""), testNullString("")
+	if _, away = BuildMatchTravel(objMatchInfo, venues[3], venues); away != nil {
+		t.Errorf("away travel = %+v, want none for a team without a location", away)
+	}
+}
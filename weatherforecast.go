@@ -0,0 +1,83 @@
+package isg
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"strconv"
+	"time"
+)
+
+// WeatherMatch : an upcoming match with the venue location its forecast is taken for
+type WeatherMatch struct {
+	MatchID   int64
+	SportID   int
+	LeagueID  int
+	VenueID   int64
+	Latitude  float64
+	Longitude float64
+	KickOff   time.Time
+}
+
+// WeatherForecast : forecast at the venue for the kick off, values the provider does not have are nil.
+// Temperature in Celsius, wind speed in km/h and precipitation in mm (chance in percent).
+type WeatherForecast struct {
+	Summary      string   `json:"summary"`
+	Temperature  *float64 `json:"temperature,omitempty"`
+	WindSpeed    *float64 `json:"wind_speed,omitempty"`
+	Precip       *float64 `json:"precip,omitempty"`
+	PrecipChance *float64 `json:"precip_chance,omitempty"`
+}
+
+// BindingWeatherInfo : weather of the match payloads from the forecast, updated is in the AEST counter format
+func BindingWeatherInfo(objForecast WeatherForecast, updated string) WeatherInfo {
+
+	var objWeather WeatherInfo
+	objWeather.Weather = objForecast.Summary
+	objWeather.Temperature = formatWeatherValue(objForecast.Temperature, 1)
+	objWeather.WindSpeed = formatWeatherValue(objForecast.WindSpeed, 1)
+	objWeather.Precip = formatWeatherValue(objForecast.Precip, 1)
+	objWeather.PrecipChance = formatWeatherValue(objForecast.PrecipChance, 0)
+	objWeather.Updated = updated
+	return objWeather
+}
+
+// FileWeatherProvider : forecasts from a json file keyed by venue id with an optional "default" entry, a stand-in for
+// local runs and tests e.g. {"default": {"summary": "Fine", "temperature": 18, "wind_speed": 12}}
+type FileWeatherProvider struct {
+	Forecasts map[string]WeatherForecast
+}
+
+// NewFileWeatherProvider : reads the forecasts of the file
+func NewFileWeatherProvider(path string) (FileWeatherProvider, error) {
+
+	var provider FileWeatherProvider
+
+	body, err := ioutil.ReadFile(path)
+	if err != nil {
+		return provider, err
+	}
+	err = json.Unmarshal(body, &provider.Forecasts)
+	return provider, err
+}
+
+// Forecast :
+func (provider FileWeatherProvider) Forecast(objMatch WeatherMatch) (WeatherForecast, error) {
+
+	objForecast, ok := provider.Forecasts[strconv.FormatInt(objMatch.VenueID, 10)]
+	if !ok {
+		objForecast, ok = provider.Forecasts["default"]
+	}
+	if !ok {
+		return objForecast, errors.New("no forecast for venue " + strconv.FormatInt(objMatch.VenueID, 10))
+	}
+	return objForecast, nil
+}
+
+// formatWeatherValue :
+func formatWeatherValue(val *float64, decimals int) string {
+	if val == nil {
+		return ""
+	}
+	return strconv.FormatFloat(Round(*val, .5, decimals), 'f', decimals, 64)
+}
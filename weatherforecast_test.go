@@ -0,0 +1,55 @@
+package isg
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFileWeatherProvider(t *testing.T) {
+
+	path := filepath.Join(t.TempDir(), "forecasts.json")
+	body := `{"40": {"summary": "Rain", "temperature": 11.26, "wind_speed": 30, "precip": 4.04, "precip_chance": 85},
+		"default": {"summary": "Fine", "temperature": 18}}`
+	if err := ioutil.WriteFile(path, []byte(body), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	provider, err := NewFileWeatherProvider(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	objForecast, err := provider.Forecast(WeatherMatch{MatchID: 1, VenueID: 40})
+	if err != nil {
+		t.Fatal(err)
+	}
+	objWeather := BindingWeatherInfo(objForecast, "2024-05-01 12:00:00")
+	want := WeatherInfo{Weather: "Rain", Temperature: "11.3", WindSpeed: "30.0", Precip: "4.0", PrecipChance: "85", Updated: "2024-05-01 12:00:00"}
+	if objWeather != want {
+		t.Errorf("venue 40 weather = %+v, want %+v", objWeather, want)
+	}
+
+	// venues without a forecast take the default, the missing values stay empty
+	objForecast, err = provider.Forecast(WeatherMatch{MatchID: 2, VenueID: 41})
+	if err != nil {
+		t.Fatal(err)
+	}
+	objWeather = BindingWeatherInfo(objForecast, "")
+	if objWeather.Weather != "Fine" || objWeather.Temperature != "18.0" || objWeather.WindSpeed != "" || objWeather.Precip != "" {
+		t.Errorf("default weather = %+v", objWeather)
+	}
+}
+
+func TestFileWeatherProviderNoDefault(t *testing.T) {
+
+	provider := FileWeatherProvider{Forecasts: map[string]WeatherForecast{"40": {Summary: "Rain"}}}
+	if _, err := provider.Forecast(WeatherMatch{VenueID: 41}); err == nil {
+		t.Error("forecast found for a venue without one")
+	}
+
+	if _, err := NewFileWeatherProvider(filepath.Join(os.TempDir(), "missing-forecasts.json")); err == nil {
+		t.Error("provider read from a missing file")
+	}
+}